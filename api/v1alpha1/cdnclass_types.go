@@ -20,6 +20,7 @@
 package v1alpha1
 
 import (
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -50,9 +51,19 @@ type CDNClassStatus struct {
 	Conditions []metav1.Condition `json:"conditions"`
 }
 
+// Condition types reported on CDNClass resources
+const (
+	// CDNClassConditionInUse indicates whether any group uses the CDNClass. Its message lists them.
+	CDNClassConditionInUse = "InUse"
+	// CDNClassConditionInSync indicates whether all groups using the CDNClass are ready
+	CDNClassConditionInSync = "InSync"
+)
+
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
 //+kubebuilder:resource:scope=Cluster
+//+kubebuilder:printcolumn:name="In Use",type=string,JSONPath=`.status.conditions[?(@.type=="InUse")].status`
+//+kubebuilder:printcolumn:name="In Sync",type=string,JSONPath=`.status.conditions[?(@.type=="InSync")].status`
 
 // CDNClass is the Schema for the cdnclasses API
 type CDNClass struct {
@@ -63,6 +74,17 @@ type CDNClass struct {
 	Status CDNClassStatus `json:"status,omitempty"`
 }
 
+// SetCondition sets a condition of the given type, only updating its transition time if its status changed
+func (c *CDNClass) SetCondition(conditionType string, status metav1.ConditionStatus, reason, message string) {
+	meta.SetStatusCondition(&c.Status.Conditions, metav1.Condition{
+		Type:               conditionType,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: c.Generation,
+	})
+}
+
 //+kubebuilder:object:root=true
 
 // CDNClassList contains a list of CDNClass
@@ -20,6 +20,10 @@
 package v1alpha1
 
 import (
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -46,14 +50,114 @@ type CDNStatusStatus struct {
 	// +optional
 	// +nullable
 	DNS *DNSStatus `json:"dns,omitempty"`
+	// Plan holds the changes that would be applied to the distribution, when changes are only being planned (dry-run)
+	// +optional
+	Plan []string `json:"plan,omitempty"`
+	// DeploymentStatus is the status of the latest deployment of the distribution to CloudFront's edge locations,
+	// either InProgress or Deployed
+	// +optional
+	DeploymentStatus string `json:"deploymentStatus,omitempty"`
+	// GeoRestriction is the geo restriction in place on the distribution
+	// +optional
+	// +nullable
+	GeoRestriction *GeoRestriction `json:"geoRestriction,omitempty"`
+	// DeclaredFields are the optional fields of the distribution the group declared when it was last synced.
+	// They're cleared from the distribution once no longer declared, while fields never declared are left untouched.
+	// +optional
+	DeclaredFields []OptionalField `json:"declaredFields,omitempty"`
+	// LastModifiedTime is the last time the distribution was modified on CloudFront
+	// +optional
+	// +nullable
+	LastModifiedTime *metav1.Time `json:"lastModifiedTime,omitempty"`
+	// LastSyncTime is the last time the distribution was successfully synced
+	// +optional
+	// +nullable
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+	// Deletion tracks the progress of the distribution's deletion, while it's being torn down
+	// +optional
+	// +nullable
+	Deletion *DeletionStatus `json:"deletion,omitempty"`
+	// Conditions represent the latest observations of the CDN's state
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	// +patchStrategy=merge
+	// +patchMergeKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+}
+
+// OptionalField is an optional field of the distribution, which the controller only manages once the group declares it
+// +kubebuilder:validation:Enum=CustomErrorResponses;GeoRestriction;DefaultRootObject
+type OptionalField string
+
+// Optional fields of the distribution
+const (
+	// OptionalFieldCustomErrorResponses are the custom error responses of the distribution
+	OptionalFieldCustomErrorResponses OptionalField = "CustomErrorResponses"
+	// OptionalFieldGeoRestriction is the geo restriction of the distribution
+	OptionalFieldGeoRestriction OptionalField = "GeoRestriction"
+	// OptionalFieldDefaultRootObject is the default root object of the distribution
+	OptionalFieldDefaultRootObject OptionalField = "DefaultRootObject"
+)
+
+// DeletionPhase is a step of the deletion of a distribution
+// +kubebuilder:validation:Enum=Disabling;WaitingForDeploy;Deleting;OACCleanup
+type DeletionPhase string
+
+// Deletion phases, in the order they happen
+const (
+	// DeletionPhaseDisabling means the distribution is about to be disabled
+	DeletionPhaseDisabling DeletionPhase = "Disabling"
+	// DeletionPhaseWaitingForDeploy means the distribution was disabled and CloudFront is deploying that change,
+	// which must finish before it can be deleted
+	DeletionPhaseWaitingForDeploy DeletionPhase = "WaitingForDeploy"
+	// DeletionPhaseDeleting means the distribution is disabled and deployed, and is about to be deleted
+	DeletionPhaseDeleting DeletionPhase = "Deleting"
+	// DeletionPhaseOACCleanup means the distribution was deleted and the OACs it used are about to be deleted
+	DeletionPhaseOACCleanup DeletionPhase = "OACCleanup"
+)
+
+// DeletionStatus provides status regarding the deletion of the distribution
+type DeletionStatus struct {
+	// Phase is the current step of the deletion
+	Phase DeletionPhase `json:"phase"`
+	// OACs are the IDs of the OACs the distribution used, to be deleted once the distribution is gone
+	// +optional
+	OACs []string `json:"oacs,omitempty"`
+	// LastTransitionTime is when the deletion last moved to another phase
+	// +optional
+	// +nullable
+	LastTransitionTime *metav1.Time `json:"lastTransitionTime,omitempty"`
 }
 
+// Condition types reported by CDNStatus
+const (
+	// ConditionReady is true when all other conditions are true, except for ConditionDrifted
+	ConditionReady = "Ready"
+	// ConditionDistributionReady is true when the distribution matches the desired state
+	ConditionDistributionReady = "DistributionReady"
+	// ConditionDNSReady is true when DNS records for all aliases are in place. Only reported if creating aliases is enabled.
+	ConditionDNSReady = "DNSReady"
+	// ConditionCertificateResolved is true when a TLS certificate was found for the aliases. Only reported if TLS is enabled.
+	ConditionCertificateResolved = "CertificateResolved"
+	// ConditionWAFAttached is true when the desired Web ACL is associated with the distribution. Only reported if a Web ACL is desired.
+	ConditionWAFAttached = "WAFAttached"
+	// ConditionDeployed is true when the latest changes to the distribution have been deployed to all edge locations
+	ConditionDeployed = "Deployed"
+	// ConditionDrifted is true when the distribution was changed outside of the controller and the changes were not reverted.
+	// Only reported if drift detection is enabled. Unlike other conditions, it does not affect ConditionReady.
+	ConditionDrifted = "Drifted"
+)
+
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
 //+kubebuilder:resource:scope=Cluster
 //+kubebuilder:printcolumn:name="ID",type=string,JSONPath=`.status.id`
 //+kubebuilder:printcolumn:name="Aliases",type=string,JSONPath=`.status.aliases`
 //+kubebuilder:printcolumn:name="Address",type=string,JSONPath=`.status.address`
+//+kubebuilder:printcolumn:name="Deployment",type=string,JSONPath=`.status.deploymentStatus`,priority=1
+//+kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+//+kubebuilder:printcolumn:name="Deletion",type=string,JSONPath=`.status.deletion.phase`,priority=1
 
 // CDNStatus is the Schema for the cdnstatuses API
 type CDNStatus struct {
@@ -73,6 +177,30 @@ func (c *CDNStatus) SetAliases(aliases []string) {
 	c.Status.Aliases = aliases
 }
 
+// SetGeoRestriction sets the geo restriction in place on the CDN
+func (c *CDNStatus) SetGeoRestriction(restrictionType string, countries []string) {
+	var codes []CountryCode
+	for _, it := range countries {
+		codes = append(codes, CountryCode(it))
+	}
+	c.Status.GeoRestriction = &GeoRestriction{Type: GeoRestrictionType(restrictionType), Countries: codes}
+}
+
+// SetDeclaredFields sets the optional fields of the distribution the group declared when it was last synced
+func (c *CDNStatus) SetDeclaredFields(fields []OptionalField) {
+	c.Status.DeclaredFields = fields
+}
+
+// IsDeclaredField returns whether the group declared the given optional field of the distribution when it was last synced
+func (c *CDNStatus) IsDeclaredField(field OptionalField) bool {
+	for _, f := range c.Status.DeclaredFields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
 // UpsertDNSRecords inserts the given records at the DNS status section if they're not present already
 func (c *CDNStatus) UpsertDNSRecords(records []string) {
 	if len(records) == 0 {
@@ -148,6 +276,17 @@ func (c *CDNStatus) HasIngressRef(ing namespacedName) bool {
 	return ok
 }
 
+// FailedIngresses returns how many of the referenced Ingresses are in Failed state
+func (c *CDNStatus) FailedIngresses() int {
+	count := 0
+	for _, status := range c.Status.Ingresses {
+		if status == failedIngressStatus {
+			count++
+		}
+	}
+	return count
+}
+
 // GetIngressKeys returns keys to manipulate all IngressRef stored in the CDNStatus
 func (c *CDNStatus) GetIngressKeys() []client.ObjectKey {
 	var keys []types.NamespacedName
@@ -172,6 +311,78 @@ func (c *CDNStatus) SetInfo(id, arn, address string) {
 	c.Status.Address = address
 }
 
+// SetPlan sets the changes that would be applied to the distribution. A nil plan means changes are being applied.
+func (c *CDNStatus) SetPlan(plan []string) {
+	c.Status.Plan = plan
+}
+
+// SetCondition sets a condition of the given type, only updating its transition time if its status changed
+func (c *CDNStatus) SetCondition(conditionType string, status metav1.ConditionStatus, reason, message string) {
+	meta.SetStatusCondition(&c.Status.Conditions, metav1.Condition{
+		Type:    conditionType,
+		Status:  status,
+		Reason:  reason,
+		Message: message,
+	})
+}
+
+// RemoveCondition removes the condition of the given type, if present
+func (c *CDNStatus) RemoveCondition(conditionType string) {
+	meta.RemoveStatusCondition(&c.Status.Conditions, conditionType)
+}
+
+// IsConditionTrue returns whether the condition of the given type is present and true
+func (c *CDNStatus) IsConditionTrue(conditionType string) bool {
+	return meta.IsStatusConditionTrue(c.Status.Conditions, conditionType)
+}
+
+// UpdateReadyCondition sets the Ready condition based on all other conditions but Drifted: it is only true if all
+// of them are true. Otherwise, it reports the reason and message of the first condition which isn't.
+func (c *CDNStatus) UpdateReadyCondition() {
+	for _, cond := range c.Status.Conditions {
+		if cond.Type == ConditionReady || cond.Type == ConditionDrifted || cond.Status == metav1.ConditionTrue {
+			continue
+		}
+		c.SetCondition(ConditionReady, metav1.ConditionFalse, cond.Reason, fmt.Sprintf("%s: %s", cond.Type, cond.Message))
+		return
+	}
+	c.SetCondition(ConditionReady, metav1.ConditionTrue, "Ready", "CDN is ready")
+}
+
+// SetSynced records the distribution has been successfully synced at the given time
+func (c *CDNStatus) SetSynced(at metav1.Time) {
+	c.Status.LastSyncTime = &at
+}
+
+// SetDeployment sets the deployment status and last modified time of the distribution. A zero lastModified is ignored.
+func (c *CDNStatus) SetDeployment(status string, lastModified time.Time) {
+	c.Status.DeploymentStatus = status
+	if !lastModified.IsZero() {
+		t := metav1.NewTime(lastModified)
+		c.Status.LastModifiedTime = &t
+	}
+}
+
+// SetDeletionPhase moves the deletion of the distribution to the given phase, starting it if needed
+func (c *CDNStatus) SetDeletionPhase(phase DeletionPhase, at time.Time) {
+	if c.Status.Deletion == nil {
+		c.Status.Deletion = &DeletionStatus{}
+	}
+	t := metav1.NewTime(at)
+	c.Status.Deletion.Phase = phase
+	c.Status.Deletion.LastTransitionTime = &t
+}
+
+// FinishDeletion clears the deletion status, once the distribution was deleted or its deletion was abandoned
+func (c *CDNStatus) FinishDeletion() {
+	c.Status.Deletion = nil
+}
+
+// DeletionInProgress returns whether the distribution is being deleted
+func (c *CDNStatus) DeletionInProgress() bool {
+	return c.Status.Deletion != nil
+}
+
 // Exists returns whether the CDNStatus exists on Kubernetes or not
 func (c *CDNStatus) Exists() bool {
 	return c.ObjectMeta.ResourceVersion != ""
@@ -2,9 +2,11 @@ package v1alpha1
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/suite"
 	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -148,3 +150,100 @@ func (s *CDNStatusTestSuite) Test_GetIngressKeys() {
 		s.ElementsMatchf(tc.want, got, "test case: %s", tc.name)
 	}
 }
+
+func (s *CDNStatusTestSuite) Test_FailedIngresses() {
+	cdnStatus := &CDNStatus{
+		Status: CDNStatusStatus{
+			Ingresses: IngressRefs{
+				"namespace/failed":         failedIngressStatus,
+				"namespace/another-failed": failedIngressStatus,
+				"namespace/synced":         syncedIngressStatus,
+			},
+		},
+	}
+
+	s.Equal(2, cdnStatus.FailedIngresses())
+	s.Equal(0, (&CDNStatus{}).FailedIngresses())
+}
+
+func (s *CDNStatusTestSuite) Test_UpdateReadyCondition_AllConditionsTrue() {
+	cdnStatus := &CDNStatus{}
+	cdnStatus.SetCondition(ConditionDistributionReady, metav1.ConditionTrue, "Synced", "synced")
+	cdnStatus.SetCondition(ConditionDeployed, metav1.ConditionTrue, "Deployed", "deployed")
+
+	cdnStatus.UpdateReadyCondition()
+
+	s.True(cdnStatus.IsConditionTrue(ConditionReady))
+}
+
+func (s *CDNStatusTestSuite) Test_UpdateReadyCondition_SomeConditionIsFalse() {
+	cdnStatus := &CDNStatus{}
+	cdnStatus.SetCondition(ConditionDistributionReady, metav1.ConditionTrue, "Synced", "synced")
+	cdnStatus.SetCondition(ConditionDNSReady, metav1.ConditionFalse, "SyncFailed", "some error")
+
+	cdnStatus.UpdateReadyCondition()
+
+	s.False(cdnStatus.IsConditionTrue(ConditionReady))
+	ready := meta.FindStatusCondition(cdnStatus.Status.Conditions, ConditionReady)
+	s.Equal("SyncFailed", ready.Reason)
+	s.Equal("DNSReady: some error", ready.Message)
+}
+
+func (s *CDNStatusTestSuite) Test_UpdateReadyCondition_IgnoresDrifted() {
+	cdnStatus := &CDNStatus{}
+	cdnStatus.SetCondition(ConditionDistributionReady, metav1.ConditionTrue, "Synced", "synced")
+	cdnStatus.SetCondition(ConditionDrifted, metav1.ConditionFalse, "NoDrift", "no drift")
+
+	cdnStatus.UpdateReadyCondition()
+
+	s.True(cdnStatus.IsConditionTrue(ConditionReady))
+}
+
+func (s *CDNStatusTestSuite) Test_SetCondition_KeepsTransitionTimeIfStatusDoesNotChange() {
+	cdnStatus := &CDNStatus{}
+	cdnStatus.SetCondition(ConditionDeployed, metav1.ConditionFalse, "InProgress", "deploying")
+	first := meta.FindStatusCondition(cdnStatus.Status.Conditions, ConditionDeployed).LastTransitionTime
+
+	cdnStatus.SetCondition(ConditionDeployed, metav1.ConditionFalse, "InProgress", "still deploying")
+
+	cond := meta.FindStatusCondition(cdnStatus.Status.Conditions, ConditionDeployed)
+	s.Equal(first, cond.LastTransitionTime)
+	s.Equal("still deploying", cond.Message)
+}
+
+func (s *CDNStatusTestSuite) Test_SetDeletionPhase_KeepsOACsAcrossPhases() {
+	c := &CDNStatus{}
+	s.False(c.DeletionInProgress())
+
+	c.SetDeletionPhase(DeletionPhaseDisabling, time.Now())
+	s.True(c.DeletionInProgress())
+	c.Status.Deletion.OACs = []string{"oac"}
+
+	c.SetDeletionPhase(DeletionPhaseWaitingForDeploy, time.Now())
+	s.Equal(DeletionPhaseWaitingForDeploy, c.Status.Deletion.Phase)
+	s.Equal([]string{"oac"}, c.Status.Deletion.OACs)
+	s.NotNil(c.Status.Deletion.LastTransitionTime)
+
+	c.FinishDeletion()
+	s.False(c.DeletionInProgress())
+}
+
+func (s *CDNStatusTestSuite) Test_SetGeoRestriction() {
+	c := &CDNStatus{}
+	c.SetGeoRestriction("allow", []string{"BR", "MX"})
+	s.Equal(&GeoRestriction{Type: "allow", Countries: []CountryCode{"BR", "MX"}}, c.Status.GeoRestriction)
+
+	c.SetGeoRestriction("none", nil)
+	s.Equal(&GeoRestriction{Type: "none"}, c.Status.GeoRestriction)
+}
+
+func (s *CDNStatusTestSuite) Test_DeclaredFields() {
+	c := &CDNStatus{}
+	s.False(c.IsDeclaredField(OptionalFieldCustomErrorResponses))
+
+	c.SetDeclaredFields([]OptionalField{OptionalFieldCustomErrorResponses})
+	s.True(c.IsDeclaredField(OptionalFieldCustomErrorResponses))
+
+	c.SetDeclaredFields(nil)
+	s.False(c.IsDeclaredField(OptionalFieldCustomErrorResponses))
+}
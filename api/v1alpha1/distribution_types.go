@@ -0,0 +1,305 @@
+// Copyright (c) 2023 GPBR Participacoes LTDA.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DistributionSpec defines the desired state of Distribution
+type DistributionSpec struct {
+	// Group is the CDN group this Distribution is part of. Origins declared here are merged with
+	// origins from Ingresses and other Distributions of the same group into a single CloudFront distribution
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Group string `json:"group"`
+	// Class is the name of the CDNClass this Distribution belongs to
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Class string `json:"class"`
+	// Origins are the origins and their cache behaviors that should be part of the distribution
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	Origins []DistributionOrigin `json:"origins"`
+	// AlternateDomainNames are the aliases that should be configured on the distribution
+	// +optional
+	AlternateDomainNames []string `json:"alternateDomainNames,omitempty"`
+	// WebACLARN is the AWS WAF web ACL that should be associated with the distribution
+	// +optional
+	WebACLARN string `json:"webACLARN,omitempty"`
+	// Tags are custom tags to be added to the distribution
+	// +optional
+	Tags map[string]string `json:"tags,omitempty"`
+	// AdoptDistributionID is the ID of an existing CloudFront distribution, not created by the controller,
+	// that should be adopted by the group instead of creating a new one. Ignored once the group already
+	// has a distribution
+	// +kubebuilder:validation:Pattern=`^[A-Z0-9]+$`
+	// +optional
+	AdoptDistributionID string `json:"adoptDistributionID,omitempty"`
+	// CustomErrorResponses configure how the distribution responds to viewers when an origin returns an error
+	// +optional
+	CustomErrorResponses []CustomErrorResponse `json:"customErrorResponses,omitempty"`
+	// GeoRestriction restricts which countries viewers can access the distribution from.
+	// If not set, any restriction already in place is kept
+	// +optional
+	GeoRestriction *GeoRestriction `json:"geoRestriction,omitempty"`
+	// DefaultRootObject is the object CloudFront returns when viewers request the root URL, such as index.html.
+	// If not set, the existing default root object is kept. If empty, the distribution has no default root object
+	// +kubebuilder:validation:Pattern=`^([^/\s]\S*)?$`
+	// +kubebuilder:validation:MaxLength=255
+	// +optional
+	DefaultRootObject *string `json:"defaultRootObject,omitempty"`
+}
+
+// GeoRestrictionType is how a GeoRestriction treats its countries
+// +kubebuilder:validation:Enum=allow;deny;none
+type GeoRestrictionType string
+
+// CountryCode is an ISO 3166-1 alpha-2 country code
+// +kubebuilder:validation:Pattern=`^[A-Z]{2}$`
+type CountryCode string
+
+// GeoRestriction represents which countries viewers can access the distribution from
+type GeoRestriction struct {
+	// Type is whether only Countries are allowed (allow), Countries are denied (deny) or no country is restricted (none)
+	// +kubebuilder:validation:Required
+	Type GeoRestrictionType `json:"type"`
+	// Countries are the countries being allowed or denied. Must be empty if Type is none
+	// +optional
+	Countries []CountryCode `json:"countries,omitempty"`
+}
+
+// CustomErrorResponse represents how the distribution responds to viewers when an origin returns an error
+type CustomErrorResponse struct {
+	// ErrorCode is the status code returned by the origin
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Enum=400;403;404;405;414;416;500;501;502;503;504
+	ErrorCode int64 `json:"errorCode"`
+	// ResponsePagePath is the path of the page returned to viewers instead of the origin's response.
+	// Must be set along with ResponseCode
+	// +kubebuilder:validation:Pattern=`^/`
+	// +optional
+	ResponsePagePath string `json:"responsePagePath,omitempty"`
+	// ResponseCode is the status code returned to viewers along with the custom error page.
+	// Must be set along with ResponsePagePath
+	// +kubebuilder:validation:Enum=200;400;403;404;405;414;416;500;501;502;503;504
+	// +optional
+	ResponseCode int64 `json:"responseCode,omitempty"`
+	// CachingMinTTL is how long, in seconds, the error is cached. Defaults to CloudFront's default of 10 seconds
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	CachingMinTTL *int64 `json:"cachingMinTTL,omitempty"`
+}
+
+// DistributionOrigin represents an origin and the cache behaviors associated with it
+type DistributionOrigin struct {
+	// Host is the origin's hostname
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Host string `json:"host"`
+	// OriginPath is the directory CloudFront requests content from within the host, such as /assets
+	// +kubebuilder:validation:Pattern=`^/.*[^/]$`
+	// +kubebuilder:validation:MaxLength=255
+	// +optional
+	OriginPath string `json:"originPath,omitempty"`
+	// OriginAccess is how CloudFront accesses the origin: Public or Bucket
+	// +kubebuilder:validation:Enum=Public;Bucket
+	// +kubebuilder:default=Public
+	// +optional
+	OriginAccess string `json:"originAccess,omitempty"`
+	// ResponseTimeout is how long, in seconds, CloudFront waits for a response from the origin
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=60
+	// +optional
+	ResponseTimeout int64 `json:"responseTimeout,omitempty"`
+	// OriginProtocolPolicy is the protocol CloudFront uses to connect to a Public origin. Defaults to match-viewer
+	// +kubebuilder:validation:Enum=http-only;https-only;match-viewer
+	// +optional
+	OriginProtocolPolicy string `json:"originProtocolPolicy,omitempty"`
+	// HTTPPort is the port CloudFront uses for HTTP connections to a Public origin: 80 or 1024-65535. Defaults to 80
+	// +kubebuilder:validation:Minimum=80
+	// +kubebuilder:validation:Maximum=65535
+	// +optional
+	HTTPPort int64 `json:"httpPort,omitempty"`
+	// HTTPSPort is the port CloudFront uses for HTTPS connections to a Public origin: 443 or 1024-65535. Defaults to 443
+	// +kubebuilder:validation:Minimum=443
+	// +kubebuilder:validation:Maximum=65535
+	// +optional
+	HTTPSPort int64 `json:"httpsPort,omitempty"`
+	// KeepaliveTimeout is how long, in seconds, CloudFront keeps idle connections to a Public origin open. Defaults to 5
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=60
+	// +optional
+	KeepaliveTimeout int64 `json:"keepaliveTimeout,omitempty"`
+	// MinSSLProtocol is the oldest SSL/TLS protocol CloudFront may use for HTTPS connections to a Public origin.
+	// Defaults to the controller's configuration
+	// +kubebuilder:validation:Enum=SSLv3;TLSv1;TLSv1.1;TLSv1.2
+	// +optional
+	MinSSLProtocol string `json:"minSSLProtocol,omitempty"`
+	// Headers are HTTP headers CloudFront adds to every request sent to the origin
+	// +optional
+	Headers map[string]string `json:"headers,omitempty"`
+	// Failover is a secondary origin CloudFront fails over to when this origin can't be reached or
+	// responds with one of the failover status codes
+	// +optional
+	Failover *DistributionFailover `json:"failover,omitempty"`
+	// OriginRequestPolicy is the ID of the origin request policy associated with the origin's behaviors
+	// +optional
+	OriginRequestPolicy string `json:"originRequestPolicy,omitempty"`
+	// CachePolicy is the ID of the cache policy associated with the origin's behaviors
+	// +optional
+	CachePolicy string `json:"cachePolicy,omitempty"`
+	// ResponsePolicy is the ID of the response headers policy associated with the origin's behaviors
+	// +optional
+	ResponsePolicy string `json:"responsePolicy,omitempty"`
+	// DefaultBehavior makes this origin the target of the distribution's default behavior, instead of the default
+	// origin. The default behavior takes the configuration of the origin's /* behavior, which is required
+	// +optional
+	DefaultBehavior bool `json:"defaultBehavior,omitempty"`
+	// Behaviors are the cache behaviors that route requests to this origin
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	Behaviors []DistributionBehavior `json:"behaviors"`
+}
+
+// DistributionFailover represents the secondary origin of an origin group
+type DistributionFailover struct {
+	// Host is the secondary origin's hostname
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Host string `json:"host"`
+	// OriginPath is the directory CloudFront requests content from within the secondary origin's host
+	// +kubebuilder:validation:Pattern=`^/.*[^/]$`
+	// +kubebuilder:validation:MaxLength=255
+	// +optional
+	OriginPath string `json:"originPath,omitempty"`
+	// OriginAccess is how CloudFront accesses the secondary origin: Public or Bucket
+	// +kubebuilder:validation:Enum=Public;Bucket
+	// +kubebuilder:default=Public
+	// +optional
+	OriginAccess string `json:"originAccess,omitempty"`
+	// StatusCodes are the status codes from the primary origin which trigger a failover. Defaults to 500, 502, 503 and 504
+	// +optional
+	StatusCodes []FailoverStatusCode `json:"statusCodes,omitempty"`
+}
+
+// FailoverStatusCode is a status code from a primary origin CloudFront can fail over on
+// +kubebuilder:validation:Enum=400;403;404;416;500;502;503;504
+type FailoverStatusCode int64
+
+// DistributionBehavior represents a cache behavior
+type DistributionBehavior struct {
+	// Path is the path pattern of the cache behavior
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Path string `json:"path"`
+	// FunctionAssociations are the functions that should be associated with this behavior
+	// +optional
+	FunctionAssociations *FunctionAssociations `json:"functionAssociations,omitempty"`
+	// AllowedMethods are the HTTP methods CloudFront processes and forwards to the origin: GET and HEAD; GET, HEAD
+	// and OPTIONS; or all methods. Defaults to all methods
+	// +optional
+	AllowedMethods []HTTPMethod `json:"allowedMethods,omitempty"`
+	// CachedMethods are the HTTP methods whose responses CloudFront caches: GET and HEAD; or GET, HEAD and OPTIONS.
+	// Defaults to GET and HEAD
+	// +optional
+	CachedMethods []HTTPMethod `json:"cachedMethods,omitempty"`
+	// ViewerProtocolPolicy is the protocol viewers may use to access the behavior. Defaults to redirect-to-https
+	// +kubebuilder:validation:Enum=allow-all;https-only;redirect-to-https
+	// +optional
+	ViewerProtocolPolicy string `json:"viewerProtocolPolicy,omitempty"`
+}
+
+// HTTPMethod is an HTTP method CloudFront can allow or cache on a cache behavior
+// +kubebuilder:validation:Enum=GET;HEAD;OPTIONS;PUT;POST;PATCH;DELETE
+type HTTPMethod string
+
+// FunctionAssociations represents the functions associated with a cache behavior
+type FunctionAssociations struct {
+	// +optional
+	ViewerRequest *ViewerRequestFunction `json:"viewerRequest,omitempty"`
+	// +optional
+	ViewerResponse *ViewerFunction `json:"viewerResponse,omitempty"`
+	// +optional
+	OriginRequest *OriginRequestFunction `json:"originRequest,omitempty"`
+	// +optional
+	OriginResponse *OriginFunction `json:"originResponse,omitempty"`
+}
+
+// ViewerFunction is a function associated with a viewer event
+type ViewerFunction struct {
+	// ARN is the function's ARN
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	ARN string `json:"arn"`
+	// FunctionType is the type of the function: cloudfront or edge
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Enum=cloudfront;edge
+	FunctionType string `json:"functionType"`
+}
+
+// ViewerRequestFunction is a function associated with the viewer request event
+type ViewerRequestFunction struct {
+	ViewerFunction `json:",inline"`
+	// IncludeBody exposes the request body to the function. Only supported by edge functions
+	// +optional
+	IncludeBody bool `json:"includeBody,omitempty"`
+}
+
+// OriginFunction is a Lambda@Edge function associated with an origin event
+type OriginFunction struct {
+	// ARN is the function's ARN
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	ARN string `json:"arn"`
+}
+
+// OriginRequestFunction is a Lambda@Edge function associated with the origin request event
+type OriginRequestFunction struct {
+	OriginFunction `json:",inline"`
+	// IncludeBody exposes the request body to the function
+	// +optional
+	IncludeBody bool `json:"includeBody,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:printcolumn:name="Group",type=string,JSONPath=`.spec.group`
+//+kubebuilder:printcolumn:name="Class",type=string,JSONPath=`.spec.class`
+
+// Distribution is the Schema for the distributions API
+type Distribution struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec DistributionSpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// DistributionList contains a list of Distribution
+type DistributionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Distribution `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Distribution{}, &DistributionList{})
+}
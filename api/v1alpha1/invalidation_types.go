@@ -0,0 +1,107 @@
+// Copyright (c) 2023 GPBR Participacoes LTDA.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// InvalidationSpec defines the desired state of Invalidation
+type InvalidationSpec struct {
+	// Group is the CDN group whose distribution should have its cache invalidated. It matches the name of a CDNStatus.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Group string `json:"group"`
+	// Paths are the path patterns to be invalidated, for example "/images/*"
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	Paths []string `json:"paths"`
+}
+
+// Phases an Invalidation goes through
+const (
+	// InvalidationPhasePending means the invalidation was not created on CloudFront yet
+	InvalidationPhasePending = "Pending"
+	// InvalidationPhaseInProgress means the invalidation was created on CloudFront, but is not complete yet
+	InvalidationPhaseInProgress = "InProgress"
+	// InvalidationPhaseCompleted means the invalidation is complete
+	InvalidationPhaseCompleted = "Completed"
+	// InvalidationPhaseFailed means the invalidation was rejected by CloudFront and will not be retried
+	InvalidationPhaseFailed = "Failed"
+)
+
+// InvalidationStatus defines the observed state of Invalidation
+type InvalidationStatus struct {
+	// Phase is the current phase of the invalidation: Pending, InProgress, Completed or Failed
+	// +optional
+	Phase string `json:"phase,omitempty"`
+	// Message is a human-readable explanation of the current phase
+	// +optional
+	Message string `json:"message,omitempty"`
+	// InvalidationID is the ID of the invalidation on CloudFront
+	// +optional
+	InvalidationID string `json:"invalidationID,omitempty"`
+	// DistributionID is the ID of the distribution being invalidated
+	// +optional
+	DistributionID string `json:"distributionID,omitempty"`
+	// CreateTime is when the invalidation was created on CloudFront
+	// +optional
+	// +nullable
+	CreateTime *metav1.Time `json:"createTime,omitempty"`
+	// CompletionTime is when the controller observed the invalidation was complete
+	// +optional
+	// +nullable
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Group",type=string,JSONPath=`.spec.group`
+//+kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
+//+kubebuilder:printcolumn:name="ID",type=string,JSONPath=`.status.invalidationID`
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// Invalidation is the Schema for the invalidations API. It invalidates paths from the cache of a group's distribution.
+type Invalidation struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="spec is immutable, create a new Invalidation instead"
+	Spec   InvalidationSpec   `json:"spec,omitempty"`
+	Status InvalidationStatus `json:"status,omitempty"`
+}
+
+// IsFinished returns whether the Invalidation reached a final phase, after which it's no longer reconciled
+func (i *Invalidation) IsFinished() bool {
+	return i.Status.Phase == InvalidationPhaseCompleted || i.Status.Phase == InvalidationPhaseFailed
+}
+
+//+kubebuilder:object:root=true
+
+// InvalidationList contains a list of Invalidation
+type InvalidationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Invalidation `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Invalidation{}, &InvalidationList{})
+}
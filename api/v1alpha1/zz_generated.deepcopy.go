@@ -203,6 +203,41 @@ func (in *CDNStatusStatus) DeepCopyInto(out *CDNStatusStatus) {
 		*out = new(DNSStatus)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Plan != nil {
+		in, out := &in.Plan, &out.Plan
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.GeoRestriction != nil {
+		in, out := &in.GeoRestriction, &out.GeoRestriction
+		*out = new(GeoRestriction)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DeclaredFields != nil {
+		in, out := &in.DeclaredFields, &out.DeclaredFields
+		*out = make([]OptionalField, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastModifiedTime != nil {
+		in, out := &in.LastModifiedTime, &out.LastModifiedTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastSyncTime != nil {
+		in, out := &in.LastSyncTime, &out.LastSyncTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Deletion != nil {
+		in, out := &in.Deletion, &out.Deletion
+		*out = new(DeletionStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CDNStatusStatus.
@@ -215,6 +250,26 @@ func (in *CDNStatusStatus) DeepCopy() *CDNStatusStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CustomErrorResponse) DeepCopyInto(out *CustomErrorResponse) {
+	*out = *in
+	if in.CachingMinTTL != nil {
+		in, out := &in.CachingMinTTL, &out.CachingMinTTL
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustomErrorResponse.
+func (in *CustomErrorResponse) DeepCopy() *CustomErrorResponse {
+	if in == nil {
+		return nil
+	}
+	out := new(CustomErrorResponse)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DNSStatus) DeepCopyInto(out *DNSStatus) {
 	*out = *in
@@ -235,6 +290,278 @@ func (in *DNSStatus) DeepCopy() *DNSStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeletionStatus) DeepCopyInto(out *DeletionStatus) {
+	*out = *in
+	if in.OACs != nil {
+		in, out := &in.OACs, &out.OACs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastTransitionTime != nil {
+		in, out := &in.LastTransitionTime, &out.LastTransitionTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeletionStatus.
+func (in *DeletionStatus) DeepCopy() *DeletionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DeletionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Distribution) DeepCopyInto(out *Distribution) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Distribution.
+func (in *Distribution) DeepCopy() *Distribution {
+	if in == nil {
+		return nil
+	}
+	out := new(Distribution)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Distribution) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DistributionBehavior) DeepCopyInto(out *DistributionBehavior) {
+	*out = *in
+	if in.FunctionAssociations != nil {
+		in, out := &in.FunctionAssociations, &out.FunctionAssociations
+		*out = new(FunctionAssociations)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AllowedMethods != nil {
+		in, out := &in.AllowedMethods, &out.AllowedMethods
+		*out = make([]HTTPMethod, len(*in))
+		copy(*out, *in)
+	}
+	if in.CachedMethods != nil {
+		in, out := &in.CachedMethods, &out.CachedMethods
+		*out = make([]HTTPMethod, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DistributionBehavior.
+func (in *DistributionBehavior) DeepCopy() *DistributionBehavior {
+	if in == nil {
+		return nil
+	}
+	out := new(DistributionBehavior)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DistributionFailover) DeepCopyInto(out *DistributionFailover) {
+	*out = *in
+	if in.StatusCodes != nil {
+		in, out := &in.StatusCodes, &out.StatusCodes
+		*out = make([]FailoverStatusCode, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DistributionFailover.
+func (in *DistributionFailover) DeepCopy() *DistributionFailover {
+	if in == nil {
+		return nil
+	}
+	out := new(DistributionFailover)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DistributionList) DeepCopyInto(out *DistributionList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Distribution, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DistributionList.
+func (in *DistributionList) DeepCopy() *DistributionList {
+	if in == nil {
+		return nil
+	}
+	out := new(DistributionList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DistributionList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DistributionOrigin) DeepCopyInto(out *DistributionOrigin) {
+	*out = *in
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Failover != nil {
+		in, out := &in.Failover, &out.Failover
+		*out = new(DistributionFailover)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Behaviors != nil {
+		in, out := &in.Behaviors, &out.Behaviors
+		*out = make([]DistributionBehavior, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DistributionOrigin.
+func (in *DistributionOrigin) DeepCopy() *DistributionOrigin {
+	if in == nil {
+		return nil
+	}
+	out := new(DistributionOrigin)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DistributionSpec) DeepCopyInto(out *DistributionSpec) {
+	*out = *in
+	if in.Origins != nil {
+		in, out := &in.Origins, &out.Origins
+		*out = make([]DistributionOrigin, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.AlternateDomainNames != nil {
+		in, out := &in.AlternateDomainNames, &out.AlternateDomainNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.CustomErrorResponses != nil {
+		in, out := &in.CustomErrorResponses, &out.CustomErrorResponses
+		*out = make([]CustomErrorResponse, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.GeoRestriction != nil {
+		in, out := &in.GeoRestriction, &out.GeoRestriction
+		*out = new(GeoRestriction)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DefaultRootObject != nil {
+		in, out := &in.DefaultRootObject, &out.DefaultRootObject
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DistributionSpec.
+func (in *DistributionSpec) DeepCopy() *DistributionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DistributionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FunctionAssociations) DeepCopyInto(out *FunctionAssociations) {
+	*out = *in
+	if in.ViewerRequest != nil {
+		in, out := &in.ViewerRequest, &out.ViewerRequest
+		*out = new(ViewerRequestFunction)
+		**out = **in
+	}
+	if in.ViewerResponse != nil {
+		in, out := &in.ViewerResponse, &out.ViewerResponse
+		*out = new(ViewerFunction)
+		**out = **in
+	}
+	if in.OriginRequest != nil {
+		in, out := &in.OriginRequest, &out.OriginRequest
+		*out = new(OriginRequestFunction)
+		**out = **in
+	}
+	if in.OriginResponse != nil {
+		in, out := &in.OriginResponse, &out.OriginResponse
+		*out = new(OriginFunction)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FunctionAssociations.
+func (in *FunctionAssociations) DeepCopy() *FunctionAssociations {
+	if in == nil {
+		return nil
+	}
+	out := new(FunctionAssociations)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GeoRestriction) DeepCopyInto(out *GeoRestriction) {
+	*out = *in
+	if in.Countries != nil {
+		in, out := &in.Countries, &out.Countries
+		*out = make([]CountryCode, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GeoRestriction.
+func (in *GeoRestriction) DeepCopy() *GeoRestriction {
+	if in == nil {
+		return nil
+	}
+	out := new(GeoRestriction)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in IngressRefs) DeepCopyInto(out *IngressRefs) {
 	{
@@ -255,3 +582,167 @@ func (in IngressRefs) DeepCopy() IngressRefs {
 	in.DeepCopyInto(out)
 	return *out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Invalidation) DeepCopyInto(out *Invalidation) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Invalidation.
+func (in *Invalidation) DeepCopy() *Invalidation {
+	if in == nil {
+		return nil
+	}
+	out := new(Invalidation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Invalidation) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InvalidationList) DeepCopyInto(out *InvalidationList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Invalidation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InvalidationList.
+func (in *InvalidationList) DeepCopy() *InvalidationList {
+	if in == nil {
+		return nil
+	}
+	out := new(InvalidationList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *InvalidationList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InvalidationSpec) DeepCopyInto(out *InvalidationSpec) {
+	*out = *in
+	if in.Paths != nil {
+		in, out := &in.Paths, &out.Paths
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InvalidationSpec.
+func (in *InvalidationSpec) DeepCopy() *InvalidationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(InvalidationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InvalidationStatus) DeepCopyInto(out *InvalidationStatus) {
+	*out = *in
+	if in.CreateTime != nil {
+		in, out := &in.CreateTime, &out.CreateTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InvalidationStatus.
+func (in *InvalidationStatus) DeepCopy() *InvalidationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(InvalidationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OriginFunction) DeepCopyInto(out *OriginFunction) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OriginFunction.
+func (in *OriginFunction) DeepCopy() *OriginFunction {
+	if in == nil {
+		return nil
+	}
+	out := new(OriginFunction)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OriginRequestFunction) DeepCopyInto(out *OriginRequestFunction) {
+	*out = *in
+	out.OriginFunction = in.OriginFunction
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OriginRequestFunction.
+func (in *OriginRequestFunction) DeepCopy() *OriginRequestFunction {
+	if in == nil {
+		return nil
+	}
+	out := new(OriginRequestFunction)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ViewerFunction) DeepCopyInto(out *ViewerFunction) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ViewerFunction.
+func (in *ViewerFunction) DeepCopy() *ViewerFunction {
+	if in == nil {
+		return nil
+	}
+	out := new(ViewerFunction)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ViewerRequestFunction) DeepCopyInto(out *ViewerRequestFunction) {
+	*out = *in
+	out.ViewerFunction = in.ViewerFunction
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ViewerRequestFunction.
+func (in *ViewerRequestFunction) DeepCopy() *ViewerRequestFunction {
+	if in == nil {
+		return nil
+	}
+	out := new(ViewerRequestFunction)
+	in.DeepCopyInto(out)
+	return out
+}
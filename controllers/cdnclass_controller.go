@@ -0,0 +1,111 @@
+// Copyright (c) 2023 GPBR Participacoes LTDA.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	networkingv1 "k8s.io/api/networking/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/Gympass/cdn-origin-controller/api/v1alpha1"
+	"github.com/Gympass/cdn-origin-controller/internal/k8s"
+)
+
+// CDNClassReconciler reconciles the status of CDNClass resources
+type CDNClassReconciler struct {
+	client.Client
+
+	StatusReporter *k8s.CDNClassStatusReporter
+}
+
+// +kubebuilder:rbac:groups=cdn.gympass.com,resources=cdnclasses,verbs=get;list;watch
+// +kubebuilder:rbac:groups=cdn.gympass.com,resources=cdnclasses/status,verbs=get;update;patch
+
+// Reconcile a CDNClass resource
+func (r *CDNClassReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log, _ := logr.FromContext(ctx)
+	log.V(1).Info("Starting reconciliation.")
+
+	class := &v1alpha1.CDNClass{}
+	err := r.Client.Get(ctx, req.NamespacedName, class)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			log.V(1).Info("Ignoring not found CDNClass.")
+			return reconcile.Result{}, nil
+		}
+
+		return reconcile.Result{}, fmt.Errorf("could not fetch CDNClass: %+v", err)
+	}
+
+	if err := r.StatusReporter.Report(ctx, class); err != nil {
+		return reconcile.Result{}, err
+	}
+	log.V(1).Info("Reconciliation successful.")
+	return reconcile.Result{}, nil
+}
+
+// SetupWithManager ...
+func (r *CDNClassReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1alpha1.CDNClass{}).
+		Watches(&v1alpha1.CDNStatus{}, handler.EnqueueRequestsFromMapFunc(r.allClasses)).
+		Watches(&networkingv1.Ingress{}, handler.EnqueueRequestsFromMapFunc(classOf)).
+		Watches(&v1alpha1.Distribution{}, handler.EnqueueRequestsFromMapFunc(classOf)).
+		Complete(r)
+}
+
+// allClasses maps an object to every CDNClass. It's used for CDNStatuses, which don't reference their class.
+func (r *CDNClassReconciler) allClasses(ctx context.Context, _ client.Object) []reconcile.Request {
+	list := &v1alpha1.CDNClassList{}
+	if err := r.List(ctx, list); err != nil {
+		log, _ := logr.FromContext(ctx)
+		log.Error(err, "Could not list CDNClasses")
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for i := range list.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&list.Items[i])})
+	}
+	return requests
+}
+
+// classOf maps an Ingress or a Distribution to the CDNClass it uses
+func classOf(_ context.Context, obj client.Object) []reconcile.Request {
+	var className string
+	switch o := obj.(type) {
+	case *v1alpha1.Distribution:
+		className = o.Spec.Class
+	default:
+		className = k8s.CDNClassAnnotationValue(obj)
+	}
+
+	if !k8s.CDNClassNotEmpty(className) {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: client.ObjectKey{Name: className}}}
+}
@@ -0,0 +1,110 @@
+// Copyright (c) 2023 GPBR Participacoes LTDA.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/Gympass/cdn-origin-controller/api/v1alpha1"
+	"github.com/Gympass/cdn-origin-controller/internal/cloudfront"
+	"github.com/Gympass/cdn-origin-controller/internal/k8s"
+)
+
+// DistributionReconciler reconciles Distribution resources
+type DistributionReconciler struct {
+	client.Client
+
+	CloudFrontService *cloudfront.Service
+	CDNClassFetcher   k8s.CDNClassFetcher
+}
+
+// +kubebuilder:rbac:groups=cdn.gympass.com,resources=distributions,verbs=get;list;watch;update
+// +kubebuilder:rbac:groups=cdn.gympass.com,resources=distributions/finalizers,verbs=update
+
+// Reconcile a Distribution resource
+func (r *DistributionReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log, _ := logr.FromContext(ctx)
+	log.Info("Starting reconciliation.")
+
+	dist := &v1alpha1.Distribution{}
+	err := r.Client.Get(ctx, req.NamespacedName, dist)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			log.Info("Ignoring not found Distribution.")
+			return reconcile.Result{}, nil
+		}
+
+		return reconcile.Result{}, fmt.Errorf("could not fetch Distribution: %+v", err)
+	}
+
+	if dist.DeletionTimestamp != nil && !k8s.HasFinalizer(dist) {
+		log.Info("Ignoring Distribution being deleted without finalizer.")
+		return reconcile.Result{}, nil
+	}
+
+	cdnClass, err := r.CDNClassFetcher.FetchByName(ctx, dist.Spec.Class)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("could not find CDN class (%s): %v", dist.Spec.Class, err)
+	}
+
+	result, err := r.CloudFrontService.ReconcileDistribution(ctx, dist, cdnClass)
+	if err == nil {
+		log.Info("Reconciliation successful.")
+	}
+	return result, err
+}
+
+// SetupWithManager ...
+func (r *DistributionReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1alpha1.Distribution{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&v1alpha1.CDNClass{},
+			handler.EnqueueRequestsFromMapFunc(r.distributionsOfClass),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Complete(r)
+}
+
+// distributionsOfClass maps a CDNClass to all Distributions using it, so changes to the class are propagated to their groups
+func (r *DistributionReconciler) distributionsOfClass(ctx context.Context, obj client.Object) []reconcile.Request {
+	list := &v1alpha1.DistributionList{}
+	if err := r.List(ctx, list); err != nil {
+		log, _ := logr.FromContext(ctx)
+		log.Error(err, "Could not list Distributions of CDNClass", "cdnClass", obj.GetName())
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for i := range list.Items {
+		if list.Items[i].Spec.Class == obj.GetName() {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&list.Items[i])})
+		}
+	}
+	return requests
+}
@@ -27,9 +27,13 @@ import (
 	networkingv1 "k8s.io/api/networking/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
+	"github.com/Gympass/cdn-origin-controller/api/v1alpha1"
 	"github.com/Gympass/cdn-origin-controller/internal/cloudfront"
 	"github.com/Gympass/cdn-origin-controller/internal/k8s"
 )
@@ -47,6 +51,7 @@ type V1Reconciler struct {
 // +kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses/finalizers,verbs=update
 // +kubebuilder:rbac:groups=cdn.gympass.com,resources=cdnstatuses,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=cdn.gympass.com,resources=cdnstatuses/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=cdn.gympass.com,resources=cdnclasses,verbs=get;list;watch
 
 // Reconcile a v1 Ingress resource
 func (r *V1Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
@@ -70,17 +75,37 @@ func (r *V1Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Re
 		return ctrl.Result{}, fmt.Errorf("could not find CDN class (%s): %v", cdnClassName, err)
 	}
 
-	err = r.CloudFrontService.Reconcile(ctx, ingress, cdnClass)
+	result, err := r.CloudFrontService.Reconcile(ctx, ingress, cdnClass)
 	if err == nil {
 		log.Info("Reconciliation successful.")
 	}
-	return ctrl.Result{}, err
+	return result, err
 }
 
 // SetupWithManager ...
 func (r *V1Reconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
-		WithEventFilter(&ingressPredicate{}).
-		For(&networkingv1.Ingress{}).
+		For(&networkingv1.Ingress{}, builder.WithPredicates(&ingressPredicate{})).
+		Watches(&v1alpha1.CDNClass{},
+			handler.EnqueueRequestsFromMapFunc(r.ingressesOfClass),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{})).
 		Complete(r)
 }
+
+// ingressesOfClass maps a CDNClass to all Ingresses using it, so changes to the class are propagated to their groups
+func (r *V1Reconciler) ingressesOfClass(ctx context.Context, obj client.Object) []reconcile.Request {
+	list := &networkingv1.IngressList{}
+	if err := r.List(ctx, list); err != nil {
+		log, _ := logr.FromContext(ctx)
+		log.Error(err, "Could not list Ingresses of CDNClass", "cdnClass", obj.GetName())
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for i := range list.Items {
+		if k8s.CDNClassAnnotationValue(&list.Items[i]) == obj.GetName() {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&list.Items[i])})
+		}
+	}
+	return requests
+}
@@ -0,0 +1,76 @@
+// Copyright (c) 2023 GPBR Participacoes LTDA.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/Gympass/cdn-origin-controller/api/v1alpha1"
+	"github.com/Gympass/cdn-origin-controller/internal/cloudfront"
+)
+
+// InvalidationReconciler reconciles Invalidation resources
+type InvalidationReconciler struct {
+	client.Client
+
+	InvalidationService *cloudfront.InvalidationService
+}
+
+// +kubebuilder:rbac:groups=cdn.gympass.com,resources=invalidations,verbs=get;list;watch
+// +kubebuilder:rbac:groups=cdn.gympass.com,resources=invalidations/status,verbs=get;update;patch
+
+// Reconcile an Invalidation resource
+func (r *InvalidationReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log, _ := logr.FromContext(ctx)
+	log.Info("Starting reconciliation.")
+
+	inv := &v1alpha1.Invalidation{}
+	err := r.Client.Get(ctx, req.NamespacedName, inv)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			log.Info("Ignoring not found Invalidation.")
+			return reconcile.Result{}, nil
+		}
+
+		return reconcile.Result{}, fmt.Errorf("could not fetch Invalidation: %+v", err)
+	}
+
+	result, err := r.InvalidationService.Reconcile(ctx, inv)
+	if err == nil {
+		log.Info("Reconciliation successful.")
+	}
+	return result, err
+}
+
+// SetupWithManager ...
+func (r *InvalidationReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1alpha1.Invalidation{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Complete(r)
+}
@@ -22,6 +22,8 @@ package controllers
 import (
 	"reflect"
 
+	networkingv1 "k8s.io/api/networking/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 
@@ -55,15 +57,24 @@ func (p ingressPredicate) Delete(event event.DeleteEvent) bool {
 func (p ingressPredicate) Update(event event.UpdateEvent) bool {
 	cdnClassOK := k8s.CDNClassNotEmpty(k8s.CDNClassAnnotationValue(event.ObjectNew))
 	objectsAreEqual := reflect.DeepEqual(event.ObjectNew, event.ObjectOld)
+	revisionsOnly := isRolloutRevisionsUpdate(event.ObjectOld, event.ObjectNew)
 	finalizerOK := k8s.HasFinalizer(event.ObjectNew)
 	groupOK := k8s.HasGroupAnnotation(event.ObjectNew)
 	lbOK := k8s.HasLoadBalancer(event.ObjectNew)
 
 	return cdnClassOK &&
 		!objectsAreEqual &&
+		!revisionsOnly &&
 		(finalizerOK || (groupOK && lbOK))
 }
 
 func (p ingressPredicate) Generic(event.GenericEvent) bool {
 	return false
 }
+
+// isRolloutRevisionsUpdate returns whether the update only records rollout revisions, which doesn't affect distributions
+func isRolloutRevisionsUpdate(oldObj, newObj client.Object) bool {
+	oldIng, oldOK := oldObj.(*networkingv1.Ingress)
+	newIng, newOK := newObj.(*networkingv1.Ingress)
+	return oldOK && newOK && k8s.IsRolloutRevisionsUpdate(oldIng, newIng)
+}
@@ -66,6 +66,11 @@ var (
 		i.Finalizers = []string{k8s.CDNFinalizer}
 		return i
 	}()
+	recordedRevisionIngress = func() *networkingv1.Ingress {
+		i := hasFinalizerIngress.DeepCopy()
+		k8s.SetRolloutRevision(i, "deploy", "2")
+		return i
+	}()
 )
 
 func (s *PredicateSuite) TearDownTest() {
@@ -152,6 +157,11 @@ func (s *PredicateSuite) Test_hasCdnAnnotationPredicate_Update() {
 			input: event.UpdateEvent{ObjectNew: hasFinalizerIngress, ObjectOld: hasFinalizerIngress},
 			want:  false,
 		},
+		{
+			name:  "Only rollout revisions changed",
+			input: event.UpdateEvent{ObjectNew: recordedRevisionIngress, ObjectOld: hasFinalizerIngress},
+			want:  false,
+		},
 		{
 			name:  "Old not from this CDN class, new is",
 			input: event.UpdateEvent{ObjectOld: baseIngress, ObjectNew: annotatedAndProvisionedIngress},
@@ -0,0 +1,134 @@
+// Copyright (c) 2023 GPBR Participacoes LTDA.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/Gympass/cdn-origin-controller/internal/cloudfront"
+	"github.com/Gympass/cdn-origin-controller/internal/k8s"
+)
+
+// RolloutReconciler reconciles Deployments backing Ingresses that should be invalidated when they roll out
+type RolloutReconciler struct {
+	client.Client
+
+	RolloutService *cloudfront.RolloutService
+}
+
+// +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch
+// +kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses,verbs=get;list;watch;patch
+// +kubebuilder:rbac:groups=cdn.gympass.com,resources=invalidations,verbs=get;list;watch;create;delete
+
+// Reconcile a Deployment resource
+func (r *RolloutReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log, _ := logr.FromContext(ctx)
+	log.V(1).Info("Starting reconciliation.")
+
+	deploy := &appsv1.Deployment{}
+	err := r.Client.Get(ctx, req.NamespacedName, deploy)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			log.V(1).Info("Ignoring not found Deployment.")
+			return reconcile.Result{}, nil
+		}
+
+		return reconcile.Result{}, fmt.Errorf("could not fetch Deployment: %+v", err)
+	}
+
+	if err := r.RolloutService.Reconcile(ctx, deploy); err != nil {
+		return reconcile.Result{}, err
+	}
+	log.V(1).Info("Reconciliation successful.")
+	return reconcile.Result{}, nil
+}
+
+// SetupWithManager ...
+func (r *RolloutReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("rollout").
+		For(&appsv1.Deployment{}, builder.WithPredicates(predicate.NewPredicateFuncs(isRolledOut))).
+		Watches(&corev1.Service{}, handler.EnqueueRequestsFromMapFunc(r.deploymentsSelectedBy)).
+		Watches(&networkingv1.Ingress{}, handler.EnqueueRequestsFromMapFunc(r.deploymentsBacking),
+			builder.WithPredicates(predicate.NewPredicateFuncs(k8s.InvalidatesOnRollout))).
+		Complete(r)
+}
+
+func isRolledOut(obj client.Object) bool {
+	deploy, ok := obj.(*appsv1.Deployment)
+	return ok && k8s.IsRolloutComplete(deploy)
+}
+
+// deploymentsBacking maps an Ingress to the Deployments selected by its backend Services, so that the revision
+// which is live when it opts in is recorded and only later rollouts invalidate its paths
+func (r *RolloutReconciler) deploymentsBacking(ctx context.Context, obj client.Object) []reconcile.Request {
+	ing, ok := obj.(*networkingv1.Ingress)
+	if !ok {
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, name := range k8s.BackendServiceNames(ing) {
+		svc := &corev1.Service{}
+		if err := r.Get(ctx, client.ObjectKey{Namespace: ing.Namespace, Name: name}, svc); err != nil {
+			continue
+		}
+		requests = append(requests, r.deploymentsSelectedBy(ctx, svc)...)
+	}
+	return requests
+}
+
+// deploymentsSelectedBy maps a Service to the Deployments it routes traffic to, so that the revision of
+// a Deployment a Service starts pointing to is recorded
+func (r *RolloutReconciler) deploymentsSelectedBy(ctx context.Context, obj client.Object) []reconcile.Request {
+	svc, ok := obj.(*corev1.Service)
+	if !ok {
+		return nil
+	}
+
+	deployList := &appsv1.DeploymentList{}
+	if err := r.List(ctx, deployList, client.InNamespace(svc.Namespace)); err != nil {
+		log, _ := logr.FromContext(ctx)
+		log.Error(err, "Could not list Deployments selected by Service", "service", svc.Name)
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for i := range deployList.Items {
+		if k8s.SelectsDeployment(svc, &deployList.Items[i]) {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&deployList.Items[i])})
+		}
+	}
+	return requests
+}
@@ -0,0 +1,64 @@
+// Copyright (c) 2023 GPBR Participacoes LTDA.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package aws
+
+import (
+	"errors"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+
+	"github.com/Gympass/cdn-origin-controller/internal/metrics"
+)
+
+const apiCallMetricsHandlerName = "cdn-origin-controller.APICallMetrics"
+
+// InstrumentAPICalls adds a handler to the given handlers which observes the latency of every AWS API call
+// made by clients using them, including retries
+func InstrumentAPICalls(handlers *request.Handlers) {
+	handlers.Complete.PushBackNamed(request.NamedHandler{
+		Name: apiCallMetricsHandlerName,
+		Fn:   observeAPICall,
+	})
+}
+
+func observeAPICall(r *request.Request) {
+	operation := ""
+	if r.Operation != nil {
+		operation = r.Operation.Name
+	}
+
+	metrics.AWSAPICallDuration.
+		WithLabelValues(r.ClientInfo.ServiceName, operation, errorCode(r.Error)).
+		Observe(time.Since(r.Time).Seconds())
+}
+
+func errorCode(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	var aerr awserr.Error
+	if errors.As(err, &aerr) {
+		return aerr.Code()
+	}
+	return "Unknown"
+}
@@ -0,0 +1,64 @@
+// Copyright (c) 2023 GPBR Participacoes LTDA.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package aws
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/client/metadata"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/Gympass/cdn-origin-controller/internal/metrics"
+)
+
+func TestRunMetricsTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, &MetricsTestSuite{})
+}
+
+type MetricsTestSuite struct {
+	suite.Suite
+}
+
+func (s *MetricsTestSuite) TestInstrumentAPICalls_ObservesCompletedCalls() {
+	handlers := request.Handlers{}
+	InstrumentAPICalls(&handlers)
+
+	r := &request.Request{
+		Time:       time.Now().Add(-time.Second),
+		ClientInfo: metadata.ClientInfo{ServiceName: "test-service"},
+		Operation:  &request.Operation{Name: "TestOperation"},
+		Error:      awserr.New("TestCode", "msg", nil),
+	}
+	handlers.Complete.Run(r)
+
+	s.Equal(1, testutil.CollectAndCount(metrics.AWSAPICallDuration))
+}
+
+func (s *MetricsTestSuite) Test_errorCode() {
+	s.Equal("", errorCode(nil))
+	s.Equal("NoSuchDistribution", errorCode(awserr.New("NoSuchDistribution", "msg", nil)))
+	s.Equal("Unknown", errorCode(errors.New("some error")))
+}
@@ -20,6 +20,8 @@
 package cloudfront
 
 import (
+	"strconv"
+
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/cloudfront"
 
@@ -36,7 +38,13 @@ func newAWSDistributionConfig(d Distribution, callerRef CallerRefFn, cfg config.
 	var allCacheBehaviors []*cloudfront.CacheBehavior
 	allOrigins := []*cloudfront.Origin{newAWSOrigin(d.DefaultOrigin)}
 
-	for _, o := range d.CustomOrigins {
+	// origins with the same ID are equal, so each of them is only part of the distribution once
+	seenOrigins := map[string]bool{d.DefaultOrigin.ID(): true}
+	for _, o := range d.customAndSecondaryOrigins() {
+		if seenOrigins[o.ID()] {
+			continue
+		}
+		seenOrigins[o.ID()] = true
 		allOrigins = append(allOrigins, newAWSOrigin(o))
 	}
 
@@ -55,16 +63,9 @@ func newAWSDistributionConfig(d Distribution, callerRef CallerRefFn, cfg config.
 		},
 		CallerReference:      aws.String(callerRef()),
 		Comment:              aws.String(d.Description),
-		CustomErrorResponses: nil,
+		CustomErrorResponses: newAWSCustomErrorResponses(d.CustomErrorResponses),
 		DefaultCacheBehavior: &cloudfront.DefaultCacheBehavior{
-			AllowedMethods: &cloudfront.AllowedMethods{
-				Items:    aws.StringSlice([]string{"GET", "HEAD", "OPTIONS", "PUT", "POST", "PATCH", "DELETE"}),
-				Quantity: aws.Int64(7),
-				CachedMethods: &cloudfront.CachedMethods{
-					Items:    aws.StringSlice([]string{"GET", "HEAD"}),
-					Quantity: aws.Int64(2),
-				},
-			},
+			AllowedMethods:             newAWSAllowedMethods(defaultAllowedMethods, defaultCachedMethods),
 			CachePolicyId:              aws.String(cfg.CloudFrontDefaultCachingPolicyID),
 			Compress:                   aws.Bool(true),
 			FieldLevelEncryptionId:     aws.String(""),
@@ -73,7 +74,7 @@ func newAWSDistributionConfig(d Distribution, callerRef CallerRefFn, cfg config.
 			LambdaFunctionAssociations: &cloudfront.LambdaFunctionAssociations{Quantity: aws.Int64(0)},
 			RealtimeLogConfigArn:       nil,
 			SmoothStreaming:            aws.Bool(false),
-			TargetOriginId:             aws.String(d.DefaultOrigin.Host),
+			TargetOriginId:             aws.String(d.DefaultOrigin.ID()),
 			TrustedKeyGroups:           nil,
 			TrustedSigners:             nil,
 			ViewerProtocolPolicy:       aws.String(cloudfront.ViewerProtocolPolicyRedirectToHttps),
@@ -82,7 +83,7 @@ func newAWSDistributionConfig(d Distribution, callerRef CallerRefFn, cfg config.
 			Items:    allOrigins,
 			Quantity: aws.Int64(int64(len(allOrigins))),
 		},
-		DefaultRootObject: nil,
+		DefaultRootObject: d.DefaultRootObject,
 		Enabled:           aws.Bool(true),
 		HttpVersion:       aws.String(cloudfront.HttpVersionHttp2),
 		IsIPV6Enabled:     aws.Bool(d.IPv6Enabled),
@@ -92,13 +93,16 @@ func newAWSDistributionConfig(d Distribution, callerRef CallerRefFn, cfg config.
 			Prefix:         aws.String(""),
 			IncludeCookies: aws.Bool(false),
 		},
-		OriginGroups:      nil,
+		OriginGroups:      newAWSOriginGroups(d),
 		PriceClass:        aws.String(d.PriceClass),
-		Restrictions:      nil,
+		Restrictions:      newAWSRestrictions(d.GeoRestriction),
 		ViewerCertificate: nil,
 		WebACLId:          aws.String(d.WebACLID),
 	}
 
+	if d.DefaultBehavior != nil {
+		config.DefaultCacheBehavior = newDefaultCacheBehavior(*d.DefaultBehavior)
+	}
 	if d.TLS.Enabled {
 		config.ViewerCertificate = &cloudfront.ViewerCertificate{
 			ACMCertificateArn:      aws.String(d.TLS.CertARN),
@@ -119,12 +123,7 @@ func newAWSDistributionConfig(d Distribution, callerRef CallerRefFn, cfg config.
 }
 
 func newAWSOrigin(o Origin) *cloudfront.Origin {
-	SSLProtocols := []*string{
-		aws.String(originSSLProtocolSSLv3),
-		aws.String(originSSLProtocolTLSv1),
-		aws.String(originSSLProtocolTLSv11),
-		aws.String(originSSLProtocolTLSv12),
-	}
+	SSLProtocols := aws.StringSlice(originSSLProtocolsFrom(o.MinSSLProtocol))
 
 	var customOriginConfig *cloudfront.CustomOriginConfig
 	var originAccessControlID *string
@@ -132,10 +131,10 @@ func newAWSOrigin(o Origin) *cloudfront.Origin {
 
 	if o.Access == OriginAccessPublic {
 		customOriginConfig = &cloudfront.CustomOriginConfig{
-			HTTPPort:               aws.Int64(80),
-			HTTPSPort:              aws.Int64(443),
-			OriginKeepaliveTimeout: aws.Int64(5),
-			OriginProtocolPolicy:   aws.String(cloudfront.OriginProtocolPolicyMatchViewer),
+			HTTPPort:               aws.Int64(o.HTTPPort),
+			HTTPSPort:              aws.Int64(o.HTTPSPort),
+			OriginKeepaliveTimeout: aws.Int64(o.KeepaliveTimeout),
+			OriginProtocolPolicy:   aws.String(o.ProtocolPolicy),
 			OriginReadTimeout:      aws.Int64(o.ResponseTimeout),
 			OriginSslProtocols: &cloudfront.OriginSslProtocols{
 				Items:    SSLProtocols,
@@ -153,13 +152,125 @@ func newAWSOrigin(o Origin) *cloudfront.Origin {
 		CustomHeaders:         newCustomHeaders(o),
 		CustomOriginConfig:    customOriginConfig,
 		DomainName:            aws.String(o.Host),
-		Id:                    aws.String(o.Host),
+		Id:                    aws.String(o.ID()),
 		OriginAccessControlId: originAccessControlID,
-		OriginPath:            aws.String(""),
+		OriginPath:            aws.String(o.Path),
 		S3OriginConfig:        s3OriginConfig,
 	}
 }
 
+// newAWSCustomErrorResponses returns the given custom error responses. It returns nil if they're nil, so that the existing
+// ones are kept, and an empty list if they're empty, so that the existing ones are removed.
+func newAWSCustomErrorResponses(responses []CustomErrorResponse) *cloudfront.CustomErrorResponses {
+	if responses == nil {
+		return nil
+	}
+	if len(responses) == 0 {
+		return &cloudfront.CustomErrorResponses{Quantity: aws.Int64(0)}
+	}
+
+	var items []*cloudfront.CustomErrorResponse
+	for _, r := range responses {
+		item := &cloudfront.CustomErrorResponse{
+			ErrorCode:          aws.Int64(r.ErrorCode),
+			ErrorCachingMinTTL: r.CachingMinTTL,
+		}
+		if len(r.ResponsePagePath) > 0 {
+			item.ResponsePagePath = aws.String(r.ResponsePagePath)
+			item.ResponseCode = aws.String(strconv.FormatInt(r.ResponseCode, 10))
+		}
+		items = append(items, item)
+	}
+
+	return &cloudfront.CustomErrorResponses{
+		Items:    items,
+		Quantity: aws.Int64(int64(len(items))),
+	}
+}
+
+// newAWSRestrictions returns the given geo restriction, or nil if there's none
+func newAWSRestrictions(g *GeoRestriction) *cloudfront.Restrictions {
+	if g == nil {
+		return nil
+	}
+
+	restrictionType := cloudfront.GeoRestrictionTypeNone
+	switch g.Type {
+	case GeoRestrictionAllow:
+		restrictionType = cloudfront.GeoRestrictionTypeWhitelist
+	case GeoRestrictionDeny:
+		restrictionType = cloudfront.GeoRestrictionTypeBlacklist
+	}
+
+	restriction := &cloudfront.GeoRestriction{
+		RestrictionType: aws.String(restrictionType),
+		Quantity:        aws.Int64(0),
+	}
+	if restrictionType != cloudfront.GeoRestrictionTypeNone {
+		restriction.Items = aws.StringSlice(g.Countries)
+		restriction.Quantity = aws.Int64(int64(len(g.Countries)))
+	}
+	return &cloudfront.Restrictions{GeoRestriction: restriction}
+}
+
+// observedGeoRestriction returns the geo restriction in place according to the given restrictions
+func observedGeoRestriction(r *cloudfront.Restrictions) *GeoRestriction {
+	if r == nil || r.GeoRestriction == nil {
+		return &GeoRestriction{Type: GeoRestrictionNone}
+	}
+
+	switch aws.StringValue(r.GeoRestriction.RestrictionType) {
+	case cloudfront.GeoRestrictionTypeWhitelist:
+		return &GeoRestriction{Type: GeoRestrictionAllow, Countries: aws.StringValueSlice(r.GeoRestriction.Items)}
+	case cloudfront.GeoRestrictionTypeBlacklist:
+		return &GeoRestriction{Type: GeoRestrictionDeny, Countries: aws.StringValueSlice(r.GeoRestriction.Items)}
+	}
+	return &GeoRestriction{Type: GeoRestrictionNone}
+}
+
+// newAWSOriginGroups returns the origin groups of the Distribution, or nil if there are none
+func newAWSOriginGroups(d Distribution) *cloudfront.OriginGroups {
+	var items []*cloudfront.OriginGroup
+	for _, o := range d.OriginGroups() {
+		items = append(items, &cloudfront.OriginGroup{
+			Id: aws.String(o.TargetID()),
+			FailoverCriteria: &cloudfront.OriginGroupFailoverCriteria{
+				StatusCodes: &cloudfront.StatusCodes{
+					Items:    aws.Int64Slice(o.Failover.StatusCodes),
+					Quantity: aws.Int64(int64(len(o.Failover.StatusCodes))),
+				},
+			},
+			Members: &cloudfront.OriginGroupMembers{
+				Items: []*cloudfront.OriginGroupMember{
+					{OriginId: aws.String(o.ID())},
+					{OriginId: aws.String(o.Failover.Secondary.ID())},
+				},
+				Quantity: aws.Int64(2),
+			},
+		})
+	}
+
+	if len(items) == 0 {
+		return nil
+	}
+	return &cloudfront.OriginGroups{
+		Items:    items,
+		Quantity: aws.Int64(int64(len(items))),
+	}
+}
+
+// originSSLProtocolsFrom returns the SSL/TLS protocols CloudFront may use to connect to an origin, from the given
+// minimum protocol up to the latest one. Only the latest protocol is returned if the minimum is unknown.
+func originSSLProtocolsFrom(minProtocol string) []string {
+	all := []string{originSSLProtocolSSLv3, originSSLProtocolTLSv1, originSSLProtocolTLSv11, originSSLProtocolTLSv12}
+	for i, p := range all {
+		if p == minProtocol {
+			return all[i:]
+		}
+	}
+	return []string{originSSLProtocolTLSv12}
+}
+
 func newCustomHeaders(o Origin) *cloudfront.CustomHeaders {
 	var items []*cloudfront.OriginCustomHeader
 	for k, v := range o.Headers() {
@@ -200,6 +311,24 @@ func newCacheBehavior(b Behavior) *cloudfront.CacheBehavior {
 	return cb
 }
 
+// newDefaultCacheBehavior returns the given Behavior as the default cache behavior of a distribution
+func newDefaultCacheBehavior(b Behavior) *cloudfront.DefaultCacheBehavior {
+	cb := newCacheBehavior(b)
+	return &cloudfront.DefaultCacheBehavior{
+		AllowedMethods:             cb.AllowedMethods,
+		CachePolicyId:              cb.CachePolicyId,
+		Compress:                   cb.Compress,
+		FieldLevelEncryptionId:     cb.FieldLevelEncryptionId,
+		FunctionAssociations:       cb.FunctionAssociations,
+		LambdaFunctionAssociations: cb.LambdaFunctionAssociations,
+		OriginRequestPolicyId:      cb.OriginRequestPolicyId,
+		ResponseHeadersPolicyId:    cb.ResponseHeadersPolicyId,
+		SmoothStreaming:            cb.SmoothStreaming,
+		TargetOriginId:             cb.TargetOriginId,
+		ViewerProtocolPolicy:       cb.ViewerProtocolPolicy,
+	}
+}
+
 func newAWSFunctionAssociation(functions []Function) []*cloudfront.FunctionAssociation {
 	var result []*cloudfront.FunctionAssociation
 	for _, fn := range functions {
@@ -228,16 +357,27 @@ func newAWSLambdaFunctionAssociation(functions []Function) []*cloudfront.LambdaF
 	return result
 }
 
+func newAWSAllowedMethods(allowed, cached []string) *cloudfront.AllowedMethods {
+	if len(allowed) == 0 {
+		allowed = defaultAllowedMethods
+	}
+	if len(cached) == 0 {
+		cached = defaultCachedMethods
+	}
+
+	return &cloudfront.AllowedMethods{
+		Items:    aws.StringSlice(allowed),
+		Quantity: aws.Int64(int64(len(allowed))),
+		CachedMethods: &cloudfront.CachedMethods{
+			Items:    aws.StringSlice(cached),
+			Quantity: aws.Int64(int64(len(cached))),
+		},
+	}
+}
+
 func baseCacheBehavior(b Behavior) *cloudfront.CacheBehavior {
 	cb := &cloudfront.CacheBehavior{
-		AllowedMethods: &cloudfront.AllowedMethods{
-			Items:    aws.StringSlice([]string{"GET", "HEAD", "OPTIONS", "PUT", "POST", "PATCH", "DELETE"}),
-			Quantity: aws.Int64(7),
-			CachedMethods: &cloudfront.CachedMethods{
-				Items:    aws.StringSlice([]string{"GET", "HEAD"}),
-				Quantity: aws.Int64(2),
-			},
-		},
+		AllowedMethods:             newAWSAllowedMethods(b.AllowedMethods, b.CachedMethods),
 		CachePolicyId:              aws.String(b.CachePolicy),
 		Compress:                   aws.Bool(true),
 		FieldLevelEncryptionId:     aws.String(""),
@@ -245,8 +385,12 @@ func baseCacheBehavior(b Behavior) *cloudfront.CacheBehavior {
 		OriginRequestPolicyId:      aws.String(b.RequestPolicy),
 		PathPattern:                aws.String(b.PathPattern),
 		SmoothStreaming:            aws.Bool(false),
-		TargetOriginId:             aws.String(b.OriginHost),
-		ViewerProtocolPolicy:       aws.String(cloudfront.ViewerProtocolPolicyRedirectToHttps),
+		TargetOriginId:             aws.String(b.OriginID),
+		ViewerProtocolPolicy:       aws.String(b.ViewerProtocolPolicy),
+	}
+
+	if len(b.ViewerProtocolPolicy) == 0 {
+		cb.ViewerProtocolPolicy = aws.String(defaultViewerProtocolPolicy)
 	}
 
 	if b.RequestPolicy == "None" {
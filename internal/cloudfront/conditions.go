@@ -0,0 +1,111 @@
+// Copyright (c) 2023 GPBR Participacoes LTDA.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package cloudfront
+
+import (
+	"errors"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/Gympass/cdn-origin-controller/api/v1alpha1"
+)
+
+// conditionError is an error which causes a CDNStatus condition to be false
+type conditionError struct {
+	conditionType string
+	reason        string
+	err           error
+}
+
+func newConditionError(conditionType, reason string, err error) error {
+	return conditionError{conditionType: conditionType, reason: reason, err: err}
+}
+
+func (e conditionError) Error() string {
+	return e.err.Error()
+}
+
+func (e conditionError) Unwrap() error {
+	return e.err
+}
+
+// setFailedCondition sets the condition related to the given error as false. Errors not related
+// to a specific condition cause the distribution to not be ready.
+func setFailedCondition(cdnStatus *v1alpha1.CDNStatus, err error) {
+	var condErr conditionError
+	if errors.As(err, &condErr) {
+		cdnStatus.SetCondition(condErr.conditionType, metav1.ConditionFalse, condErr.reason, err.Error())
+		return
+	}
+	cdnStatus.SetCondition(v1alpha1.ConditionDistributionReady, metav1.ConditionFalse, "ReconciliationFailed", err.Error())
+}
+
+// setCertificateCondition reports the TLS certificate used by the distribution, if TLS is enabled
+func setCertificateCondition(cdnStatus *v1alpha1.CDNStatus, desired Distribution) {
+	if !desired.TLS.Enabled {
+		cdnStatus.RemoveCondition(v1alpha1.ConditionCertificateResolved)
+		return
+	}
+	cdnStatus.SetCondition(v1alpha1.ConditionCertificateResolved, metav1.ConditionTrue, "CertificateFound",
+		"Using certificate "+desired.TLS.CertARN)
+}
+
+// setDistributionConditions reports the outcome of syncing the distribution
+func setDistributionConditions(cdnStatus *v1alpha1.CDNStatus, desired, synced Distribution, err error) {
+	switch {
+	case err != nil:
+		setFailedCondition(cdnStatus, err)
+		return
+	case desired.DryRun:
+		cdnStatus.SetCondition(v1alpha1.ConditionDistributionReady, metav1.ConditionFalse, "DryRun",
+			"Changes are only being planned, check the plan in the status")
+		return
+	case desired.IsEmpty():
+		return
+	}
+
+	cdnStatus.SetCondition(v1alpha1.ConditionDistributionReady, metav1.ConditionTrue, "Synced", "Distribution matches the desired state")
+	cdnStatus.SetSynced(metav1.Now())
+	if meta.FindStatusCondition(cdnStatus.Status.Conditions, v1alpha1.ConditionDrifted) != nil {
+		cdnStatus.SetCondition(v1alpha1.ConditionDrifted, metav1.ConditionFalse, "Synced", "Distribution matches the desired state")
+	}
+
+	if len(synced.WebACLID) > 0 {
+		cdnStatus.SetCondition(v1alpha1.ConditionWAFAttached, metav1.ConditionTrue, "WebACLAssociated", "Associated with Web ACL "+synced.WebACLID)
+	} else {
+		cdnStatus.RemoveCondition(v1alpha1.ConditionWAFAttached)
+	}
+
+	if synced.IsDeployed() {
+		cdnStatus.SetCondition(v1alpha1.ConditionDeployed, metav1.ConditionTrue, "Deployed", "Changes are deployed to all edge locations")
+	} else {
+		cdnStatus.SetCondition(v1alpha1.ConditionDeployed, metav1.ConditionFalse, "InProgress", "Changes are being deployed to edge locations")
+	}
+}
+
+// setDNSCondition reports the outcome of syncing DNS records for the distribution's aliases
+func setDNSCondition(cdnStatus *v1alpha1.CDNStatus, err error) {
+	if err != nil {
+		cdnStatus.SetCondition(v1alpha1.ConditionDNSReady, metav1.ConditionFalse, "SyncFailed", err.Error())
+		return
+	}
+	cdnStatus.SetCondition(v1alpha1.ConditionDNSReady, metav1.ConditionTrue, "Synced", "DNS records are in place for all aliases")
+}
@@ -0,0 +1,124 @@
+// Copyright (c) 2023 GPBR Participacoes LTDA.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package cloudfront
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/Gympass/cdn-origin-controller/api/v1alpha1"
+)
+
+func TestRunConditionsTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, &ConditionsTestSuite{})
+}
+
+type ConditionsTestSuite struct {
+	suite.Suite
+}
+
+func (s *ConditionsTestSuite) Test_setFailedCondition_WrappedConditionError() {
+	cdnStatus := &v1alpha1.CDNStatus{}
+	err := fmt.Errorf("computing desired state: %w", newConditionError(v1alpha1.ConditionCertificateResolved, "CertificateNotFound", errors.New("no cert")))
+
+	setFailedCondition(cdnStatus, err)
+
+	cond := meta.FindStatusCondition(cdnStatus.Status.Conditions, v1alpha1.ConditionCertificateResolved)
+	s.Equal(metav1.ConditionFalse, cond.Status)
+	s.Equal("CertificateNotFound", cond.Reason)
+	s.Equal("computing desired state: no cert", cond.Message)
+}
+
+func (s *ConditionsTestSuite) Test_setFailedCondition_GenericError() {
+	cdnStatus := &v1alpha1.CDNStatus{}
+
+	setFailedCondition(cdnStatus, errors.New("some error"))
+
+	cond := meta.FindStatusCondition(cdnStatus.Status.Conditions, v1alpha1.ConditionDistributionReady)
+	s.Equal(metav1.ConditionFalse, cond.Status)
+	s.Equal("some error", cond.Message)
+}
+
+func (s *ConditionsTestSuite) Test_setDistributionConditions_Synced() {
+	cdnStatus := &v1alpha1.CDNStatus{}
+	desired := Distribution{CustomOrigins: []Origin{{Host: "foo.com"}}, WebACLID: "acl"}
+	synced := desired
+	synced.DeploymentStatus = "InProgress"
+
+	setDistributionConditions(cdnStatus, desired, synced, nil)
+
+	s.True(cdnStatus.IsConditionTrue(v1alpha1.ConditionDistributionReady))
+	s.True(cdnStatus.IsConditionTrue(v1alpha1.ConditionWAFAttached))
+	s.False(cdnStatus.IsConditionTrue(v1alpha1.ConditionDeployed))
+	s.NotNil(cdnStatus.Status.LastSyncTime)
+}
+
+func (s *ConditionsTestSuite) Test_setDistributionConditions_NoWebACLRemovesCondition() {
+	cdnStatus := &v1alpha1.CDNStatus{}
+	cdnStatus.SetCondition(v1alpha1.ConditionWAFAttached, metav1.ConditionTrue, "WebACLAssociated", "")
+	desired := Distribution{CustomOrigins: []Origin{{Host: "foo.com"}}}
+	synced := desired
+	synced.DeploymentStatus = cfDeployedStatus
+
+	setDistributionConditions(cdnStatus, desired, synced, nil)
+
+	s.Nil(meta.FindStatusCondition(cdnStatus.Status.Conditions, v1alpha1.ConditionWAFAttached))
+	s.True(cdnStatus.IsConditionTrue(v1alpha1.ConditionDeployed))
+}
+
+func (s *ConditionsTestSuite) Test_setDistributionConditions_SyncClearsDrift() {
+	cdnStatus := &v1alpha1.CDNStatus{}
+	desired := Distribution{CustomOrigins: []Origin{{Host: "foo.com"}}}
+
+	setDistributionConditions(cdnStatus, desired, desired, nil)
+	s.Nil(meta.FindStatusCondition(cdnStatus.Status.Conditions, v1alpha1.ConditionDrifted))
+
+	cdnStatus.SetCondition(v1alpha1.ConditionDrifted, metav1.ConditionTrue, "DriftDetected", "drift")
+	setDistributionConditions(cdnStatus, desired, desired, nil)
+	s.True(meta.IsStatusConditionFalse(cdnStatus.Status.Conditions, v1alpha1.ConditionDrifted))
+}
+
+func (s *ConditionsTestSuite) Test_setDistributionConditions_DryRun() {
+	cdnStatus := &v1alpha1.CDNStatus{}
+	desired := Distribution{CustomOrigins: []Origin{{Host: "foo.com"}}, DryRun: true}
+
+	setDistributionConditions(cdnStatus, desired, desired, nil)
+
+	cond := meta.FindStatusCondition(cdnStatus.Status.Conditions, v1alpha1.ConditionDistributionReady)
+	s.Equal(metav1.ConditionFalse, cond.Status)
+	s.Equal("DryRun", cond.Reason)
+	s.Nil(cdnStatus.Status.LastSyncTime)
+}
+
+func (s *ConditionsTestSuite) Test_setCertificateCondition() {
+	cdnStatus := &v1alpha1.CDNStatus{}
+
+	setCertificateCondition(cdnStatus, Distribution{TLS: tlsConfig{Enabled: true, CertARN: "arn"}})
+	s.True(cdnStatus.IsConditionTrue(v1alpha1.ConditionCertificateResolved))
+
+	setCertificateCondition(cdnStatus, Distribution{})
+	s.Nil(meta.FindStatusCondition(cdnStatus.Status.Conditions, v1alpha1.ConditionCertificateResolved))
+}
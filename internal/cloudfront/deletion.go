@@ -0,0 +1,126 @@
+// Copyright (c) 2023 GPBR Participacoes LTDA.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package cloudfront
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	"github.com/Gympass/cdn-origin-controller/api/v1alpha1"
+	"github.com/Gympass/cdn-origin-controller/internal/metrics"
+)
+
+// deleteDistribution starts or carries on with the deletion of the group's distribution. CloudFront only deletes
+// distributions which are disabled and deployed, which takes minutes, so deletion goes through phases persisted in
+// the CDNStatus. It advances as far as possible and returns once a phase can't be completed yet, expecting to be
+// called again on a later reconciliation.
+func (s *Service) deleteDistribution(ctx context.Context, dist Distribution, cdnStatus *v1alpha1.CDNStatus) error {
+	log, _ := logr.FromContext(ctx)
+	if !s.Config.DeletionEnabled {
+		if dist.Exists() || cdnStatus.DeletionInProgress() {
+			log.V(1).Info("In a deletion operation, but configured not to delete Distributions. Will not delete.")
+		}
+		cdnStatus.FinishDeletion()
+		return nil
+	}
+
+	if !cdnStatus.DeletionInProgress() {
+		if !dist.Exists() {
+			return nil
+		}
+		log.V(1).Info("Starting deletion of distribution on AWS, it will be deleted once disabled and deployed.", "id", dist.ID)
+		cdnStatus.SetInfo(dist.ID, dist.ARN, cdnStatus.Status.Address)
+		cdnStatus.SetDeletionPhase(v1alpha1.DeletionPhaseDisabling, time.Now())
+	}
+
+	for cdnStatus.DeletionInProgress() {
+		phase := cdnStatus.Status.Deletion.Phase
+		advanced, err := s.advanceDeletion(cdnStatus)
+		if err != nil {
+			return fmt.Errorf("deleting distribution (phase %s): %v", phase, err)
+		}
+		if !advanced {
+			log.V(1).Info("Waiting for distribution to be deployed before deleting it.", "id", cdnStatus.Status.ID)
+			return nil
+		}
+	}
+
+	log.V(1).Info("Deleted distribution on AWS.", "id", cdnStatus.Status.ID)
+	return nil
+}
+
+// advanceDeletion runs the current phase of the distribution's deletion, moving it to the next phase if the current
+// one is complete. Returns whether it moved.
+func (s *Service) advanceDeletion(cdnStatus *v1alpha1.CDNStatus) (bool, error) {
+	id := cdnStatus.Status.ID
+	deletion := cdnStatus.Status.Deletion
+
+	switch deletion.Phase {
+	case v1alpha1.DeletionPhaseDisabling:
+		oacIDs, err := s.DistRepo.Disable(id)
+		if errors.Is(err, ErrDistNotFound) {
+			cdnStatus.SetDeletionPhase(v1alpha1.DeletionPhaseOACCleanup, time.Now())
+			return true, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		deletion.OACs = oacIDs
+		cdnStatus.SetDeletionPhase(v1alpha1.DeletionPhaseWaitingForDeploy, time.Now())
+
+	case v1alpha1.DeletionPhaseWaitingForDeploy:
+		deployed, err := s.DistRepo.IsDeployed(id)
+		if errors.Is(err, ErrDistNotFound) {
+			cdnStatus.SetDeletionPhase(v1alpha1.DeletionPhaseOACCleanup, time.Now())
+			return true, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		if !deployed {
+			return false, nil
+		}
+		if deletion.LastTransitionTime != nil {
+			metrics.DistributionDeploymentWaitDuration.Observe(time.Since(deletion.LastTransitionTime.Time).Seconds())
+		}
+		cdnStatus.SetDeletionPhase(v1alpha1.DeletionPhaseDeleting, time.Now())
+
+	case v1alpha1.DeletionPhaseDeleting:
+		if err := s.DistRepo.Delete(id); err != nil {
+			return false, err
+		}
+		cdnStatus.SetDeletionPhase(v1alpha1.DeletionPhaseOACCleanup, time.Now())
+
+	case v1alpha1.DeletionPhaseOACCleanup:
+		if err := s.DistRepo.DeleteOACs(deletion.OACs); err != nil {
+			return false, fmt.Errorf("deleting OACs: %v", err)
+		}
+		cdnStatus.FinishDeletion()
+
+	default:
+		return false, fmt.Errorf("unknown deletion phase %q", deletion.Phase)
+	}
+
+	return true, nil
+}
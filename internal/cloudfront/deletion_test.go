@@ -0,0 +1,131 @@
+// Copyright (c) 2023 GPBR Participacoes LTDA.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package cloudfront
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/Gympass/cdn-origin-controller/api/v1alpha1"
+	"github.com/Gympass/cdn-origin-controller/internal/config"
+)
+
+func TestRunDeletionTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, &DeletionTestSuite{})
+}
+
+type DeletionTestSuite struct {
+	suite.Suite
+}
+
+func newDeletingCDNStatus(phase v1alpha1.DeletionPhase, oacs ...string) *v1alpha1.CDNStatus {
+	cdnStatus := &v1alpha1.CDNStatus{
+		ObjectMeta: metav1.ObjectMeta{Name: "group"},
+		Status:     v1alpha1.CDNStatusStatus{ID: "dist-id"},
+	}
+	cdnStatus.SetDeletionPhase(phase, time.Now())
+	cdnStatus.Status.Deletion.OACs = oacs
+	return cdnStatus
+}
+
+func (s *DeletionTestSuite) TestDeleteDistribution_StartsDeletionAndWaitsForDeploy() {
+	distRepo := &distRepoMock{}
+	distRepo.On("Disable", "dist-id").Return([]string{"oac"}, nil).Once()
+	distRepo.On("IsDeployed", "dist-id").Return(false, nil).Once()
+
+	svc := &Service{Config: config.Config{DeletionEnabled: true}, DistRepo: distRepo}
+	cdnStatus := &v1alpha1.CDNStatus{ObjectMeta: metav1.ObjectMeta{Name: "group"}}
+
+	s.NoError(svc.deleteDistribution(context.Background(), Distribution{ID: "dist-id"}, cdnStatus))
+	s.True(cdnStatus.DeletionInProgress())
+	s.Equal(v1alpha1.DeletionPhaseWaitingForDeploy, cdnStatus.Status.Deletion.Phase)
+	s.Equal([]string{"oac"}, cdnStatus.Status.Deletion.OACs)
+	s.Equal("dist-id", cdnStatus.Status.ID)
+	distRepo.AssertExpectations(s.T())
+}
+
+func (s *DeletionTestSuite) TestDeleteDistribution_ResumesOnceDeployed() {
+	distRepo := &distRepoMock{}
+	distRepo.On("IsDeployed", "dist-id").Return(true, nil).Once()
+	distRepo.On("Delete", "dist-id").Return(nil).Once()
+	distRepo.On("DeleteOACs", []string{"oac"}).Return(nil).Once()
+
+	svc := &Service{Config: config.Config{DeletionEnabled: true}, DistRepo: distRepo}
+	cdnStatus := newDeletingCDNStatus(v1alpha1.DeletionPhaseWaitingForDeploy, "oac")
+
+	s.NoError(svc.deleteDistribution(context.Background(), Distribution{ID: "dist-id"}, cdnStatus))
+	s.False(cdnStatus.DeletionInProgress())
+	distRepo.AssertExpectations(s.T())
+}
+
+func (s *DeletionTestSuite) TestDeleteDistribution_DistributionAlreadyGone() {
+	distRepo := &distRepoMock{}
+	distRepo.On("Disable", "dist-id").Return([]string{}, ErrDistNotFound).Once()
+	distRepo.On("DeleteOACs", []string(nil)).Return(nil).Once()
+
+	svc := &Service{Config: config.Config{DeletionEnabled: true}, DistRepo: distRepo}
+	cdnStatus := newDeletingCDNStatus(v1alpha1.DeletionPhaseDisabling)
+
+	s.NoError(svc.deleteDistribution(context.Background(), Distribution{}, cdnStatus))
+	s.False(cdnStatus.DeletionInProgress())
+	distRepo.AssertExpectations(s.T())
+}
+
+func (s *DeletionTestSuite) TestDeleteDistribution_FailureKeepsPhase() {
+	distRepo := &distRepoMock{}
+	distRepo.On("Delete", "dist-id").Return(errors.New("mock err")).Once()
+
+	svc := &Service{Config: config.Config{DeletionEnabled: true}, DistRepo: distRepo}
+	cdnStatus := newDeletingCDNStatus(v1alpha1.DeletionPhaseDeleting, "oac")
+
+	s.Error(svc.deleteDistribution(context.Background(), Distribution{}, cdnStatus))
+	s.Equal(v1alpha1.DeletionPhaseDeleting, cdnStatus.Status.Deletion.Phase)
+	s.Equal([]string{"oac"}, cdnStatus.Status.Deletion.OACs)
+	distRepo.AssertNotCalled(s.T(), "DeleteOACs", mock.Anything)
+}
+
+func (s *DeletionTestSuite) TestDeleteDistribution_DeletionDisabled() {
+	distRepo := &distRepoMock{}
+
+	svc := &Service{Config: config.Config{DeletionEnabled: false}, DistRepo: distRepo}
+	cdnStatus := newDeletingCDNStatus(v1alpha1.DeletionPhaseWaitingForDeploy)
+
+	s.NoError(svc.deleteDistribution(context.Background(), Distribution{ID: "dist-id"}, cdnStatus))
+	s.False(cdnStatus.DeletionInProgress())
+	distRepo.AssertNotCalled(s.T(), "Disable", mock.Anything)
+}
+
+func (s *DeletionTestSuite) TestDeleteDistribution_NothingToDelete() {
+	distRepo := &distRepoMock{}
+
+	svc := &Service{Config: config.Config{DeletionEnabled: true}, DistRepo: distRepo}
+	cdnStatus := &v1alpha1.CDNStatus{ObjectMeta: metav1.ObjectMeta{Name: "group"}}
+
+	s.NoError(svc.deleteDistribution(context.Background(), Distribution{}, cdnStatus))
+	s.False(cdnStatus.DeletionInProgress())
+	distRepo.AssertNotCalled(s.T(), "Disable", mock.Anything)
+}
@@ -0,0 +1,319 @@
+// Copyright (c) 2023 GPBR Participacoes LTDA.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package cloudfront
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/aws/aws-sdk-go/aws"
+	awscloudfront "github.com/aws/aws-sdk-go/service/cloudfront"
+
+	"github.com/Gympass/cdn-origin-controller/internal/strhelper"
+)
+
+// identityFields are fields that uniquely identify an item within a list of the CloudFront API,
+// so lists of these items can be compared regardless of the order they're in
+var identityFields = []string{"Id", "PathPattern", "HeaderName", "Key", "EventType", "ErrorCode", "OriginId"}
+
+// orderedIdentityFields are identity fields of items whose position within a list is meaningful to CloudFront,
+// like cache behaviors, which are matched in order
+var orderedIdentityFields = []string{"PathPattern", "OriginId"}
+
+// derivedFields are fields whose value is derived from other fields, so they never represent a change on their own
+var derivedFields = []string{"Quantity"}
+
+// diffDistributionConfigs returns a human-readable description of each difference between the observed
+// and desired distribution configurations, in a deterministic order. It returns no changes if both are equivalent.
+//
+// Unset values are considered equivalent to their zero values, lists of strings are compared regardless of their order,
+// and read-only fields are ignored.
+func diffDistributionConfigs(observed, desired *awscloudfront.DistributionConfig) []string {
+	observed = withAWSDefaults(observed)
+	desired = withAWSDefaults(desired)
+
+	var changes []string
+	diffValues("", reflect.ValueOf(observed), reflect.ValueOf(desired), &changes)
+	return changes
+}
+
+// withAWSDefaults returns a copy of the distribution config with unset fields set to the values
+// CloudFront assigns to them when they're omitted and which are not zero values
+func withAWSDefaults(cfg *awscloudfront.DistributionConfig) *awscloudfront.DistributionConfig {
+	if cfg == nil {
+		return nil
+	}
+
+	cp := &awscloudfront.DistributionConfig{}
+	copyAWSStruct(cp, cfg)
+
+	if cp.ViewerCertificate == nil {
+		cp.ViewerCertificate = &awscloudfront.ViewerCertificate{
+			CloudFrontDefaultCertificate: aws.Bool(true),
+			MinimumProtocolVersion:       aws.String(awscloudfront.MinimumProtocolVersionTlsv1),
+			SSLSupportMethod:             aws.String(awscloudfront.SSLSupportMethodVip),
+		}
+	}
+
+	if cp.Origins != nil {
+		for i, o := range cp.Origins.Items {
+			oCopy := &awscloudfront.Origin{}
+			copyAWSStruct(oCopy, o)
+			if oCopy.ConnectionAttempts == nil {
+				oCopy.ConnectionAttempts = aws.Int64(3)
+			}
+			if oCopy.ConnectionTimeout == nil {
+				oCopy.ConnectionTimeout = aws.Int64(10)
+			}
+			cp.Origins.Items[i] = oCopy
+		}
+	}
+
+	return cp
+}
+
+// copyAWSStruct deep copies src into dst, which must be pointers to the same type
+func copyAWSStruct(dst, src interface{}) {
+	reflect.ValueOf(dst).Elem().Set(deepCopyValue(reflect.ValueOf(src).Elem()))
+}
+
+func deepCopyValue(v reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return reflect.Zero(v.Type())
+		}
+		cp := reflect.New(v.Type().Elem())
+		cp.Elem().Set(deepCopyValue(v.Elem()))
+		return cp
+	case reflect.Struct:
+		cp := reflect.New(v.Type()).Elem()
+		for i := 0; i < v.NumField(); i++ {
+			if cp.Field(i).CanSet() {
+				cp.Field(i).Set(deepCopyValue(v.Field(i)))
+			}
+		}
+		return cp
+	case reflect.Slice:
+		if v.IsNil() {
+			return reflect.Zero(v.Type())
+		}
+		cp := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			cp.Index(i).Set(deepCopyValue(v.Index(i)))
+		}
+		return cp
+	default:
+		return v
+	}
+}
+
+func diffValues(path string, observed, desired reflect.Value, changes *[]string) {
+	observed, desired = derefOrZero(observed, desired)
+
+	switch desired.Kind() {
+	case reflect.Struct:
+		diffStructs(path, observed, desired, changes)
+	case reflect.Slice:
+		diffSlices(path, observed, desired, changes)
+	default:
+		if !reflect.DeepEqual(observed.Interface(), desired.Interface()) {
+			*changes = append(*changes, fmt.Sprintf("%s: %s -> %s", path, formatValue(observed), formatValue(desired)))
+		}
+	}
+}
+
+// derefOrZero dereferences pointers, replacing nil ones with the zero value of the type they point to
+func derefOrZero(observed, desired reflect.Value) (reflect.Value, reflect.Value) {
+	for observed.Kind() == reflect.Ptr || desired.Kind() == reflect.Ptr {
+		observed = elemOrZero(observed)
+		desired = elemOrZero(desired)
+	}
+	return observed, desired
+}
+
+func elemOrZero(v reflect.Value) reflect.Value {
+	if v.Kind() != reflect.Ptr {
+		return v
+	}
+	if v.IsNil() {
+		return reflect.Zero(v.Type().Elem())
+	}
+	return v.Elem()
+}
+
+func diffStructs(path string, observed, desired reflect.Value, changes *[]string) {
+	t := desired.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() || f.Tag.Get("deprecated") == "true" || strhelper.Contains(derivedFields, f.Name) {
+			continue
+		}
+		diffValues(joinPath(path, f.Name), observed.Field(i), desired.Field(i), changes)
+	}
+}
+
+func diffSlices(path string, observed, desired reflect.Value, changes *[]string) {
+	if isStringSlice(desired.Type()) {
+		diffStringSets(path, observed, desired, changes)
+		return
+	}
+
+	idField := identityField(desired.Type().Elem())
+	if len(idField) == 0 {
+		diffSlicesByIndex(path, observed, desired, changes)
+		return
+	}
+
+	observedByID := indexByIdentity(observed, idField)
+	desiredByID := indexByIdentity(desired, idField)
+
+	for _, id := range sortedKeys(observedByID, desiredByID) {
+		itemPath := fmt.Sprintf("%s[%s=%s]", path, idField, id)
+		o, inObserved := observedByID[id]
+		d, inDesired := desiredByID[id]
+		switch {
+		case !inObserved:
+			*changes = append(*changes, itemPath+": added")
+		case !inDesired:
+			*changes = append(*changes, itemPath+": removed")
+		default:
+			diffValues(itemPath, o, d, changes)
+		}
+	}
+
+	if strhelper.Contains(orderedIdentityFields, idField) {
+		observedOrder := identities(observed, idField)
+		desiredOrder := identities(desired, idField)
+		if !reflect.DeepEqual(observedOrder, desiredOrder) {
+			*changes = append(*changes, fmt.Sprintf("%s order: %v -> %v", path, observedOrder, desiredOrder))
+		}
+	}
+}
+
+func diffSlicesByIndex(path string, observed, desired reflect.Value, changes *[]string) {
+	length := observed.Len()
+	if desired.Len() > length {
+		length = desired.Len()
+	}
+
+	for i := 0; i < length; i++ {
+		itemPath := fmt.Sprintf("%s[%d]", path, i)
+		switch {
+		case i >= observed.Len():
+			*changes = append(*changes, itemPath+": added")
+		case i >= desired.Len():
+			*changes = append(*changes, itemPath+": removed")
+		default:
+			diffValues(itemPath, observed.Index(i), desired.Index(i), changes)
+		}
+	}
+}
+
+func diffStringSets(path string, observed, desired reflect.Value, changes *[]string) {
+	o := sortedStrings(observed)
+	d := sortedStrings(desired)
+	if !reflect.DeepEqual(o, d) {
+		*changes = append(*changes, fmt.Sprintf("%s: %v -> %v", path, o, d))
+	}
+}
+
+func isStringSlice(t reflect.Type) bool {
+	elem := t.Elem()
+	if elem.Kind() == reflect.Ptr {
+		elem = elem.Elem()
+	}
+	return elem.Kind() == reflect.String
+}
+
+func sortedStrings(v reflect.Value) []string {
+	result := []string{}
+	for i := 0; i < v.Len(); i++ {
+		item := elemOrZero(v.Index(i))
+		result = append(result, item.String())
+	}
+	sort.Strings(result)
+	return result
+}
+
+func identityField(t reflect.Type) string {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return ""
+	}
+	for _, name := range identityFields {
+		if _, ok := t.FieldByName(name); ok {
+			return name
+		}
+	}
+	return ""
+}
+
+func identityOf(v reflect.Value, idField string) string {
+	return formatValue(elemOrZero(elemOrZero(v).FieldByName(idField)))
+}
+
+func indexByIdentity(v reflect.Value, idField string) map[string]reflect.Value {
+	result := make(map[string]reflect.Value)
+	for i := 0; i < v.Len(); i++ {
+		result[identityOf(v.Index(i), idField)] = v.Index(i)
+	}
+	return result
+}
+
+func identities(v reflect.Value, idField string) []string {
+	result := []string{}
+	for i := 0; i < v.Len(); i++ {
+		result = append(result, identityOf(v.Index(i), idField))
+	}
+	return result
+}
+
+func sortedKeys(maps ...map[string]reflect.Value) []string {
+	seen := make(map[string]bool)
+	var keys []string
+	for _, m := range maps {
+		for k := range m {
+			if !seen[k] {
+				seen[k] = true
+				keys = append(keys, k)
+			}
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func formatValue(v reflect.Value) string {
+	if v.Kind() == reflect.String {
+		return fmt.Sprintf("%q", v.String())
+	}
+	return fmt.Sprintf("%v", v.Interface())
+}
+
+func joinPath(path, field string) string {
+	if len(path) == 0 {
+		return field
+	}
+	return path + "." + field
+}
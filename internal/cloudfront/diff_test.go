@@ -0,0 +1,119 @@
+// Copyright (c) 2023 GPBR Participacoes LTDA.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package cloudfront
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	awscloudfront "github.com/aws/aws-sdk-go/service/cloudfront"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestRunDiffTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, &DiffTestSuite{})
+}
+
+type DiffTestSuite struct {
+	suite.Suite
+}
+
+func (s *DiffTestSuite) TestDiffDistributionConfigs_EqualConfigsHaveNoChanges() {
+	s.Empty(diffDistributionConfigs(testDistConfig(), testDistConfig()))
+}
+
+func (s *DiffTestSuite) TestDiffDistributionConfigs_UnsetValuesAreEqualToZeroValues() {
+	observed := testDistConfig()
+	observed.Comment = aws.String("")
+	observed.Origins.Items[0].OriginPath = aws.String("")
+
+	desired := testDistConfig()
+	desired.Comment = nil
+	desired.Origins.Items[0].OriginPath = nil
+
+	s.Empty(diffDistributionConfigs(observed, desired))
+}
+
+func (s *DiffTestSuite) TestDiffDistributionConfigs_AWSDefaultsAreNotChanges() {
+	observed := testDistConfig()
+	observed.Origins.Items[0].ConnectionAttempts = aws.Int64(3)
+	observed.Origins.Items[0].ConnectionTimeout = aws.Int64(10)
+
+	s.Empty(diffDistributionConfigs(observed, testDistConfig()))
+}
+
+func (s *DiffTestSuite) TestDiffDistributionConfigs_UnorderedListsAreEqual() {
+	observed := testDistConfig()
+	observed.Aliases = &awscloudfront.Aliases{Items: aws.StringSlice([]string{"b.com", "a.com"}), Quantity: aws.Int64(2)}
+	observed.Origins.Items[0], observed.Origins.Items[1] = observed.Origins.Items[1], observed.Origins.Items[0]
+
+	desired := testDistConfig()
+	desired.Aliases = &awscloudfront.Aliases{Items: aws.StringSlice([]string{"a.com", "b.com"}), Quantity: aws.Int64(2)}
+
+	s.Empty(diffDistributionConfigs(observed, desired))
+}
+
+func (s *DiffTestSuite) TestDiffDistributionConfigs_ChangedField() {
+	desired := testDistConfig()
+	desired.Origins.Items[1].DomainName = aws.String("other.com")
+
+	s.Equal([]string{`Origins.Items[Id="bar"].DomainName: "bar.com" -> "other.com"`}, diffDistributionConfigs(testDistConfig(), desired))
+}
+
+func (s *DiffTestSuite) TestDiffDistributionConfigs_AddedAndRemovedItems() {
+	desired := testDistConfig()
+	desired.Origins.Items[1] = &awscloudfront.Origin{Id: aws.String("baz"), DomainName: aws.String("baz.com")}
+
+	s.Equal([]string{
+		`Origins.Items[Id="bar"]: removed`,
+		`Origins.Items[Id="baz"]: added`,
+	}, diffDistributionConfigs(testDistConfig(), desired))
+}
+
+func (s *DiffTestSuite) TestDiffDistributionConfigs_BehaviorOrderIsAChange() {
+	observed := testDistConfig()
+	observed.CacheBehaviors = &awscloudfront.CacheBehaviors{Items: []*awscloudfront.CacheBehavior{
+		{PathPattern: aws.String("/a"), TargetOriginId: aws.String("foo")},
+		{PathPattern: aws.String("/b"), TargetOriginId: aws.String("bar")},
+	}}
+
+	desired := testDistConfig()
+	desired.CacheBehaviors = &awscloudfront.CacheBehaviors{Items: []*awscloudfront.CacheBehavior{
+		{PathPattern: aws.String("/b"), TargetOriginId: aws.String("bar")},
+		{PathPattern: aws.String("/a"), TargetOriginId: aws.String("foo")},
+	}}
+
+	s.Equal([]string{`CacheBehaviors.Items order: ["/a" "/b"] -> ["/b" "/a"]`}, diffDistributionConfigs(observed, desired))
+}
+
+func testDistConfig() *awscloudfront.DistributionConfig {
+	return &awscloudfront.DistributionConfig{
+		Comment: aws.String("comment"),
+		Enabled: aws.Bool(true),
+		Origins: &awscloudfront.Origins{
+			Items: []*awscloudfront.Origin{
+				{Id: aws.String("foo"), DomainName: aws.String("foo.com")},
+				{Id: aws.String("bar"), DomainName: aws.String("bar.com")},
+			},
+			Quantity: aws.Int64(2),
+		},
+	}
+}
@@ -23,7 +23,9 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+	"time"
 
+	"github.com/Gympass/cdn-origin-controller/api/v1alpha1"
 	"github.com/Gympass/cdn-origin-controller/internal/config"
 	"github.com/Gympass/cdn-origin-controller/internal/strhelper"
 )
@@ -44,6 +46,58 @@ type Distribution struct {
 	Tags             map[string]string
 	TLS              tlsConfig
 	WebACLID         string
+	// DefaultBehavior is the Behavior responding for requests no custom Behavior matches, when one of the custom
+	// Origins is the DefaultOrigin. If nil, the default origin and the default policies are used
+	DefaultBehavior *Behavior
+	// CustomErrorResponses configure how the Distribution responds to viewers when an origin returns an error.
+	// The existing configuration is kept if nil, and removed if empty
+	CustomErrorResponses []CustomErrorResponse
+	// GeoRestriction restricts which countries viewers can access the Distribution from.
+	// The existing restriction is kept if nil
+	GeoRestriction *GeoRestriction
+	// DefaultRootObject is the object returned when viewers request the root URL of the Distribution.
+	// The existing default root object is kept if nil, and removed if empty
+	DefaultRootObject *string
+	// KeptFields are the optional fields whose existing configuration is kept, regardless of the values above, since
+	// the group declares them with values that can't be parsed
+	KeptFields []v1alpha1.OptionalField
+	// DryRun means changes to the Distribution should only be planned, not applied
+	DryRun bool
+	// PlannedChanges are the changes that would have been applied to the Distribution, if in dry-run mode
+	PlannedChanges []string
+	// DeploymentStatus is the status of the Distribution's deployment on CloudFront, if it exists
+	DeploymentStatus string
+	// LastModifiedTime is when the Distribution was last modified on CloudFront, if it exists
+	LastModifiedTime time.Time
+}
+
+// CustomErrorResponse represents how CloudFront responds to viewers when an origin returns an error
+type CustomErrorResponse struct {
+	// ErrorCode is the status code returned by the origin
+	ErrorCode int64
+	// ResponsePagePath is the path of the page CloudFront returns to viewers instead of the origin's response, if any
+	ResponsePagePath string
+	// ResponseCode is the status code CloudFront returns to viewers along with the custom error page, if any
+	ResponseCode int64
+	// CachingMinTTL is how long, in seconds, CloudFront caches the error. CloudFront's default is used if nil
+	CachingMinTTL *int64
+}
+
+const (
+	// GeoRestrictionAllow means only viewers from the listed countries can access the Distribution
+	GeoRestrictionAllow = "allow"
+	// GeoRestrictionDeny means viewers from the listed countries can't access the Distribution
+	GeoRestrictionDeny = "deny"
+	// GeoRestrictionNone means viewers from any country can access the Distribution
+	GeoRestrictionNone = "none"
+)
+
+// GeoRestriction represents which countries viewers can access a Distribution from
+type GeoRestriction struct {
+	// Type is how Countries are restricted: GeoRestrictionAllow, GeoRestrictionDeny or GeoRestrictionNone
+	Type string
+	// Countries are the ISO 3166-1 alpha-2 codes of the countries being allowed or denied
+	Countries []string
 }
 
 type tlsConfig struct {
@@ -74,15 +128,23 @@ func (d Distribution) Exists() bool {
 	return len(d.ID) > 0
 }
 
+// IsDeployed returns whether the latest changes to the Distribution have been deployed to all edge locations
+func (d Distribution) IsDeployed() bool {
+	return d.DeploymentStatus == cfDeployedStatus
+}
+
 // IsEmpty return whether this Distribution has custom origins/behaviors
 func (d Distribution) IsEmpty() bool {
 	return len(d.CustomOrigins) == 0
 }
 
+// OACs returns the OACs of the Distribution's bucket origins. Origins with the same host share an OAC.
 func (d Distribution) OACs() []OAC {
 	var result []OAC
-	for _, o := range d.CustomOrigins {
-		if o.isBucketBased() {
+	seen := make(map[string]bool)
+	for _, o := range d.customAndSecondaryOrigins() {
+		if o.isBucketBased() && !seen[o.OAC.Name] {
+			seen[o.OAC.Name] = true
 			result = append(result, o.OAC)
 		}
 	}
@@ -90,7 +152,7 @@ func (d Distribution) OACs() []OAC {
 }
 
 func (d Distribution) HasOrigin(originHost string) bool {
-	for _, o := range d.CustomOrigins {
+	for _, o := range d.customAndSecondaryOrigins() {
 		if o.Host == originHost {
 			return true
 		}
@@ -98,13 +160,36 @@ func (d Distribution) HasOrigin(originHost string) bool {
 	return false
 }
 
+// OriginGroups returns the custom Origins which fail over to a secondary origin
+func (d Distribution) OriginGroups() []Origin {
+	var result []Origin
+	for _, o := range d.CustomOrigins {
+		if o.Failover != nil {
+			result = append(result, o)
+		}
+	}
+	return result
+}
+
+// customAndSecondaryOrigins returns the custom Origins along with the secondary origins they fail over to
+func (d Distribution) customAndSecondaryOrigins() []Origin {
+	var result []Origin
+	for _, o := range d.CustomOrigins {
+		result = append(result, o)
+		if o.Failover != nil {
+			result = append(result, o.Failover.Secondary)
+		}
+	}
+	return result
+}
+
 // DistributionBuilder allows the construction of a Distribution
 type DistributionBuilder struct {
 	id                  string
 	address             string
 	alternateDomains    []string
 	arn                 string
-	customOrigins       map[string]Origin // map[originHost]Origin
+	customOrigins       map[string]Origin // map[origin identity]Origin
 	defaultOriginDomain string
 	description         string
 	ipv6Enabled         bool
@@ -114,6 +199,11 @@ type DistributionBuilder struct {
 	tags                map[string]string
 	tls                 tlsConfig
 	webACLID            string
+	errorResponses      []CustomErrorResponse
+	geoRestriction      *GeoRestriction
+	defaultRootObject   *string
+	keptFields          []v1alpha1.OptionalField
+	dryRun              bool
 	cfg                 config.Config
 }
 
@@ -130,13 +220,39 @@ func NewDistributionBuilder(group string, cfg config.Config) DistributionBuilder
 }
 
 // WithOrigin takes in an Origin that should be part of the Distribution.
-// If called more than once, input Origins with matching host overwrite each other, and the last
-// one to be processed remains.
+// If called more than once, input Origins with equal parameters are merged into a single Origin
+// with the Behaviors of all of them. Behaviors with matching path patterns overwrite each other,
+// and the last one to be processed remains.
 func (b DistributionBuilder) WithOrigin(o Origin) DistributionBuilder {
-	b.customOrigins[o.Host] = o
+	existing, ok := b.customOrigins[o.identity()]
+	if !ok {
+		b.customOrigins[o.identity()] = o
+		return b
+	}
+
+	existing.Behaviors = mergeBehaviors(existing.Behaviors, o.Behaviors)
+	existing.isDefault = existing.isDefault || o.isDefault
+	b.customOrigins[o.identity()] = existing
 	return b
 }
 
+func mergeBehaviors(existing, candidates []Behavior) []Behavior {
+	result := append([]Behavior{}, existing...)
+	for _, candidate := range candidates {
+		replaced := false
+		for i := range result {
+			if result[i].PathPattern == candidate.PathPattern {
+				result[i] = candidate
+				replaced = true
+			}
+		}
+		if !replaced {
+			result = append(result, candidate)
+		}
+	}
+	return result
+}
+
 // WithLogging takes in bucket address and file prefix to enable sending CF logs to S3
 func (b DistributionBuilder) WithLogging(bucketAddress, prefix string) DistributionBuilder {
 	b.logging = loggingConfig{
@@ -185,6 +301,36 @@ func (b DistributionBuilder) WithWebACL(id string) DistributionBuilder {
 	return b
 }
 
+// WithCustomErrorResponses takes the custom error responses the Distribution should have
+func (b DistributionBuilder) WithCustomErrorResponses(responses []CustomErrorResponse) DistributionBuilder {
+	b.errorResponses = responses
+	return b
+}
+
+// WithGeoRestriction takes the geo restriction the Distribution should have
+func (b DistributionBuilder) WithGeoRestriction(restriction *GeoRestriction) DistributionBuilder {
+	b.geoRestriction = restriction
+	return b
+}
+
+// WithDefaultRootObject takes the default root object the Distribution should have
+func (b DistributionBuilder) WithDefaultRootObject(object *string) DistributionBuilder {
+	b.defaultRootObject = object
+	return b
+}
+
+// WithKeptFields takes the optional fields whose existing configuration the Distribution should keep
+func (b DistributionBuilder) WithKeptFields(fields []v1alpha1.OptionalField) DistributionBuilder {
+	b.keptFields = fields
+	return b
+}
+
+// WithDryRun configures the Distribution to only have its changes planned, instead of applied
+func (b DistributionBuilder) WithDryRun() DistributionBuilder {
+	b.dryRun = true
+	return b
+}
+
 // WithARN takes in identifying information from an existing CloudFront to populate the resulting Distribution
 func (b DistributionBuilder) WithARN(arn string) DistributionBuilder {
 	b.id = b.extractID(arn)
@@ -195,6 +341,10 @@ func (b DistributionBuilder) WithARN(arn string) DistributionBuilder {
 // extractID assumes a valid ARN is given
 // arn:aws:cloudfront::<account>:distribution/<ID>
 func (b DistributionBuilder) extractID(arn string) string {
+	return distributionIDFromARN(arn)
+}
+
+func distributionIDFromARN(arn string) string {
 	return strings.Split(arn, "/")[1]
 }
 
@@ -215,12 +365,19 @@ func (b DistributionBuilder) Build() (Distribution, error) {
 		IPv6Enabled:      b.ipv6Enabled,
 		AlternateDomains: b.alternateDomains,
 		WebACLID:         b.webACLID,
+		DryRun:           b.dryRun,
+
+		CustomErrorResponses: b.errorResponses,
+		GeoRestriction:       b.geoRestriction,
+		DefaultRootObject:    b.defaultRootObject,
 	}
 
+	d = withKeptFields(d, b.keptFields)
+	d = withOriginIDs(d)
 	if err := validate(d); err != nil {
 		return Distribution{}, err
 	}
-	return mergeCustomOrigins(d), nil
+	return withDefaultBehavior(d), nil
 }
 
 func (b DistributionBuilder) generateTags() map[string]string {
@@ -232,15 +389,19 @@ func (b DistributionBuilder) generateTags() map[string]string {
 }
 
 const (
-	ownershipTagKey   = "cdn-origin-controller.gympass.com/owned"
-	ownershipTagValue = "true"
-	groupTagKey       = "cdn-origin-controller.gympass.com/cdn.group"
+	ownershipTagKey    = "cdn-origin-controller.gympass.com/owned"
+	ownershipTagValue  = "true"
+	groupTagKey        = "cdn-origin-controller.gympass.com/cdn.group"
+	controllerIDTagKey = "cdn-origin-controller.gympass.com/controller-id"
 )
 
 func (b DistributionBuilder) defaultTags() map[string]string {
 	tags := make(map[string]string)
 	tags[ownershipTagKey] = ownershipTagValue
 	tags[groupTagKey] = b.group
+	if len(b.cfg.ControllerID) > 0 {
+		tags[controllerIDTagKey] = b.cfg.ControllerID
+	}
 	return tags
 }
 
@@ -252,47 +413,116 @@ func (b DistributionBuilder) originSlice() []Origin {
 	return result
 }
 
-func validate(d Distribution) error {
-	var origins []Origin
-	origins = append(origins, d.DefaultOrigin)
-	origins = append(origins, d.CustomOrigins...)
-
-	existingOrigins := make(map[string]Origin)
-	for _, o := range origins {
-		if existing, ok := existingOrigins[o.Host]; ok {
-			if !existing.HasEqualParameters(o) {
-				return fmt.Errorf("same host (%s) specified twice with different parameters for origin configuration", o.Host)
-			}
+// withKeptFields unsets the given optional fields of the Distribution, so that their existing configuration is kept
+func withKeptFields(d Distribution, fields []v1alpha1.OptionalField) Distribution {
+	d.KeptFields = fields
+	for _, f := range fields {
+		switch f {
+		case v1alpha1.OptionalFieldCustomErrorResponses:
+			d.CustomErrorResponses = nil
+		case v1alpha1.OptionalFieldGeoRestriction:
+			d.GeoRestriction = nil
+		case v1alpha1.OptionalFieldDefaultRootObject:
+			d.DefaultRootObject = nil
 		}
-		existingOrigins[o.Host] = o
 	}
-	return nil
+	return d
+}
+
+// IsKeptField returns whether the existing configuration of the given optional field is kept
+func (d Distribution) IsKeptField(field v1alpha1.OptionalField) bool {
+	for _, f := range d.KeptFields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
+// withOriginIDs identifies each Origin of the Distribution. An Origin is identified by its host, unless other
+// Origins with different parameters share that host, in which case only the extra ones are identified by their host
+// and a hash of their parameters. The default Origin always keeps its host as ID, so that the default Behavior
+// keeps targeting it, and among custom Origins the host is kept by the one with the lowest hash, so that IDs don't
+// depend on the order Origins are added in. Origins which need new IDs are renamed along with the Behaviors targeting
+// them within a single update of the distribution.
+func withOriginIDs(d Distribution) Distribution {
+	plainIdentityByHost := map[string]Origin{d.DefaultOrigin.Host: d.DefaultOrigin}
+	for _, o := range d.customAndSecondaryOrigins() {
+		current, ok := plainIdentityByHost[o.Host]
+		if !ok || (current.Host != d.DefaultOrigin.Host && o.hashedID() < current.hashedID()) {
+			plainIdentityByHost[o.Host] = o
+		}
+	}
+
+	idOf := func(o Origin) string {
+		if o.identity() == plainIdentityByHost[o.Host].identity() {
+			return o.Host
+		}
+		return o.hashedID()
+	}
+
+	d.DefaultOrigin = d.DefaultOrigin.withID(idOf(d.DefaultOrigin))
+
+	var customOrigins []Origin
+	for _, o := range d.CustomOrigins {
+		if o.Failover != nil {
+			failover := *o.Failover
+			failover.Secondary = failover.Secondary.withID(idOf(failover.Secondary))
+			o.Failover = &failover
+		}
+		customOrigins = append(customOrigins, o.withID(idOf(o)))
+	}
+	d.CustomOrigins = customOrigins
+	return d
 }
 
-// mergeCustomOrigins ensures duplicate origins are merged into a single one.
-// It expects a valid Distribution as input.
-func mergeCustomOrigins(d Distribution) Distribution {
-	merged := mergedOriginMap(d)
+// withDefaultBehavior promotes the catch-all Behavior of the custom Origin asking to be the default one, if any,
+// to the default Behavior of the Distribution, replacing the default origin. Since validate ensures such Origins have
+// a catch-all Behavior, it also ensures there's at most one of them.
+func withDefaultBehavior(d Distribution) Distribution {
+	var customOrigins []Origin
+	for _, o := range d.CustomOrigins {
+		if !o.isDefault {
+			customOrigins = append(customOrigins, o)
+			continue
+		}
+
+		var behaviors []Behavior
+		for _, b := range o.Behaviors {
+			if b.PathPattern == catchAllPathPattern {
+				defaultBehavior := b
+				defaultBehavior.PathPattern = defaultPathPattern
+				d.DefaultBehavior = &defaultBehavior
+				continue
+			}
+			behaviors = append(behaviors, b)
+		}
+		o.Behaviors = behaviors
+		customOrigins = append(customOrigins, o)
 
-	var normalized []Origin
-	for _, origin := range merged {
-		normalized = append(normalized, origin)
+		d.DefaultOrigin = o
+		d.DefaultOrigin.Behaviors = nil
 	}
 
-	d.CustomOrigins = normalized
+	d.CustomOrigins = customOrigins
 	return d
 }
 
-func mergedOriginMap(d Distribution) map[string]Origin {
-	existingOrigins := make(map[string]Origin) // map[host]origin
-	for _, candidateO := range d.CustomOrigins {
-		existingO, ok := existingOrigins[candidateO.Host]
-		if !ok {
-			existingOrigins[candidateO.Host] = candidateO
-		} else {
-			existingO.Behaviors = append(existingO.Behaviors, candidateO.Behaviors...)
-			existingOrigins[existingO.Host] = existingO
+// validate ensures no path pattern is routed to more than one Origin and that Origins asking to be the default one
+// have a catch-all Behavior to promote
+func validate(d Distribution) error {
+	for _, o := range d.CustomOrigins {
+		if o.isDefault && !o.hasBehavior(catchAllPathPattern) {
+			return fmt.Errorf("origin %s is the default behavior's target but has no %s path", o.ID(), catchAllPathPattern)
 		}
 	}
-	return existingOrigins
+
+	originIDByPath := make(map[string]string)
+	for _, b := range d.SortedCustomBehaviors() {
+		if id, ok := originIDByPath[b.PathPattern]; ok && id != b.OriginID {
+			return fmt.Errorf("path pattern %s is routed to more than one origin (%s and %s)", b.PathPattern, id, b.OriginID)
+		}
+		originIDByPath[b.PathPattern] = b.OriginID
+	}
+	return nil
 }
@@ -20,10 +20,13 @@
 package cloudfront_test
 
 import (
+	"strings"
 	"testing"
 
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/stretchr/testify/suite"
 
+	"github.com/Gympass/cdn-origin-controller/api/v1alpha1"
 	"github.com/Gympass/cdn-origin-controller/internal/cloudfront"
 	"github.com/Gympass/cdn-origin-controller/internal/config"
 )
@@ -79,6 +82,16 @@ func (s *DistributionTestSuite) TestDistributionBuilder_New() {
 	s.Equal("test price class", dist.PriceClass)
 	s.Equal("true", dist.Tags["cdn-origin-controller.gympass.com/owned"])
 	s.Equal("test group", dist.Tags["cdn-origin-controller.gympass.com/cdn.group"])
+	s.NotContains(dist.Tags, "cdn-origin-controller.gympass.com/controller-id")
+}
+
+func (s *DistributionTestSuite) TestDistributionBuilder_TagsControllerID() {
+	cfg := s.cfg
+	cfg.ControllerID = "cluster-a"
+
+	dist, err := cloudfront.NewDistributionBuilder("test group", cfg).Build()
+	s.NoError(err)
+	s.Equal("cluster-a", dist.Tags["cdn-origin-controller.gympass.com/controller-id"])
 }
 
 func (s *DistributionTestSuite) TestDistributionBuilder_WithOrigin() {
@@ -98,6 +111,171 @@ func (s *DistributionTestSuite) TestDistributionBuilder_WithOrigin() {
 	s.Equal(origin, dist.CustomOrigins[0])
 }
 
+func (s *DistributionTestSuite) TestDistributionBuilder_WithOriginsOfSameHostAndDifferentPaths() {
+	assets := cloudfront.NewOriginBuilder("dist", "bucket.s3.us-east-1.amazonaws.com", "Bucket", s.cfg).
+		WithOriginPath("/assets").
+		WithBehavior("/assets/*").
+		Build()
+	docs := cloudfront.NewOriginBuilder("dist", "bucket.s3.us-east-1.amazonaws.com", "Bucket", s.cfg).
+		WithOriginPath("/docs").
+		WithBehavior("/docs/*").
+		Build()
+
+	dist, err := cloudfront.NewDistributionBuilder("test group", s.cfg).
+		WithOrigin(assets).
+		WithOrigin(docs).
+		Build()
+
+	s.NoError(err)
+	s.Len(dist.CustomOrigins, 2)
+	s.NotEqual(dist.CustomOrigins[0].ID(), dist.CustomOrigins[1].ID())
+	var hashed int
+	for _, o := range dist.CustomOrigins {
+		if strings.HasPrefix(o.ID(), "bucket.s3.us-east-1.amazonaws.com-") {
+			hashed++
+		}
+		s.Len(o.Behaviors, 1)
+		s.Equal(o.ID(), o.Behaviors[0].OriginID)
+	}
+	s.Equal(1, hashed, "only the extra origins sharing a host should have hashed IDs")
+	s.Len(dist.OACs(), 1, "origins of the same bucket should share an OAC")
+}
+
+func (s *DistributionTestSuite) TestDistributionBuilder_WithOriginsOfSameHostAndDifferentParameters() {
+	newOrigin := func(timeout int64, pathPattern string) cloudfront.Origin {
+		return cloudfront.NewOriginBuilder("dist", "test.custom.origin", "Public", s.cfg).
+			WithResponseTimeout(timeout).
+			WithBehavior(pathPattern).
+			Build()
+	}
+
+	dist, err := cloudfront.NewDistributionBuilder("test group", s.cfg).
+		WithOrigin(newOrigin(10, "/fast")).
+		WithOrigin(newOrigin(60, "/slow")).
+		Build()
+	s.NoError(err)
+	s.Len(dist.CustomOrigins, 2)
+
+	idByPath := make(map[string]string)
+	for _, b := range dist.SortedCustomBehaviors() {
+		idByPath[b.PathPattern] = b.OriginID
+	}
+	s.NotEqual(idByPath["/fast"], idByPath["/slow"])
+
+	// IDs are stable across builds
+	again, err := cloudfront.NewDistributionBuilder("test group", s.cfg).
+		WithOrigin(newOrigin(60, "/slow")).
+		WithOrigin(newOrigin(10, "/fast")).
+		Build()
+	s.NoError(err)
+	for _, b := range again.SortedCustomBehaviors() {
+		s.Equal(idByPath[b.PathPattern], b.OriginID)
+	}
+}
+
+func (s *DistributionTestSuite) TestDistributionBuilder_AddingOriginsSharingAHostKeepsExistingIDs() {
+	newOrigin := func(timeout int64, pathPattern string) cloudfront.Origin {
+		return cloudfront.NewOriginBuilder("dist", "test.custom.origin", "Public", s.cfg).
+			WithResponseTimeout(timeout).
+			WithBehavior(pathPattern).
+			Build()
+	}
+
+	dist, err := cloudfront.NewDistributionBuilder("test group", s.cfg).
+		WithOrigin(newOrigin(10, "/first")).
+		WithOrigin(newOrigin(20, "/second")).
+		Build()
+	s.NoError(err)
+	idByPath := make(map[string]string)
+	for _, b := range dist.SortedCustomBehaviors() {
+		idByPath[b.PathPattern] = b.OriginID
+	}
+
+	for _, timeout := range []int64{1, 30, 40, 50, 60} {
+		again, err := cloudfront.NewDistributionBuilder("test group", s.cfg).
+			WithOrigin(newOrigin(10, "/first")).
+			WithOrigin(newOrigin(20, "/second")).
+			WithOrigin(newOrigin(timeout, "/third")).
+			Build()
+		s.NoError(err)
+
+		var renamed int
+		for _, b := range again.SortedCustomBehaviors() {
+			if id, ok := idByPath[b.PathPattern]; ok && id != b.OriginID {
+				renamed++
+			}
+		}
+		s.LessOrEqualf(renamed, 1, "at most the origin keeping the host as ID may be renamed, timeout: %d", timeout)
+	}
+}
+
+func (s *DistributionTestSuite) TestDistributionBuilder_WithOriginsOfEqualParameters() {
+	dist, err := cloudfront.NewDistributionBuilder("test group", s.cfg).
+		WithOrigin(cloudfront.NewOriginBuilder("dist", "test.custom.origin", "Public", s.cfg).WithBehavior("/foo").WithBehavior("/bar").Build()).
+		WithOrigin(cloudfront.NewOriginBuilder("dist", "test.custom.origin", "Public", s.cfg).WithBehavior("/bar").WithBehavior("/baz").Build()).
+		Build()
+
+	s.NoError(err)
+	s.Len(dist.CustomOrigins, 1)
+	s.Equal("test.custom.origin", dist.CustomOrigins[0].ID(), "origins not sharing their host with others keep it as ID")
+	s.Len(dist.CustomOrigins[0].Behaviors, 3)
+}
+
+func (s *DistributionTestSuite) TestDistributionBuilder_SamePathPatternOnDifferentOrigins() {
+	_, err := cloudfront.NewDistributionBuilder("test group", s.cfg).
+		WithOrigin(cloudfront.NewOriginBuilder("dist", "test.custom.origin", "Public", s.cfg).WithResponseTimeout(10).WithBehavior("/foo").Build()).
+		WithOrigin(cloudfront.NewOriginBuilder("dist", "test.custom.origin", "Public", s.cfg).WithResponseTimeout(20).WithBehavior("/foo").Build()).
+		Build()
+	s.Error(err)
+}
+
+func (s *DistributionTestSuite) TestDistributionBuilder_DefaultOriginHostWithDifferentParameters() {
+	dist, err := cloudfront.NewDistributionBuilder("test group", s.cfg).
+		WithOrigin(cloudfront.NewOriginBuilder("dist", "test.default.origin", "Public", s.cfg).
+			WithResponseTimeout(10).
+			WithBehavior("/foo").
+			Build()).
+		Build()
+	s.NoError(err)
+	s.Equal("test.default.origin", dist.DefaultOrigin.ID(), "the default origin should keep its host as ID")
+	s.NotEqual(dist.DefaultOrigin.ID(), dist.CustomOrigins[0].ID())
+	s.Equal(dist.CustomOrigins[0].ID(), dist.CustomOrigins[0].Behaviors[0].OriginID)
+
+	dist, err = cloudfront.NewDistributionBuilder("test group", s.cfg).
+		WithOrigin(cloudfront.NewOriginBuilder("dist", "test.default.origin", "Public", s.cfg).WithBehavior("/foo").Build()).
+		Build()
+	s.NoError(err)
+	s.Equal("test.default.origin", dist.DefaultOrigin.ID())
+	s.Equal("test.default.origin", dist.CustomOrigins[0].ID(), "same origin as the default one")
+}
+
+func (s *DistributionTestSuite) TestDistributionBuilder_WithFailover() {
+	primary := cloudfront.NewOriginBuilder("dist", "alb.us-east-1.com", "Public", s.cfg).
+		WithFailover("alb.us-west-2.com", "", "Public", []int64{500}).
+		WithBehavior("/foo").
+		Build()
+	maintenance := cloudfront.NewOriginBuilder("dist", "alb.us-east-1.com", "Public", s.cfg).
+		WithFailover("bucket.s3.amazonaws.com", "", "Bucket", []int64{503}).
+		WithBehavior("/bar").
+		Build()
+
+	dist, err := cloudfront.NewDistributionBuilder("test group", s.cfg).
+		WithOrigin(primary).
+		WithOrigin(maintenance).
+		Build()
+
+	s.NoError(err)
+	s.Len(dist.CustomOrigins, 2)
+	s.Len(dist.OriginGroups(), 2)
+	s.NotEqual(dist.CustomOrigins[0].ID(), dist.CustomOrigins[1].ID(), "origins with different failovers are different origins")
+	for _, o := range dist.CustomOrigins {
+		s.Equal(o.TargetID(), o.Behaviors[0].OriginID)
+		s.Equal(o.Failover.Secondary.Host, o.Failover.Secondary.ID(), "secondary origins not sharing their host keep it as ID")
+	}
+	s.True(dist.HasOrigin("bucket.s3.amazonaws.com"))
+	s.Equal([]cloudfront.OAC{cloudfront.NewOAC("dist", "bucket.s3.amazonaws.com", "")}, dist.OACs())
+}
+
 func (s *DistributionTestSuite) TestDistributionBuilder_WithLogging() {
 	bucketAddr := "test.bucket.address"
 	prefix := "test prefix"
@@ -185,6 +363,37 @@ func (s *DistributionTestSuite) TestDistributionBuilder_WithWebACL() {
 	s.Equal("test:acl", dist.WebACLID)
 }
 
+func (s *DistributionTestSuite) TestDistributionBuilder_WithCustomErrorResponses() {
+	responses := []cloudfront.CustomErrorResponse{{ErrorCode: 404, ResponsePagePath: "/index.html", ResponseCode: 200}}
+	dist, err := cloudfront.NewDistributionBuilder("test group", s.cfg).
+		WithCustomErrorResponses(responses).
+		Build()
+
+	s.NoError(err)
+	s.Equal(responses, dist.CustomErrorResponses)
+}
+
+func (s *DistributionTestSuite) TestDistributionBuilder_WithKeptFields() {
+	dist, err := cloudfront.NewDistributionBuilder("test group", s.cfg).
+		WithCustomErrorResponses([]cloudfront.CustomErrorResponse{}).
+		WithGeoRestriction(&cloudfront.GeoRestriction{Type: cloudfront.GeoRestrictionNone}).
+		WithDefaultRootObject(aws.String("")).
+		WithKeptFields([]v1alpha1.OptionalField{
+			v1alpha1.OptionalFieldCustomErrorResponses,
+			v1alpha1.OptionalFieldGeoRestriction,
+			v1alpha1.OptionalFieldDefaultRootObject,
+		}).
+		Build()
+
+	s.NoError(err)
+	s.Nil(dist.CustomErrorResponses, "existing custom error responses should be kept")
+	s.Nil(dist.GeoRestriction, "existing geo restriction should be kept")
+	s.Nil(dist.DefaultRootObject, "existing default root object should be kept")
+	s.True(dist.IsKeptField(v1alpha1.OptionalFieldCustomErrorResponses))
+	s.True(dist.IsKeptField(v1alpha1.OptionalFieldGeoRestriction))
+	s.True(dist.IsKeptField(v1alpha1.OptionalFieldDefaultRootObject))
+}
+
 func (s *DistributionTestSuite) TestDistributionBuilder_WithARN() {
 	dist, err := cloudfront.NewDistributionBuilder("group", s.cfg).
 		WithARN("arn:aws:cloudfront::000000000000:distribution/AAAAAAAAAAAAAA").
@@ -194,3 +403,74 @@ func (s *DistributionTestSuite) TestDistributionBuilder_WithARN() {
 	s.Equal("arn:aws:cloudfront::000000000000:distribution/AAAAAAAAAAAAAA", dist.ARN)
 	s.Equal("AAAAAAAAAAAAAA", dist.ID)
 }
+
+func (s *DistributionTestSuite) TestDistributionBuilder_WithDefaultBehavior() {
+	spa := cloudfront.NewOriginBuilder("dist", "spa.s3.amazonaws.com", "Bucket", s.cfg).
+		WithCachePolicy("spa-cache-policy").
+		WithBehavior("/*").
+		WithMethods("/*", []string{"GET", "HEAD"}, nil).
+		WithBehavior("/assets/*").
+		WithDefaultBehavior().
+		Build()
+	api := cloudfront.NewOriginBuilder("dist", "api.com", "Public", s.cfg).WithBehavior("/api/*").Build()
+
+	dist, err := cloudfront.NewDistributionBuilder("test group", s.cfg).
+		WithOrigin(spa).
+		WithOrigin(api).
+		Build()
+
+	s.NoError(err)
+	s.Equal("spa.s3.amazonaws.com", dist.DefaultOrigin.Host)
+	s.Empty(dist.DefaultOrigin.Behaviors)
+	s.Require().NotNil(dist.DefaultBehavior)
+	s.Equal("*", dist.DefaultBehavior.PathPattern)
+	s.Equal("spa.s3.amazonaws.com", dist.DefaultBehavior.OriginID)
+	s.Equal("spa-cache-policy", dist.DefaultBehavior.CachePolicy)
+	s.Equal([]string{"GET", "HEAD"}, dist.DefaultBehavior.AllowedMethods)
+
+	s.Len(dist.CustomOrigins, 2, "the default origin keeps serving its other behaviors")
+	var patterns []string
+	for _, b := range dist.SortedCustomBehaviors() {
+		patterns = append(patterns, b.PathPattern)
+	}
+	s.Equal([]string{"/assets/*", "/api/*"}, patterns, "the catch-all behavior is served by the default behavior")
+}
+
+func (s *DistributionTestSuite) TestDistributionBuilder_WithDefaultBehaviorWithoutCatchAllBehavior() {
+	spa := cloudfront.NewOriginBuilder("dist", "spa.com", "Public", s.cfg).
+		WithBehavior("/app/*").
+		WithDefaultBehavior().
+		Build()
+
+	other := cloudfront.NewOriginBuilder("dist", "other.com", "Public", s.cfg).WithBehavior("/*").Build()
+
+	_, err := cloudfront.NewDistributionBuilder("test group", s.cfg).WithOrigin(spa).Build()
+	s.ErrorContains(err, "spa.com")
+
+	_, err = cloudfront.NewDistributionBuilder("test group", s.cfg).WithOrigin(spa).WithOrigin(other).Build()
+	s.Error(err, "the catch-all path of another origin should not be promoted")
+}
+
+func (s *DistributionTestSuite) TestDistributionBuilder_WithDefaultBehaviorTargetingOriginGroup() {
+	primary := cloudfront.NewOriginBuilder("dist", "alb.us-east-1.com", "Public", s.cfg).
+		WithFailover("alb.us-west-2.com", "", "Public", []int64{500}).
+		WithBehavior("/*").
+		WithDefaultBehavior().
+		Build()
+
+	dist, err := cloudfront.NewDistributionBuilder("test group", s.cfg).WithOrigin(primary).Build()
+
+	s.NoError(err)
+	s.Require().NotNil(dist.DefaultBehavior)
+	s.Equal(dist.DefaultOrigin.TargetID(), dist.DefaultBehavior.OriginID)
+	s.Len(dist.OriginGroups(), 1)
+}
+
+func (s *DistributionTestSuite) TestDistributionBuilder_MoreThanOneDefaultBehavior() {
+	_, err := cloudfront.NewDistributionBuilder("test group", s.cfg).
+		WithOrigin(cloudfront.NewOriginBuilder("dist", "foo.com", "Public", s.cfg).WithBehavior("/*").WithDefaultBehavior().Build()).
+		WithOrigin(cloudfront.NewOriginBuilder("dist", "bar.com", "Public", s.cfg).WithBehavior("/*").WithDefaultBehavior().Build()).
+		Build()
+
+	s.Error(err)
+}
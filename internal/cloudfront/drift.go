@@ -0,0 +1,166 @@
+// Copyright (c) 2023 GPBR Participacoes LTDA.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package cloudfront
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	"github.com/Gympass/cdn-origin-controller/api/v1alpha1"
+	"github.com/Gympass/cdn-origin-controller/internal/k8s"
+	"github.com/Gympass/cdn-origin-controller/internal/metrics"
+)
+
+const (
+	reasonDriftDetected = "DriftDetected"
+	reasonDriftReverted = "DriftReverted"
+)
+
+// DriftDetector periodically compares the distribution of every CDNStatus with its desired state, reverting
+// or reporting changes made outside of the controller, for example through the AWS console
+type DriftDetector struct {
+	Service         *Service
+	CDNClassFetcher k8s.CDNClassFetcher
+	// Interval is how often all distributions are checked
+	Interval time.Duration
+	// ReportOnly makes drift be reported through the Drifted condition and events, instead of reverted
+	ReportOnly bool
+}
+
+var _ manager.Runnable = &DriftDetector{}
+
+// Start checks all distributions for drift every Interval, until the context is done.
+// It only runs in the elected leader.
+func (d *DriftDetector) Start(ctx context.Context) error {
+	ctx = logr.NewContext(ctx, log.FromContext(ctx).WithName("drift-detector"))
+
+	ticker := time.NewTicker(d.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			d.detectAll(ctx)
+		}
+	}
+}
+
+func (d *DriftDetector) detectAll(ctx context.Context) {
+	log, _ := logr.FromContext(ctx)
+
+	list := &v1alpha1.CDNStatusList{}
+	if err := d.Service.List(ctx, list); err != nil {
+		log.Error(err, "Could not list CDNStatuses to detect drift")
+		return
+	}
+
+	for i := range list.Items {
+		if err := d.detect(ctx, &list.Items[i]); err != nil {
+			log.Error(err, "Could not detect drift", "cdnStatus", list.Items[i].Name)
+		}
+	}
+}
+
+func (d *DriftDetector) detect(ctx context.Context, cdnStatus *v1alpha1.CDNStatus) error {
+	if len(cdnStatus.Status.ID) == 0 {
+		return nil
+	}
+
+	className, err := k8s.GroupClassName(ctx, d.Service, cdnStatus.Name)
+	if err != nil {
+		return fmt.Errorf("fetching class of group: %v", err)
+	}
+	// groups without members are deleted once reconciled
+	if !k8s.CDNClassNotEmpty(className) {
+		return nil
+	}
+
+	class, err := d.CDNClassFetcher.FetchByName(ctx, className)
+	if err != nil {
+		return fmt.Errorf("fetching CDNClass %s: %v", className, err)
+	}
+
+	desired, err := d.Service.existingDesiredState(ctx, k8s.CDNIngress{Group: cdnStatus.Name, Class: class})
+	if err != nil {
+		return fmt.Errorf("computing desired state: %v", err)
+	}
+
+	// dry-run groups already have their drift reported in their plan, empty groups are deleted once reconciled and
+	// groups without a distribution have nothing to compare with
+	if desired.DryRun || desired.IsEmpty() || !desired.Exists() {
+		return nil
+	}
+
+	return d.handleDrift(ctx, cdnStatus, desired)
+}
+
+// handleDrift compares the distribution with the desired one, reverting or reporting the differences
+func (d *DriftDetector) handleDrift(ctx context.Context, cdnStatus *v1alpha1.CDNStatus, desired Distribution) error {
+	planned := desired
+	planned.DryRun = true
+	planned, err := d.Service.DistRepo.Sync(planned)
+	if err != nil {
+		return fmt.Errorf("comparing distribution with desired state: %v", err)
+	}
+
+	observedStatus := cdnStatus.Status.DeepCopy()
+	drift := strings.Join(planned.PlannedChanges, "; ")
+	switch {
+	case len(planned.PlannedChanges) == 0:
+		cdnStatus.SetCondition(v1alpha1.ConditionDrifted, metav1.ConditionFalse, "NoDrift", "Distribution matches the desired state")
+	case d.ReportOnly:
+		msg := "Distribution was changed outside of the controller: " + drift
+		// the same drift is found on every check until the distribution is reconciled, so it's only reported once
+		if previous := meta.FindStatusCondition(cdnStatus.Status.Conditions, v1alpha1.ConditionDrifted); previous != nil &&
+			previous.Status == metav1.ConditionTrue && previous.Message == msg {
+			return nil
+		}
+		metrics.DistributionDrifts.WithLabelValues(cdnStatus.Name, metrics.DriftReported).Inc()
+		d.Service.Recorder.Event(cdnStatus, corev1.EventTypeWarning, reasonDriftDetected, msg)
+		cdnStatus.SetCondition(v1alpha1.ConditionDrifted, metav1.ConditionTrue, reasonDriftDetected, msg)
+	default:
+		if _, err := d.Service.DistRepo.Sync(desired); err != nil {
+			return fmt.Errorf("reverting drift: %v", err)
+		}
+		metrics.DistributionDrifts.WithLabelValues(cdnStatus.Name, metrics.DriftReverted).Inc()
+		msg := "Reverted changes made outside of the controller: " + drift
+		d.Service.Recorder.Event(cdnStatus, corev1.EventTypeNormal, reasonDriftReverted, msg)
+		cdnStatus.SetCondition(v1alpha1.ConditionDrifted, metav1.ConditionFalse, reasonDriftReverted, msg)
+	}
+
+	if equality.Semantic.DeepEqual(observedStatus, &cdnStatus.Status) {
+		return nil
+	}
+	if err := d.Service.Status().Update(ctx, cdnStatus); err != nil {
+		return fmt.Errorf("updating CDNStatus: %v", err)
+	}
+	return nil
+}
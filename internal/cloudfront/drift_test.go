@@ -0,0 +1,204 @@
+// Copyright (c) 2023 GPBR Participacoes LTDA.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package cloudfront
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/Gympass/cdn-origin-controller/api/v1alpha1"
+	"github.com/Gympass/cdn-origin-controller/internal/k8s"
+	"github.com/Gympass/cdn-origin-controller/internal/metrics"
+)
+
+type distRepoMock struct {
+	mock.Mock
+	DistributionRepository
+}
+
+func (m *distRepoMock) Sync(d Distribution) (Distribution, error) {
+	args := m.Called(d)
+	return args.Get(0).(Distribution), args.Error(1)
+}
+
+func TestRunDriftDetectorTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, &DriftDetectorTestSuite{})
+}
+
+type DriftDetectorTestSuite struct {
+	suite.Suite
+}
+
+func (s *DriftDetectorTestSuite) newDetector(repo DistributionRepository, reportOnly bool, cdnStatus *v1alpha1.CDNStatus) (*DriftDetector, *record.FakeRecorder) {
+	scheme := runtime.NewScheme()
+	s.NoError(v1alpha1.AddToScheme(scheme))
+
+	k8sClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(cdnStatus).
+		WithStatusSubresource(&v1alpha1.CDNStatus{}).
+		Build()
+
+	recorder := record.NewFakeRecorder(10)
+	svc := &Service{Client: k8sClient, Recorder: recorder, DistRepo: repo}
+	return &DriftDetector{Service: svc, ReportOnly: reportOnly}, recorder
+}
+
+func (s *DriftDetectorTestSuite) fetch(d *DriftDetector, name string) *v1alpha1.CDNStatus {
+	got := &v1alpha1.CDNStatus{}
+	s.NoError(d.Service.Get(context.Background(), client.ObjectKey{Name: name}, got))
+	return got
+}
+
+func newDriftCDNStatus(name string) *v1alpha1.CDNStatus {
+	return &v1alpha1.CDNStatus{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Status:     v1alpha1.CDNStatusStatus{ID: "dist-id"},
+	}
+}
+
+func (s *DriftDetectorTestSuite) TestHandleDrift_NoDrift() {
+	desired := Distribution{ID: "dist-id", Group: "no-drift"}
+	repo := &distRepoMock{}
+	repo.On("Sync", Distribution{ID: "dist-id", Group: "no-drift", DryRun: true}).Return(desired, nil).Once()
+
+	cdnStatus := newDriftCDNStatus("no-drift")
+	detector, recorder := s.newDetector(repo, false, cdnStatus)
+
+	s.NoError(detector.handleDrift(context.Background(), cdnStatus, desired))
+	s.True(meta.IsStatusConditionFalse(s.fetch(detector, "no-drift").Status.Conditions, v1alpha1.ConditionDrifted))
+	s.Empty(recorder.Events)
+	repo.AssertExpectations(s.T())
+}
+
+func (s *DriftDetectorTestSuite) TestHandleDrift_ReportOnly() {
+	desired := Distribution{ID: "dist-id", Group: "report-only"}
+	planned := Distribution{ID: "dist-id", Group: "report-only", DryRun: true, PlannedChanges: []string{"PriceClass: \"PriceClass_100\" -> \"PriceClass_All\""}}
+	repo := &distRepoMock{}
+	repo.On("Sync", Distribution{ID: "dist-id", Group: "report-only", DryRun: true}).Return(planned, nil).Once()
+
+	cdnStatus := newDriftCDNStatus("report-only")
+	detector, recorder := s.newDetector(repo, true, cdnStatus)
+
+	s.NoError(detector.handleDrift(context.Background(), cdnStatus, desired))
+
+	cond := meta.FindStatusCondition(s.fetch(detector, "report-only").Status.Conditions, v1alpha1.ConditionDrifted)
+	s.Equal(metav1.ConditionTrue, cond.Status)
+	s.Contains(cond.Message, "PriceClass")
+	s.Contains(<-recorder.Events, reasonDriftDetected)
+	s.Equal(float64(1), testutil.ToFloat64(metrics.DistributionDrifts.WithLabelValues("report-only", metrics.DriftReported)))
+	repo.AssertExpectations(s.T())
+}
+
+func (s *DriftDetectorTestSuite) TestHandleDrift_ReportOnlyDoesntReportTheSameDriftTwice() {
+	desired := Distribution{ID: "dist-id", Group: "report-once"}
+	planned := Distribution{ID: "dist-id", Group: "report-once", DryRun: true, PlannedChanges: []string{"Enabled: false -> true"}}
+	repo := &distRepoMock{}
+	repo.On("Sync", Distribution{ID: "dist-id", Group: "report-once", DryRun: true}).Return(planned, nil).Twice()
+
+	cdnStatus := newDriftCDNStatus("report-once")
+	detector, recorder := s.newDetector(repo, true, cdnStatus)
+
+	s.NoError(detector.handleDrift(context.Background(), cdnStatus, desired))
+	s.NoError(detector.handleDrift(context.Background(), s.fetch(detector, "report-once"), desired))
+
+	s.Len(recorder.Events, 1)
+	s.Equal(float64(1), testutil.ToFloat64(metrics.DistributionDrifts.WithLabelValues("report-once", metrics.DriftReported)))
+	repo.AssertExpectations(s.T())
+}
+
+func (s *DriftDetectorTestSuite) TestDetect_DoesntAdoptDistributions() {
+	scheme := runtime.NewScheme()
+	s.NoError(clientgoscheme.AddToScheme(scheme))
+	s.NoError(v1alpha1.AddToScheme(scheme))
+
+	cdnStatus := newDriftCDNStatus("adopting")
+	ing := &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "ing",
+			Namespace: "default",
+			Annotations: map[string]string{
+				k8s.CDNClassAnnotation: "class",
+				k8s.CDNGroupAnnotation: "adopting",
+				"cdn-origin-controller.gympass.com/cf.adopt-distribution-id": "other-dist-id",
+			},
+		},
+		Status: networkingv1.IngressStatus{LoadBalancer: networkingv1.IngressLoadBalancerStatus{
+			Ingress: []networkingv1.IngressLoadBalancerIngress{{Hostname: "host"}},
+		}},
+	}
+	class := &v1alpha1.CDNClass{ObjectMeta: metav1.ObjectMeta{Name: "class"}}
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(cdnStatus, ing, class).Build()
+
+	repo := &distRepoMock{}
+	repo.On("ARNByGroup", "adopting").Return("", ErrDistNotFound).Once()
+
+	svc := &Service{Client: k8sClient, Recorder: record.NewFakeRecorder(10), DistRepo: repo, Fetcher: k8s.NewIngressFetcherV1(k8sClient)}
+	detector := &DriftDetector{Service: svc, CDNClassFetcher: k8s.NewCDNClassFetcher(k8sClient)}
+
+	s.NoError(detector.detect(context.Background(), cdnStatus))
+	repo.AssertExpectations(s.T())
+}
+
+func (s *DriftDetectorTestSuite) TestHandleDrift_Revert() {
+	desired := Distribution{ID: "dist-id", Group: "revert"}
+	planned := Distribution{ID: "dist-id", Group: "revert", DryRun: true, PlannedChanges: []string{"Enabled: false -> true"}}
+	repo := &distRepoMock{}
+	repo.On("Sync", Distribution{ID: "dist-id", Group: "revert", DryRun: true}).Return(planned, nil).Once()
+	repo.On("Sync", desired).Return(desired, nil).Once()
+
+	cdnStatus := newDriftCDNStatus("revert")
+	detector, recorder := s.newDetector(repo, false, cdnStatus)
+
+	s.NoError(detector.handleDrift(context.Background(), cdnStatus, desired))
+
+	cond := meta.FindStatusCondition(s.fetch(detector, "revert").Status.Conditions, v1alpha1.ConditionDrifted)
+	s.Equal(metav1.ConditionFalse, cond.Status)
+	s.Equal(reasonDriftReverted, cond.Reason)
+	s.Contains(<-recorder.Events, reasonDriftReverted)
+	s.Equal(float64(1), testutil.ToFloat64(metrics.DistributionDrifts.WithLabelValues("revert", metrics.DriftReverted)))
+	repo.AssertExpectations(s.T())
+}
+
+func (s *DriftDetectorTestSuite) TestHandleDrift_FailureToCompareIsReturned() {
+	desired := Distribution{ID: "dist-id", Group: "failure"}
+	repo := &distRepoMock{}
+	repo.On("Sync", mock.Anything).Return(Distribution{}, errors.New("mock err")).Once()
+
+	cdnStatus := newDriftCDNStatus("failure")
+	detector, _ := s.newDetector(repo, false, cdnStatus)
+
+	s.Error(detector.handleDrift(context.Background(), cdnStatus, desired))
+	s.Nil(meta.FindStatusCondition(s.fetch(detector, "failure").Status.Conditions, v1alpha1.ConditionDrifted))
+}
@@ -0,0 +1,226 @@
+// Copyright (c) 2023 GPBR Participacoes LTDA.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package cloudfront
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/go-logr/logr"
+	"github.com/hashicorp/go-multierror"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	"github.com/Gympass/cdn-origin-controller/api/v1alpha1"
+	"github.com/Gympass/cdn-origin-controller/internal/k8s"
+	"github.com/Gympass/cdn-origin-controller/internal/metrics"
+	"github.com/Gympass/cdn-origin-controller/internal/route53"
+)
+
+// GarbageCollector periodically looks for AWS resources owned by the controller whose group no longer exists in the
+// cluster, for example because the cluster was destroyed or the CDNStatus was lost. Only resources marked with the
+// controller's ID are considered, so that controllers of other clusters sharing the AWS account are left alone.
+// Orphaned resources are always reported, and only deleted if garbage collection deletion is enabled.
+type GarbageCollector struct {
+	Service *Service
+	OACRepo OACRepository
+	// Interval is how often orphaned resources are looked for
+	Interval time.Duration
+}
+
+var _ manager.Runnable = &GarbageCollector{}
+
+// Start collects orphaned resources every Interval, until the context is done.
+// It only runs in the elected leader.
+func (g *GarbageCollector) Start(ctx context.Context) error {
+	ctx = logr.NewContext(ctx, log.FromContext(ctx).WithName("garbage-collector"))
+
+	ticker := time.NewTicker(g.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := g.collect(ctx); err != nil {
+				log, _ := logr.FromContext(ctx)
+				log.Error(err, "Could not collect orphaned resources")
+			}
+		}
+	}
+}
+
+func (g *GarbageCollector) collect(ctx context.Context) error {
+	activeGroups, err := k8s.ActiveGroups(ctx, g.Service.Client)
+	if err != nil {
+		return fmt.Errorf("listing active groups: %v", err)
+	}
+
+	errs := &multierror.Error{}
+	errs = multierror.Append(errs, g.collectDistributions(ctx, activeGroups))
+	errs = multierror.Append(errs, g.collectOACs(ctx, activeGroups))
+	return errs.ErrorOrNil()
+}
+
+func (g *GarbageCollector) collectDistributions(ctx context.Context, activeGroups sets.String) error {
+	owned, err := g.Service.DistRepo.ListOwned()
+	if err != nil {
+		return fmt.Errorf("listing owned distributions: %v", err)
+	}
+
+	var orphaned []Distribution
+	for _, d := range owned {
+		if !activeGroups.Has(d.Group) {
+			orphaned = append(orphaned, d)
+		}
+	}
+	metrics.OrphanedResources.WithLabelValues(metrics.OrphanedDistribution).Set(float64(len(orphaned)))
+
+	log, _ := logr.FromContext(ctx)
+	errs := &multierror.Error{}
+	for _, d := range orphaned {
+		if !g.Service.Config.GarbageCollectionDeletionEnabled {
+			log.Info("Found orphaned distribution, not deleting it since garbage collection deletion is disabled.", "group", d.Group, "id", d.ID)
+			continue
+		}
+
+		deleted, err := g.deleteDistribution(ctx, d)
+		if err != nil {
+			errs = multierror.Append(errs, fmt.Errorf("deleting orphaned distribution %s of group %s: %v", d.ID, d.Group, err))
+			continue
+		}
+		if !deleted {
+			log.Info("Disabled orphaned distribution, it will be deleted once deployed.", "group", d.Group, "id", d.ID)
+			continue
+		}
+		log.Info("Deleted orphaned distribution.", "group", d.Group, "id", d.ID)
+	}
+	return errs.ErrorOrNil()
+}
+
+// deleteDistribution deletes the DNS records owned by any class for the distribution's aliases, then disables the
+// distribution. Once disabling it is deployed the distribution is deleted, along with its OACs. Since there is no
+// CDNStatus to keep track of it, progress is taken from the distribution itself, carrying on at every collection.
+// Returns whether the distribution was deleted.
+func (g *GarbageCollector) deleteDistribution(ctx context.Context, d Distribution) (bool, error) {
+	out, err := g.Service.DistRepo.DistributionConfigByID(d.ID)
+	if err != nil {
+		return false, fmt.Errorf("getting distribution config: %v", err)
+	}
+
+	var domains []string
+	if out.DistributionConfig.Aliases != nil {
+		domains = aws.StringValueSlice(out.DistributionConfig.Aliases.Items)
+	}
+
+	if len(domains) > 0 {
+		classes := &v1alpha1.CDNClassList{}
+		if err := g.Service.List(ctx, classes); err != nil {
+			return false, fmt.Errorf("listing CDNClasses: %v", err)
+		}
+
+		for _, class := range classes.Items {
+			if !class.Spec.CreateAlias {
+				continue
+			}
+			// both address record types are looked for, since only existing records are deleted
+			aliases := route53.NewAliases("", class.Spec.HostedZoneID, class.Spec.TXTOwnerValue, domains, true)
+			if err := g.Service.AliasRepo.DeleteOwned(aliases); err != nil {
+				return false, fmt.Errorf("deleting DNS records owned by class %s: %v", class.Name, err)
+			}
+		}
+	}
+
+	oacIDs, err := g.Service.DistRepo.Disable(d.ID)
+	if errors.Is(err, ErrDistNotFound) {
+		return true, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	deployed, err := g.Service.DistRepo.IsDeployed(d.ID)
+	if errors.Is(err, ErrDistNotFound) {
+		return true, nil
+	}
+	if err != nil || !deployed {
+		return false, err
+	}
+
+	if err := g.Service.DistRepo.Delete(d.ID); err != nil {
+		return false, err
+	}
+	if err := g.Service.DistRepo.DeleteOACs(oacIDs); err != nil {
+		return false, fmt.Errorf("deleting OACs: %v", err)
+	}
+	return true, nil
+}
+
+// collectOACs deletes OACs created by the controller with its ID which are not associated with any distribution.
+// OACs which could belong to an active group are kept, since they're created before being associated.
+func (g *GarbageCollector) collectOACs(ctx context.Context, activeGroups sets.String) error {
+	managed, err := g.OACRepo.ListManaged()
+	if err != nil {
+		return err
+	}
+
+	inUse, err := g.Service.DistRepo.OACIDsInUse()
+	if err != nil {
+		return fmt.Errorf("listing OACs in use: %v", err)
+	}
+	inUseIDs := sets.NewString(inUse...)
+
+	var orphaned []OAC
+	for _, oac := range managed {
+		if !inUseIDs.Has(oac.ID) && !g.mayBelongToGroup(oac, activeGroups) {
+			orphaned = append(orphaned, oac)
+		}
+	}
+	metrics.OrphanedResources.WithLabelValues(metrics.OrphanedOAC).Set(float64(len(orphaned)))
+
+	log, _ := logr.FromContext(ctx)
+	errs := &multierror.Error{}
+	for _, oac := range orphaned {
+		if !g.Service.Config.GarbageCollectionDeletionEnabled {
+			log.Info("Found orphaned OAC, not deleting it since garbage collection deletion is disabled.", "name", oac.Name, "id", oac.ID)
+			continue
+		}
+
+		if _, err := g.OACRepo.Delete(oac); err != nil {
+			errs = multierror.Append(errs, fmt.Errorf("deleting orphaned OAC %s: %v", oac.Name, err))
+			continue
+		}
+		log.Info("Deleted orphaned OAC.", "name", oac.Name, "id", oac.ID)
+	}
+	return errs.ErrorOrNil()
+}
+
+func (g *GarbageCollector) mayBelongToGroup(oac OAC, groups sets.String) bool {
+	for group := range groups {
+		if isOACOfGroup(oac.Name, group) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,210 @@
+// Copyright (c) 2023 GPBR Participacoes LTDA.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package cloudfront
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	awscloudfront "github.com/aws/aws-sdk-go/service/cloudfront"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/Gympass/cdn-origin-controller/api/v1alpha1"
+	"github.com/Gympass/cdn-origin-controller/internal/config"
+	"github.com/Gympass/cdn-origin-controller/internal/metrics"
+	"github.com/Gympass/cdn-origin-controller/internal/route53"
+)
+
+func (m *distRepoMock) ListOwned() ([]Distribution, error) {
+	args := m.Called()
+	return args.Get(0).([]Distribution), args.Error(1)
+}
+
+func (m *distRepoMock) OACIDsInUse() ([]string, error) {
+	args := m.Called()
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *distRepoMock) DistributionConfigByID(id string) (*awscloudfront.GetDistributionConfigOutput, error) {
+	args := m.Called(id)
+	return args.Get(0).(*awscloudfront.GetDistributionConfigOutput), args.Error(1)
+}
+
+func (m *distRepoMock) Disable(id string) ([]string, error) {
+	args := m.Called(id)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *distRepoMock) IsDeployed(id string) (bool, error) {
+	args := m.Called(id)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *distRepoMock) Delete(id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *distRepoMock) DeleteOACs(ids []string) error {
+	args := m.Called(ids)
+	return args.Error(0)
+}
+
+func (m *aliasRepoMock) DeleteOwned(aliases route53.Aliases) error {
+	args := m.Called(aliases)
+	return args.Error(0)
+}
+
+func TestRunGarbageCollectorTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, &GarbageCollectorTestSuite{})
+}
+
+type GarbageCollectorTestSuite struct {
+	suite.Suite
+}
+
+func (s *GarbageCollectorTestSuite) newCollector(deletionEnabled bool, distRepo DistributionRepository, aliasRepo route53.AliasRepository, oacRepo OACRepository, objs ...client.Object) *GarbageCollector {
+	scheme := runtime.NewScheme()
+	s.NoError(clientgoscheme.AddToScheme(scheme))
+	s.NoError(v1alpha1.AddToScheme(scheme))
+
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+	svc := &Service{
+		Client:    k8sClient,
+		Config:    config.Config{DeletionEnabled: true, GarbageCollectionDeletionEnabled: deletionEnabled, ControllerID: "cluster-a"},
+		DistRepo:  distRepo,
+		AliasRepo: aliasRepo,
+	}
+	return &GarbageCollector{Service: svc, OACRepo: oacRepo}
+}
+
+func (s *GarbageCollectorTestSuite) TestCollect_DeletesOrphanedResources() {
+	active := &v1alpha1.CDNStatus{ObjectMeta: metav1.ObjectMeta{Name: "active.foo.bar"}}
+	class := &v1alpha1.CDNClass{
+		ObjectMeta: metav1.ObjectMeta{Name: "default"},
+		Spec:       v1alpha1.CDNClassSpec{CreateAlias: true, HostedZoneID: "zone", TXTOwnerValue: "owner"},
+	}
+	orphanedDist := Distribution{ID: "orphaned-id", Group: "orphaned.foo.bar"}
+	orphanedOAC := OAC{ID: "orphaned-oac", Name: "orphaned.foo.bar-bucket"}
+
+	distRepo := &distRepoMock{}
+	distRepo.On("ListOwned").Return([]Distribution{{ID: "active-id", Group: "active.foo.bar"}, orphanedDist}, nil).Once()
+	distRepo.On("DistributionConfigByID", "orphaned-id").Return(&awscloudfront.GetDistributionConfigOutput{
+		DistributionConfig: &awscloudfront.DistributionConfig{
+			Aliases: &awscloudfront.Aliases{Items: aws.StringSlice([]string{"alias.foo.bar"})},
+		},
+	}, nil).Once()
+	distRepo.On("Disable", "orphaned-id").Return([]string{"dist-oac"}, nil).Once()
+	distRepo.On("IsDeployed", "orphaned-id").Return(true, nil).Once()
+	distRepo.On("Delete", "orphaned-id").Return(nil).Once()
+	distRepo.On("DeleteOACs", []string{"dist-oac"}).Return(nil).Once()
+	distRepo.On("OACIDsInUse").Return([]string{"in-use-oac"}, nil).Once()
+
+	aliasRepo := &aliasRepoMock{}
+	aliasRepo.On("DeleteOwned", route53.NewAliases("", "zone", "owner", []string{"alias.foo.bar"}, true)).Return(nil).Once()
+
+	oacRepo := &mockOACRepo{}
+	oacRepo.On("ListManaged").Return([]OAC{
+		{ID: "in-use-oac", Name: "orphaned.foo.bar-in-use"},
+		{ID: "active-oac", Name: "active.foo.bar-bucket"},
+		orphanedOAC,
+	}, nil).Once()
+	oacRepo.On("Delete", orphanedOAC).Return(nil).Once()
+
+	gc := s.newCollector(true, distRepo, aliasRepo, oacRepo, active, class)
+
+	s.NoError(gc.collect(context.Background()))
+	s.Equal(float64(1), testutil.ToFloat64(metrics.OrphanedResources.WithLabelValues(metrics.OrphanedDistribution)))
+	s.Equal(float64(1), testutil.ToFloat64(metrics.OrphanedResources.WithLabelValues(metrics.OrphanedOAC)))
+	distRepo.AssertExpectations(s.T())
+	aliasRepo.AssertExpectations(s.T())
+	oacRepo.AssertExpectations(s.T())
+}
+
+func (s *GarbageCollectorTestSuite) TestCollect_DeletionDisabledOnlyReports() {
+	distRepo := &distRepoMock{}
+	distRepo.On("ListOwned").Return([]Distribution{{ID: "orphaned-id", Group: "orphaned.foo.bar"}}, nil).Once()
+	distRepo.On("OACIDsInUse").Return([]string{}, nil).Once()
+
+	oacRepo := &mockOACRepo{}
+	oacRepo.On("ListManaged").Return([]OAC{{ID: "orphaned-oac", Name: "orphaned.foo.bar-bucket"}}, nil).Once()
+
+	gc := s.newCollector(false, distRepo, &aliasRepoMock{}, oacRepo)
+
+	s.NoError(gc.collect(context.Background()))
+	s.Equal(float64(1), testutil.ToFloat64(metrics.OrphanedResources.WithLabelValues(metrics.OrphanedDistribution)))
+	s.Equal(float64(1), testutil.ToFloat64(metrics.OrphanedResources.WithLabelValues(metrics.OrphanedOAC)))
+	distRepo.AssertNotCalled(s.T(), "Delete", mock.Anything)
+	oacRepo.AssertNotCalled(s.T(), "Delete", mock.Anything)
+}
+
+func (s *GarbageCollectorTestSuite) TestCollect_WaitsForDisabledDistributionToBeDeployed() {
+	distRepo := &distRepoMock{}
+	distRepo.On("ListOwned").Return([]Distribution{{ID: "orphaned-id", Group: "orphaned.foo.bar"}}, nil).Once()
+	distRepo.On("DistributionConfigByID", "orphaned-id").Return(&awscloudfront.GetDistributionConfigOutput{
+		DistributionConfig: &awscloudfront.DistributionConfig{},
+	}, nil).Once()
+	distRepo.On("Disable", "orphaned-id").Return([]string{"dist-oac"}, nil).Once()
+	distRepo.On("IsDeployed", "orphaned-id").Return(false, nil).Once()
+	distRepo.On("OACIDsInUse").Return([]string{"dist-oac"}, nil).Once()
+
+	oacRepo := &mockOACRepo{}
+	oacRepo.On("ListManaged").Return([]OAC{}, nil).Once()
+
+	gc := s.newCollector(true, distRepo, &aliasRepoMock{}, oacRepo)
+
+	s.NoError(gc.collect(context.Background()))
+	distRepo.AssertExpectations(s.T())
+	distRepo.AssertNotCalled(s.T(), "Delete", mock.Anything)
+	distRepo.AssertNotCalled(s.T(), "DeleteOACs", mock.Anything)
+}
+
+func (s *GarbageCollectorTestSuite) TestCollect_ErrorDeletingDistributionDoesNotStopOACCollection() {
+	orphanedDist := Distribution{ID: "orphaned-id", Group: "orphaned.foo.bar"}
+
+	distRepo := &distRepoMock{}
+	distRepo.On("ListOwned").Return([]Distribution{orphanedDist}, nil).Once()
+	distRepo.On("DistributionConfigByID", "orphaned-id").Return(&awscloudfront.GetDistributionConfigOutput{
+		DistributionConfig: &awscloudfront.DistributionConfig{},
+	}, nil).Once()
+	distRepo.On("Disable", "orphaned-id").Return([]string{}, errors.New("mock err")).Once()
+	distRepo.On("OACIDsInUse").Return([]string{}, nil).Once()
+
+	orphanedOAC := OAC{ID: "orphaned-oac", Name: "orphaned.foo.bar-bucket"}
+	oacRepo := &mockOACRepo{}
+	oacRepo.On("ListManaged").Return([]OAC{orphanedOAC}, nil).Once()
+	oacRepo.On("Delete", orphanedOAC).Return(nil).Once()
+
+	gc := s.newCollector(true, distRepo, &aliasRepoMock{}, oacRepo)
+
+	s.Error(gc.collect(context.Background()))
+	distRepo.AssertExpectations(s.T())
+	oacRepo.AssertExpectations(s.T())
+}
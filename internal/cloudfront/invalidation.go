@@ -0,0 +1,106 @@
+// Copyright (c) 2023 GPBR Participacoes LTDA.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package cloudfront
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	awscloudfront "github.com/aws/aws-sdk-go/service/cloudfront"
+	"github.com/aws/aws-sdk-go/service/cloudfront/cloudfrontiface"
+)
+
+const cfInvalidationCompletedStatus = "Completed"
+
+// Invalidation represents a CloudFront cache invalidation
+type Invalidation struct {
+	ID             string
+	DistributionID string
+	Paths          []string
+	Status         string
+	CreateTime     time.Time
+}
+
+// IsCompleted returns whether CloudFront finished invalidating the paths
+func (i Invalidation) IsCompleted() bool {
+	return i.Status == cfInvalidationCompletedStatus
+}
+
+// InvalidationRepository creates and fetches invalidations of CloudFront distributions
+type InvalidationRepository interface {
+	// Create invalidates the given paths on the distribution of the given ID. Calls with the same
+	// callerRef are idempotent, so retrying a creation does not result in duplicate invalidations.
+	Create(distributionID, callerRef string, paths []string) (Invalidation, error)
+	// Get fetches the invalidation of the given ID from the distribution of the given ID
+	Get(distributionID, id string) (Invalidation, error)
+}
+
+// NewInvalidationRepository takes a CloudFront client and returns an InvalidationRepository
+func NewInvalidationRepository(client cloudfrontiface.CloudFrontAPI) InvalidationRepository {
+	return invalidationRepository{client: client}
+}
+
+var _ InvalidationRepository = invalidationRepository{}
+
+type invalidationRepository struct {
+	client cloudfrontiface.CloudFrontAPI
+}
+
+func (r invalidationRepository) Create(distributionID, callerRef string, paths []string) (Invalidation, error) {
+	out, err := r.client.CreateInvalidation(&awscloudfront.CreateInvalidationInput{
+		DistributionId: aws.String(distributionID),
+		InvalidationBatch: &awscloudfront.InvalidationBatch{
+			CallerReference: aws.String(callerRef),
+			Paths: &awscloudfront.Paths{
+				Items:    aws.StringSlice(paths),
+				Quantity: aws.Int64(int64(len(paths))),
+			},
+		},
+	})
+	if err != nil {
+		return Invalidation{}, fmt.Errorf("creating invalidation: %w", err)
+	}
+	return newInvalidation(distributionID, out.Invalidation), nil
+}
+
+func (r invalidationRepository) Get(distributionID, id string) (Invalidation, error) {
+	out, err := r.client.GetInvalidation(&awscloudfront.GetInvalidationInput{
+		DistributionId: aws.String(distributionID),
+		Id:             aws.String(id),
+	})
+	if err != nil {
+		return Invalidation{}, fmt.Errorf("fetching invalidation: %w", err)
+	}
+	return newInvalidation(distributionID, out.Invalidation), nil
+}
+
+func newInvalidation(distributionID string, inv *awscloudfront.Invalidation) Invalidation {
+	result := Invalidation{
+		ID:             aws.StringValue(inv.Id),
+		DistributionID: distributionID,
+		Status:         aws.StringValue(inv.Status),
+		CreateTime:     aws.TimeValue(inv.CreateTime),
+	}
+	if inv.InvalidationBatch != nil && inv.InvalidationBatch.Paths != nil {
+		result.Paths = aws.StringValueSlice(inv.InvalidationBatch.Paths.Items)
+	}
+	return result
+}
@@ -0,0 +1,152 @@
+// Copyright (c) 2023 GPBR Participacoes LTDA.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package cloudfront
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	awscloudfront "github.com/aws/aws-sdk-go/service/cloudfront"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/Gympass/cdn-origin-controller/api/v1alpha1"
+	cdnaws "github.com/Gympass/cdn-origin-controller/internal/aws"
+)
+
+const (
+	reasonInvalidationCreated   = "InvalidationCreated"
+	reasonInvalidationCompleted = "InvalidationCompleted"
+	reasonInvalidationFailed    = "InvalidationFailed"
+)
+
+// invalidationPollInterval is how often an Invalidation is checked while it's not complete or its distribution does not exist
+const invalidationPollInterval = 30 * time.Second
+
+// invalidationFailureCodes are AWS error codes which mean retrying the Invalidation will never succeed
+var invalidationFailureCodes = []string{
+	awscloudfront.ErrCodeInvalidArgument,
+	awscloudfront.ErrCodeBatchTooLarge,
+	awscloudfront.ErrCodeNoSuchDistribution,
+	awscloudfront.ErrCodeNoSuchInvalidation,
+}
+
+// InvalidationService handles operations involving the invalidation of CloudFront caches
+type InvalidationService struct {
+	client.Client
+
+	Recorder record.EventRecorder
+	Repo     InvalidationRepository
+}
+
+// Reconcile an Invalidation resource. The returned result asks for the Invalidation to be
+// reconciled again while it's not complete.
+func (s *InvalidationService) Reconcile(ctx context.Context, inv *v1alpha1.Invalidation) (reconcile.Result, error) {
+	if inv.IsFinished() {
+		return reconcile.Result{}, nil
+	}
+
+	if len(inv.Status.InvalidationID) == 0 {
+		return s.create(ctx, inv)
+	}
+	return s.track(ctx, inv)
+}
+
+func (s *InvalidationService) create(ctx context.Context, inv *v1alpha1.Invalidation) (reconcile.Result, error) {
+	cdnStatus := &v1alpha1.CDNStatus{}
+	err := s.Get(ctx, client.ObjectKey{Name: inv.Spec.Group}, cdnStatus)
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return reconcile.Result{}, fmt.Errorf("fetching CDNStatus: %v", err)
+	}
+	if err != nil || len(cdnStatus.Status.ID) == 0 {
+		inv.Status.Phase = v1alpha1.InvalidationPhasePending
+		inv.Status.Message = fmt.Sprintf("Distribution for group %s does not exist yet", inv.Spec.Group)
+		return reconcile.Result{RequeueAfter: invalidationPollInterval}, s.updateStatus(ctx, inv)
+	}
+
+	// the UID is used as caller reference so that retrying after a failed status update does not invalidate twice
+	created, err := s.Repo.Create(cdnStatus.Status.ID, string(inv.UID), inv.Spec.Paths)
+	if err != nil {
+		return reconcile.Result{}, s.handleFailure(ctx, inv, err)
+	}
+
+	inv.Status.InvalidationID = created.ID
+	inv.Status.DistributionID = created.DistributionID
+	inv.Status.CreateTime = &metav1.Time{Time: created.CreateTime}
+	s.Recorder.Eventf(inv, corev1.EventTypeNormal, reasonInvalidationCreated,
+		"Created invalidation %s on distribution %s", created.ID, created.DistributionID)
+	return s.handleInvalidation(ctx, inv, created)
+}
+
+func (s *InvalidationService) track(ctx context.Context, inv *v1alpha1.Invalidation) (reconcile.Result, error) {
+	observed, err := s.Repo.Get(inv.Status.DistributionID, inv.Status.InvalidationID)
+	if err != nil {
+		return reconcile.Result{}, s.handleFailure(ctx, inv, err)
+	}
+	return s.handleInvalidation(ctx, inv, observed)
+}
+
+func (s *InvalidationService) handleInvalidation(ctx context.Context, inv *v1alpha1.Invalidation, observed Invalidation) (reconcile.Result, error) {
+	if !observed.IsCompleted() {
+		inv.Status.Phase = v1alpha1.InvalidationPhaseInProgress
+		inv.Status.Message = "Invalidation is in progress"
+		return reconcile.Result{RequeueAfter: invalidationPollInterval}, s.updateStatus(ctx, inv)
+	}
+
+	inv.Status.Phase = v1alpha1.InvalidationPhaseCompleted
+	inv.Status.Message = "Invalidation is complete"
+	inv.Status.CompletionTime = &metav1.Time{Time: time.Now()}
+	s.Recorder.Event(inv, corev1.EventTypeNormal, reasonInvalidationCompleted, inv.Status.Message)
+	return reconcile.Result{}, s.updateStatus(ctx, inv)
+}
+
+// handleFailure marks the Invalidation as failed if the error can't be recovered from.
+// Otherwise, it returns the error so the Invalidation is retried.
+func (s *InvalidationService) handleFailure(ctx context.Context, inv *v1alpha1.Invalidation, err error) error {
+	s.Recorder.Event(inv, corev1.EventTypeWarning, reasonInvalidationFailed, err.Error())
+	if !isPermanentInvalidationError(err) {
+		return err
+	}
+
+	inv.Status.Phase = v1alpha1.InvalidationPhaseFailed
+	inv.Status.Message = err.Error()
+	return s.updateStatus(ctx, inv)
+}
+
+func (s *InvalidationService) updateStatus(ctx context.Context, inv *v1alpha1.Invalidation) error {
+	if err := s.Status().Update(ctx, inv); err != nil {
+		return fmt.Errorf("updating Invalidation status: %v", err)
+	}
+	return nil
+}
+
+func isPermanentInvalidationError(err error) bool {
+	for _, code := range invalidationFailureCodes {
+		if cdnaws.IsErrorCode(err, code) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,193 @@
+// Copyright (c) 2023 GPBR Participacoes LTDA.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package cloudfront
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	awscloudfront "github.com/aws/aws-sdk-go/service/cloudfront"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/Gympass/cdn-origin-controller/api/v1alpha1"
+)
+
+type invalidationRepoMock struct {
+	mock.Mock
+}
+
+func (m *invalidationRepoMock) Create(distributionID, callerRef string, paths []string) (Invalidation, error) {
+	args := m.Called(distributionID, callerRef, paths)
+	return args.Get(0).(Invalidation), args.Error(1)
+}
+
+func (m *invalidationRepoMock) Get(distributionID, id string) (Invalidation, error) {
+	args := m.Called(distributionID, id)
+	return args.Get(0).(Invalidation), args.Error(1)
+}
+
+func TestRunInvalidationServiceTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, &InvalidationServiceTestSuite{})
+}
+
+type InvalidationServiceTestSuite struct {
+	suite.Suite
+}
+
+func (s *InvalidationServiceTestSuite) newService(repo InvalidationRepository, objs ...client.Object) *InvalidationService {
+	scheme := runtime.NewScheme()
+	s.NoError(v1alpha1.AddToScheme(scheme))
+
+	k8sClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(objs...).
+		WithStatusSubresource(&v1alpha1.Invalidation{}).
+		Build()
+
+	return &InvalidationService{Client: k8sClient, Recorder: &record.FakeRecorder{}, Repo: repo}
+}
+
+func newInvalidationResource(status v1alpha1.InvalidationStatus) *v1alpha1.Invalidation {
+	return &v1alpha1.Invalidation{
+		ObjectMeta: metav1.ObjectMeta{Name: "inv", Namespace: "default", UID: "uid"},
+		Spec:       v1alpha1.InvalidationSpec{Group: "group", Paths: []string{"/*"}},
+		Status:     status,
+	}
+}
+
+func (s *InvalidationServiceTestSuite) fetch(svc *InvalidationService) *v1alpha1.Invalidation {
+	got := &v1alpha1.Invalidation{}
+	s.NoError(svc.Get(context.Background(), client.ObjectKey{Namespace: "default", Name: "inv"}, got))
+	return got
+}
+
+func (s *InvalidationServiceTestSuite) TestReconcile_FinishedInvalidationIsIgnored() {
+	repo := &invalidationRepoMock{}
+	inv := newInvalidationResource(v1alpha1.InvalidationStatus{Phase: v1alpha1.InvalidationPhaseCompleted})
+	svc := s.newService(repo, inv)
+
+	result, err := svc.Reconcile(context.Background(), inv)
+	s.NoError(err)
+	s.Zero(result.RequeueAfter)
+	repo.AssertNotCalled(s.T(), "Create", mock.Anything, mock.Anything, mock.Anything)
+	repo.AssertNotCalled(s.T(), "Get", mock.Anything, mock.Anything)
+}
+
+func (s *InvalidationServiceTestSuite) TestReconcile_DistributionDoesNotExistYet() {
+	repo := &invalidationRepoMock{}
+	inv := newInvalidationResource(v1alpha1.InvalidationStatus{})
+	svc := s.newService(repo, inv)
+
+	result, err := svc.Reconcile(context.Background(), inv)
+	s.NoError(err)
+	s.Equal(invalidationPollInterval, result.RequeueAfter)
+	s.Equal(v1alpha1.InvalidationPhasePending, s.fetch(svc).Status.Phase)
+	repo.AssertNotCalled(s.T(), "Create", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *InvalidationServiceTestSuite) TestReconcile_CreatesInvalidation() {
+	createTime := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	repo := &invalidationRepoMock{}
+	repo.On("Create", "dist-id", "uid", []string{"/*"}).
+		Return(Invalidation{ID: "inv-id", DistributionID: "dist-id", Status: "InProgress", CreateTime: createTime}, nil)
+
+	inv := newInvalidationResource(v1alpha1.InvalidationStatus{})
+	cdnStatus := &v1alpha1.CDNStatus{
+		ObjectMeta: metav1.ObjectMeta{Name: "group"},
+		Status:     v1alpha1.CDNStatusStatus{ID: "dist-id"},
+	}
+	svc := s.newService(repo, inv, cdnStatus)
+
+	result, err := svc.Reconcile(context.Background(), inv)
+	s.NoError(err)
+	s.Equal(invalidationPollInterval, result.RequeueAfter)
+
+	got := s.fetch(svc)
+	s.Equal(v1alpha1.InvalidationPhaseInProgress, got.Status.Phase)
+	s.Equal("inv-id", got.Status.InvalidationID)
+	s.Equal("dist-id", got.Status.DistributionID)
+	s.True(createTime.Equal(got.Status.CreateTime.Time))
+	repo.AssertExpectations(s.T())
+}
+
+func (s *InvalidationServiceTestSuite) TestReconcile_InvalidationCompletes() {
+	repo := &invalidationRepoMock{}
+	repo.On("Get", "dist-id", "inv-id").
+		Return(Invalidation{ID: "inv-id", DistributionID: "dist-id", Status: cfInvalidationCompletedStatus}, nil)
+
+	inv := newInvalidationResource(v1alpha1.InvalidationStatus{
+		Phase:          v1alpha1.InvalidationPhaseInProgress,
+		InvalidationID: "inv-id",
+		DistributionID: "dist-id",
+	})
+	svc := s.newService(repo, inv)
+
+	result, err := svc.Reconcile(context.Background(), inv)
+	s.NoError(err)
+	s.Zero(result.RequeueAfter)
+
+	got := s.fetch(svc)
+	s.Equal(v1alpha1.InvalidationPhaseCompleted, got.Status.Phase)
+	s.NotNil(got.Status.CompletionTime)
+	repo.AssertExpectations(s.T())
+}
+
+func (s *InvalidationServiceTestSuite) TestReconcile_PermanentFailureMarksInvalidationAsFailed() {
+	repo := &invalidationRepoMock{}
+	repo.On("Get", "dist-id", "inv-id").
+		Return(Invalidation{}, awserr.New(awscloudfront.ErrCodeNoSuchInvalidation, "mock", nil))
+
+	inv := newInvalidationResource(v1alpha1.InvalidationStatus{
+		Phase:          v1alpha1.InvalidationPhaseInProgress,
+		InvalidationID: "inv-id",
+		DistributionID: "dist-id",
+	})
+	svc := s.newService(repo, inv)
+
+	_, err := svc.Reconcile(context.Background(), inv)
+	s.NoError(err)
+	s.Equal(v1alpha1.InvalidationPhaseFailed, s.fetch(svc).Status.Phase)
+}
+
+func (s *InvalidationServiceTestSuite) TestReconcile_TransientFailureIsReturned() {
+	repo := &invalidationRepoMock{}
+	repo.On("Get", "dist-id", "inv-id").Return(Invalidation{}, errors.New("mock err"))
+
+	inv := newInvalidationResource(v1alpha1.InvalidationStatus{
+		Phase:          v1alpha1.InvalidationPhaseInProgress,
+		InvalidationID: "inv-id",
+		DistributionID: "dist-id",
+	})
+	svc := s.newService(repo, inv)
+
+	_, err := svc.Reconcile(context.Background(), inv)
+	s.Error(err)
+	s.Equal(v1alpha1.InvalidationPhaseInProgress, s.fetch(svc).Status.Phase)
+}
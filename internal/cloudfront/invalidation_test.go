@@ -0,0 +1,117 @@
+// Copyright (c) 2023 GPBR Participacoes LTDA.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package cloudfront
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	awscloudfront "github.com/aws/aws-sdk-go/service/cloudfront"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/Gympass/cdn-origin-controller/internal/test"
+)
+
+func TestRunInvalidationRepositoryTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, &InvalidationRepositoryTestSuite{})
+}
+
+type InvalidationRepositoryTestSuite struct {
+	suite.Suite
+}
+
+func (s *InvalidationRepositoryTestSuite) TestCreate_Success() {
+	createTime := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	client := &test.MockCloudFrontAPI{
+		ExpectedCreateInvalidationOutput: &awscloudfront.CreateInvalidationOutput{
+			Invalidation: &awscloudfront.Invalidation{
+				Id:         aws.String("inv-id"),
+				Status:     aws.String("InProgress"),
+				CreateTime: aws.Time(createTime),
+				InvalidationBatch: &awscloudfront.InvalidationBatch{
+					Paths: &awscloudfront.Paths{Items: aws.StringSlice([]string{"/foo/*", "/bar"})},
+				},
+			},
+		},
+	}
+	client.On("CreateInvalidation", &awscloudfront.CreateInvalidationInput{
+		DistributionId: aws.String("dist-id"),
+		InvalidationBatch: &awscloudfront.InvalidationBatch{
+			CallerReference: aws.String("caller-ref"),
+			Paths: &awscloudfront.Paths{
+				Items:    aws.StringSlice([]string{"/foo/*", "/bar"}),
+				Quantity: aws.Int64(2),
+			},
+		},
+	}).Return(nil)
+
+	got, err := NewInvalidationRepository(client).Create("dist-id", "caller-ref", []string{"/foo/*", "/bar"})
+	s.NoError(err)
+	s.Equal(Invalidation{
+		ID:             "inv-id",
+		DistributionID: "dist-id",
+		Paths:          []string{"/foo/*", "/bar"},
+		Status:         "InProgress",
+		CreateTime:     createTime,
+	}, got)
+	s.False(got.IsCompleted())
+	client.AssertExpectations(s.T())
+}
+
+func (s *InvalidationRepositoryTestSuite) TestCreate_Failure() {
+	client := &test.MockCloudFrontAPI{}
+	client.On("CreateInvalidation", mock.Anything).Return(errors.New("mock err"))
+
+	_, err := NewInvalidationRepository(client).Create("dist-id", "caller-ref", []string{"/*"})
+	s.Error(err)
+}
+
+func (s *InvalidationRepositoryTestSuite) TestGet_Success() {
+	client := &test.MockCloudFrontAPI{
+		ExpectedGetInvalidationOutput: &awscloudfront.GetInvalidationOutput{
+			Invalidation: &awscloudfront.Invalidation{
+				Id:     aws.String("inv-id"),
+				Status: aws.String("Completed"),
+			},
+		},
+	}
+	client.On("GetInvalidation", &awscloudfront.GetInvalidationInput{
+		DistributionId: aws.String("dist-id"),
+		Id:             aws.String("inv-id"),
+	}).Return(nil)
+
+	got, err := NewInvalidationRepository(client).Get("dist-id", "inv-id")
+	s.NoError(err)
+	s.Equal("inv-id", got.ID)
+	s.Equal("dist-id", got.DistributionID)
+	s.True(got.IsCompleted())
+}
+
+func (s *InvalidationRepositoryTestSuite) TestGet_Failure() {
+	client := &test.MockCloudFrontAPI{}
+	client.On("GetInvalidation", mock.Anything).Return(errors.New("mock err"))
+
+	_, err := NewInvalidationRepository(client).Get("dist-id", "inv-id")
+	s.Error(err)
+}
@@ -41,17 +41,26 @@ type OAC struct {
 	SigningProtocol               string `json:"signingProtocol"`
 }
 
-func NewOAC(distribution, originName string) OAC {
+func NewOAC(distribution, originName, controllerID string) OAC {
 	return OAC{
 		Name:                          oacName(distribution, originName),
 		OriginName:                    originName,
-		Description:                   oacDescription(originName),
+		Description:                   oacDescription(originName, controllerID),
 		OriginAccessControlOriginType: awscloudfront.OriginAccessControlOriginTypesS3,
 		SigningBehavior:               awscloudfront.OriginAccessControlSigningBehaviorsAlways,
 		SigningProtocol:               awscloudfront.OriginAccessControlSigningProtocolsSigv4,
 	}
 }
 
+// hasEqualConfig returns whether both OACs have the same configuration, regardless of their IDs
+func (o OAC) hasEqualConfig(other OAC) bool {
+	return o.Name == other.Name &&
+		o.Description == other.Description &&
+		o.OriginAccessControlOriginType == other.OriginAccessControlOriginType &&
+		o.SigningBehavior == other.SigningBehavior &&
+		o.SigningProtocol == other.SigningProtocol
+}
+
 func oacName(distributionName, s3Host string) string {
 	// keeps the default behavior for already working AOC's.
 	s3Name := strings.Split(s3Host, ".")[0]
@@ -65,8 +74,28 @@ func oacName(distributionName, s3Host string) string {
 	return fmt.Sprintf("%s-%s", hostName, generateShortID(distributionName, s3Name))
 }
 
-func oacDescription(originName string) string {
-	return fmt.Sprintf("OAC for %s, managed by cdn-origin-controller", originName)
+const oacDescriptionSuffix = ", managed by cdn-origin-controller"
+
+func oacDescription(originName, controllerID string) string {
+	return fmt.Sprintf("OAC for %s%s", originName, managedOACDescriptionSuffix(controllerID))
+}
+
+// managedOACDescriptionSuffix identifies the controller which manages an OAC, if it has an ID
+func managedOACDescriptionSuffix(controllerID string) string {
+	if len(controllerID) == 0 {
+		return oacDescriptionSuffix
+	}
+	return fmt.Sprintf("%s (%s)", oacDescriptionSuffix, controllerID)
+}
+
+// isManagedOACDescription returns whether an OAC description is one set by the operator with the given controller ID
+func isManagedOACDescription(description, controllerID string) bool {
+	return strings.HasPrefix(description, "OAC for ") && strings.HasSuffix(description, managedOACDescriptionSuffix(controllerID))
+}
+
+// isOACOfGroup returns whether an OAC name could have been generated for an origin of the given group
+func isOACOfGroup(name, group string) bool {
+	return strings.HasPrefix(name, group+"-") || strings.HasPrefix(name, strings.Split(group, ".")[0]+"-")
 }
 
 func generateShortID(distrName, domainName string) string {
@@ -38,6 +38,8 @@ type OACRepository interface {
 	Sync(desired OAC) (OAC, error)
 	// Delete deletes the OAC of given id. If successful, returns deleted OAC
 	Delete(toBeDeleted OAC) (OAC, error)
+	// ListManaged lists all OACs created by the operator with its controller ID
+	ListManaged() ([]OAC, error)
 }
 
 func NewOACRepository(client cloudfrontiface.CloudFrontAPI, oacLister OACLister, cfg config.Config) OACRepository {
@@ -54,6 +56,9 @@ type oacRepository struct {
 
 func (r oacRepository) Sync(desired OAC) (OAC, error) {
 	observed, eTag, err := r.getOAC(desired)
+	if err == nil && observed.hasEqualConfig(desired) {
+		return observed, nil
+	}
 	if err == nil {
 		return r.updateOAC(desired, observed, eTag)
 	}
@@ -86,6 +91,22 @@ func (r oacRepository) Delete(toBeDeleted OAC) (OAC, error) {
 	return existingOAC, nil
 }
 
+func (r oacRepository) ListManaged() ([]OAC, error) {
+	var result []OAC
+	err := r.oacLister.ListOriginAccessControlsPages(&awscloudfront.ListOriginAccessControlsInput{}, func(output *awscloudfront.ListOriginAccessControlsOutput, _ bool) bool {
+		for _, item := range output.OriginAccessControlList.Items {
+			if isManagedOACDescription(aws.StringValue(item.Description), r.cfg.ControllerID) {
+				result = append(result, newOACFromOriginAccessControlSummary(item, ""))
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("listing OACs: %v", err)
+	}
+	return result, nil
+}
+
 func (r oacRepository) createOAC(desired OAC) (OAC, error) {
 	out, err := r.client.CreateOriginAccessControl(
 		&awscloudfront.CreateOriginAccessControlInput{
@@ -172,6 +193,7 @@ func newOACFromOriginAccessControlConfig(cfg *awscloudfront.OriginAccessControlC
 	return OAC{
 		ID:                            aws.StringValue(id),
 		Name:                          aws.StringValue(cfg.Name),
+		Description:                   aws.StringValue(cfg.Description),
 		OriginName:                    originName,
 		OriginAccessControlOriginType: aws.StringValue(cfg.OriginAccessControlOriginType),
 		SigningBehavior:               aws.StringValue(cfg.SigningBehavior),
@@ -183,6 +205,7 @@ func newOACFromOriginAccessControlSummary(summary *awscloudfront.OriginAccessCon
 	return OAC{
 		ID:                            aws.StringValue(summary.Id),
 		Name:                          aws.StringValue(summary.Name),
+		Description:                   aws.StringValue(summary.Description),
 		OriginName:                    originName,
 		OriginAccessControlOriginType: aws.StringValue(summary.OriginAccessControlOriginType),
 		SigningBehavior:               aws.StringValue(summary.SigningBehavior),
@@ -174,6 +174,47 @@ func (s *oacRepositorySuite) TestSync_OACWillBeUpdatedAndShouldReturnNoError() {
 		SigningProtocol:               "sigv4",
 	}, got)
 }
+
+func (s *oacRepositorySuite) TestSync_OACAlreadyUpToDateShouldNotBeUpdated() {
+	var noError error
+
+	s.client.On("GetOriginAccessControl", mock.Anything).
+		Return(noError)
+	s.client.ExpectedGetOriginAccessControlOutput = &awscloudfront.GetOriginAccessControlOutput{
+		ETag: aws.String("eTag"),
+	}
+
+	s.lister.On("ListOriginAccessControlsPages", mock.Anything, mock.Anything).
+		Return(noError)
+	s.lister.expectedPages = []*awscloudfront.ListOriginAccessControlsOutput{
+		{
+			OriginAccessControlList: &awscloudfront.OriginAccessControlList{Items: []*awscloudfront.OriginAccessControlSummary{
+				{
+					Id:                            aws.String("id"),
+					Name:                          aws.String("name"),
+					Description:                   aws.String("description"),
+					OriginAccessControlOriginType: aws.String(awscloudfront.OriginAccessControlOriginTypesS3),
+					SigningBehavior:               aws.String(awscloudfront.OriginAccessControlSigningBehaviorsAlways),
+					SigningProtocol:               aws.String(awscloudfront.OriginAccessControlSigningProtocolsSigv4),
+				},
+			}},
+		},
+	}
+
+	got, err := NewOACRepository(s.client, s.lister, s.cfg).Sync(OAC{
+		Name:                          "name",
+		Description:                   "description",
+		OriginName:                    "originName",
+		OriginAccessControlOriginType: "s3",
+		SigningBehavior:               "always",
+		SigningProtocol:               "sigv4",
+	})
+
+	s.NoError(err)
+	s.Equal("id", got.ID)
+	s.client.AssertNotCalled(s.T(), "UpdateOriginAccessControl", mock.Anything)
+}
+
 func (s *oacRepositorySuite) TestSync_OACFailsToBeFetchedAndShouldReturnError() {
 	s.lister.On("ListOriginAccessControlsPages", mock.Anything, mock.Anything).
 		Return(errors.New("some error"))
@@ -396,3 +437,35 @@ func (s *oacRepositorySuite) TestDelete_FailedToDeleteAndShouldReturnError() {
 	s.Error(err)
 	s.Empty(got)
 }
+
+func (s *oacRepositorySuite) TestListManaged_OnlyListsOACsCreatedByTheController() {
+	s.lister.On("ListOriginAccessControlsPages", mock.Anything, mock.Anything).Return(nil)
+	s.lister.expectedPages = []*awscloudfront.ListOriginAccessControlsOutput{
+		{
+			OriginAccessControlList: &awscloudfront.OriginAccessControlList{
+				Items: []*awscloudfront.OriginAccessControlSummary{
+					{Id: aws.String("managed"), Name: aws.String("group-bucket"), Description: aws.String(oacDescription("bucket.s3.amazonaws.com", "cluster-a"))},
+					{Id: aws.String("unmanaged"), Name: aws.String("by-hand"), Description: aws.String("created by hand")},
+					{Id: aws.String("other-controller"), Name: aws.String("group-foo"), Description: aws.String(oacDescription("foo.s3.amazonaws.com", "cluster-b"))},
+					{Id: aws.String("no-controller"), Name: aws.String("group-bar"), Description: aws.String(oacDescription("bar.s3.amazonaws.com", ""))},
+				},
+			},
+		},
+		{
+			OriginAccessControlList: &awscloudfront.OriginAccessControlList{
+				Items: []*awscloudfront.OriginAccessControlSummary{
+					{Id: aws.String("other-managed"), Name: aws.String("group-other"), Description: aws.String(oacDescription("other.s3.amazonaws.com", "cluster-a"))},
+				},
+			},
+		},
+	}
+
+	s.cfg.ControllerID = "cluster-a"
+	repo := NewOACRepository(s.client, s.lister, s.cfg)
+	got, err := repo.ListManaged()
+
+	s.NoError(err)
+	s.Len(got, 2)
+	s.Equal("managed", got[0].ID)
+	s.Equal("other-managed", got[1].ID)
+}
@@ -35,13 +35,28 @@ type OACTestSuite struct {
 }
 
 func (s *OACTestSuite) TestNewOACWithDefaultNamePattern() {
-	oac := NewOAC("asylium.gympass.com", "gympass-production-nv-wellz-accounts.s3.us-east-1.amazonaws.com")
+	oac := NewOAC("asylium.gympass.com", "gympass-production-nv-wellz-accounts.s3.us-east-1.amazonaws.com", "")
 	s.Equal("asylium.gympass.com-gympass-production-nv-wellz-accounts", oac.Name)
 	s.True(len(oac.Name) <= 63)
 }
 
 func (s *OACTestSuite) TestNewOACWithShortNamePattern() {
-	oac := NewOAC("wellz-accounts-fe-develop-nv.nv.dev.us.gympass.cloud", "gympass-develop-nv-wellz-accounts-fe-develop-nv.s3.us-east-1.amazonaws.com")
+	oac := NewOAC("wellz-accounts-fe-develop-nv.nv.dev.us.gympass.cloud", "gympass-develop-nv-wellz-accounts-fe-develop-nv.s3.us-east-1.amazonaws.com", "")
 	s.Equal("wellz-accounts-fe-develop-nv-51d6956b", oac.Name)
 	s.True(len(oac.Name) <= 63)
 }
+
+func (s *OACTestSuite) Test_isManagedOACDescription() {
+	s.True(isManagedOACDescription(NewOAC("group", "bucket.s3.amazonaws.com", "").Description, ""))
+	s.True(isManagedOACDescription(NewOAC("group", "bucket.s3.amazonaws.com", "cluster-a").Description, "cluster-a"))
+	s.False(isManagedOACDescription(NewOAC("group", "bucket.s3.amazonaws.com", "cluster-a").Description, ""))
+	s.False(isManagedOACDescription(NewOAC("group", "bucket.s3.amazonaws.com", "").Description, "cluster-a"))
+	s.False(isManagedOACDescription(NewOAC("group", "bucket.s3.amazonaws.com", "cluster-b").Description, "cluster-a"))
+	s.False(isManagedOACDescription("OAC created by hand", ""))
+}
+
+func (s *OACTestSuite) Test_isOACOfGroup() {
+	s.True(isOACOfGroup(NewOAC("asylium.gympass.com", "gympass-production-nv-wellz-accounts.s3.us-east-1.amazonaws.com", "").Name, "asylium.gympass.com"))
+	s.True(isOACOfGroup(NewOAC("wellz-accounts-fe-develop-nv.nv.dev.us.gympass.cloud", "gympass-develop-nv-wellz-accounts-fe-develop-nv.s3.us-east-1.amazonaws.com", "").Name, "wellz-accounts-fe-develop-nv.nv.dev.us.gympass.cloud"))
+	s.False(isOACOfGroup(NewOAC("foo.gympass.com", "bucket.s3.amazonaws.com", "").Name, "bar.gympass.com"))
+}
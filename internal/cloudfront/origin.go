@@ -20,8 +20,11 @@
 package cloudfront
 
 import (
+	"fmt"
 	"strings"
 
+	awscloudfront "github.com/aws/aws-sdk-go/service/cloudfront"
+
 	"github.com/Gympass/cdn-origin-controller/internal/config"
 	"github.com/Gympass/cdn-origin-controller/internal/k8s"
 )
@@ -32,8 +35,22 @@ const (
 )
 
 const (
-	defaultResponseTimeout    = 30
-	templateOriginHeadersHost = "{{origin.host}}"
+	defaultResponseTimeout      = 30
+	defaultProtocolPolicy       = awscloudfront.OriginProtocolPolicyMatchViewer
+	defaultHTTPPort             = 80
+	defaultHTTPSPort            = 443
+	defaultKeepaliveTimeout     = 5
+	defaultViewerProtocolPolicy = k8s.ViewerProtocolPolicyRedirectToHTTPS
+	templateOriginHeadersHost   = "{{origin.host}}"
+	// catchAllPathPattern is the path pattern whose Behavior becomes the default one, when its Origin is the default
+	catchAllPathPattern = "/*"
+	// defaultPathPattern is the path pattern the default Behavior responds for
+	defaultPathPattern = "*"
+)
+
+var (
+	defaultAllowedMethods = []string{"GET", "HEAD", "OPTIONS", "PUT", "POST", "PATCH", "DELETE"}
+	defaultCachedMethods  = []string{"GET", "HEAD"}
 )
 
 // originHeaders represents pairs of HTTP header key/values that should be added to requests to the origin
@@ -67,6 +84,8 @@ func (h originHeaders) get() map[string]string {
 type Origin struct {
 	// Host is the origin's hostname
 	Host string
+	// Path is the directory CloudFront requests content from within the Origin's host, if any
+	Path string
 	// Behaviors is the collection of Behaviors associated with this Origin
 	Behaviors []Behavior
 	// ResponseTimeout is how long CloudFront will wait for a response from the Origin in seconds
@@ -75,13 +94,120 @@ type Origin struct {
 	Access string
 	// OAC configures Access Origin Control for this Origin
 	OAC OAC
+	// ProtocolPolicy is the protocol CloudFront uses to connect to the Origin, if it's public
+	ProtocolPolicy string
+	// HTTPPort is the port CloudFront uses for HTTP connections to the Origin, if it's public
+	HTTPPort int64
+	// HTTPSPort is the port CloudFront uses for HTTPS connections to the Origin, if it's public
+	HTTPSPort int64
+	// KeepaliveTimeout is how long CloudFront keeps idle connections to the Origin open in seconds, if it's public
+	KeepaliveTimeout int64
+	// MinSSLProtocol is the oldest SSL/TLS protocol CloudFront may use for HTTPS connections to the Origin, if it's public
+	MinSSLProtocol string
+	// Failover configures a secondary origin CloudFront fails over to, if any
+	Failover *Failover
 
 	headers originHeaders
+	// id is empty if the Origin is identified by its host
+	id string
+	// isDefault is true if the Origin's catch-all Behavior should be the default Behavior of the Distribution
+	isDefault bool
+}
+
+// Failover represents the secondary origin of an origin group, which CloudFront fails over to when
+// the primary Origin can't be reached or responds with one of the StatusCodes
+type Failover struct {
+	// Secondary is the origin CloudFront fails over to
+	Secondary Origin
+	// StatusCodes are the status codes from the primary Origin which trigger a failover
+	StatusCodes []int64
 }
 
 // HasEqualParameters returns whether both Origins have the same parameters. It ignores differences in Behaviors
 func (o Origin) HasEqualParameters(o2 Origin) bool {
-	return o.Host == o2.Host && o.ResponseTimeout == o2.ResponseTimeout && o.Access == o2.Access && o.OAC == o2.OAC
+	return o.identity() == o2.identity()
+}
+
+// identity returns a representation of all the Origin's parameters, which is the same for Origins that can be
+// merged into a single CloudFront origin. It ignores Behaviors and the Origin's ID
+func (o Origin) identity() string {
+	return fmt.Sprintf("%+v", struct {
+		Host             string
+		Path             string
+		Access           string
+		ProtocolPolicy   string
+		MinSSLProtocol   string
+		ResponseTimeout  int64
+		HTTPPort         int64
+		HTTPSPort        int64
+		KeepaliveTimeout int64
+		OACName          string
+		Headers          map[string]string
+		Failover         string
+	}{
+		Host:             o.Host,
+		Path:             o.Path,
+		Access:           o.Access,
+		ProtocolPolicy:   o.ProtocolPolicy,
+		MinSSLProtocol:   o.MinSSLProtocol,
+		ResponseTimeout:  o.ResponseTimeout,
+		HTTPPort:         o.HTTPPort,
+		HTTPSPort:        o.HTTPSPort,
+		KeepaliveTimeout: o.KeepaliveTimeout,
+		OACName:          o.OAC.Name,
+		Headers:          o.Headers(),
+		Failover:         o.failoverIdentity(),
+	})
+}
+
+func (o Origin) failoverIdentity() string {
+	if o.Failover == nil {
+		return ""
+	}
+	return fmt.Sprintf("%s %v", o.Failover.Secondary.identity(), o.Failover.StatusCodes)
+}
+
+// hashedID returns an ID made of the Origin's host and a short hash of its parameters, which tells apart
+// Origins sharing the same host
+func (o Origin) hashedID() string {
+	return o.Host + "-" + generateMD5(o.identity())[:8]
+}
+
+// ID returns the identifier of the Origin within its Distribution. It's the Origin's host, unless other Origins
+// of the same Distribution share that host, in which case a hash of the Origin's parameters is appended to it
+func (o Origin) ID() string {
+	if len(o.id) > 0 {
+		return o.id
+	}
+	return o.Host
+}
+
+// TargetID returns the ID Behaviors of the Origin route requests to: the ID of the Origin's origin group,
+// if it fails over to a secondary origin, or the Origin's own ID otherwise
+func (o Origin) TargetID() string {
+	if o.Failover != nil {
+		return o.ID() + "-failover"
+	}
+	return o.ID()
+}
+
+// withID returns a copy of the Origin identified by the given ID, with its Behaviors targeting it
+func (o Origin) withID(id string) Origin {
+	o.id = ""
+	if id != o.Host {
+		o.id = id
+	}
+	if o.Behaviors == nil {
+		return o
+	}
+
+	behaviors := make([]Behavior, len(o.Behaviors))
+	for i, b := range o.Behaviors {
+		b.OriginID = o.TargetID()
+		behaviors[i] = b
+	}
+	o.Behaviors = behaviors
+	return o
 }
 
 // Headers returns the headers that are bound to this Origin.
@@ -95,6 +221,15 @@ func (o Origin) isBucketBased() bool {
 	return o.Access == OriginAccessBucket
 }
 
+func (o Origin) hasBehavior(pathPattern string) bool {
+	for _, b := range o.Behaviors {
+		if b.PathPattern == pathPattern {
+			return true
+		}
+	}
+	return false
+}
+
 // Behavior represents a CloudFront Cache Behavior
 type Behavior struct {
 	// PathPattern is the path pattern used when configuring the Behavior
@@ -107,21 +242,54 @@ type Behavior struct {
 	ResponsePolicy string
 	// OriginHost the origin's host this behavior belongs to
 	OriginHost string
+	// OriginID is the ID of the origin or origin group this behavior routes requests to
+	OriginID string
 	// FunctionAssociations is a slice of Function that should be bound to this Behavior
 	FunctionAssociations []Function
+	// AllowedMethods are the HTTP methods CloudFront processes and forwards to the origin on this Behavior
+	AllowedMethods []string
+	// CachedMethods are the HTTP methods whose responses CloudFront caches on this Behavior
+	CachedMethods []string
+	// ViewerProtocolPolicy is the protocol viewers may use to access this Behavior
+	ViewerProtocolPolicy string
+}
+
+// behaviorMethods represents the allowed and cached HTTP methods of a Behavior
+type behaviorMethods struct {
+	allowed []string
+	cached  []string
 }
 
 // OriginBuilder allows the construction of an Origin
 type OriginBuilder struct {
 	host             string
+	path             string
 	headers          map[string]string
 	requestPolicy    string
 	distributionName string
 	cachePolicy      string
 	responsePolicy   string
 	respTimeout      int64
+	protocolPolicy   string
+	httpPort         int64
+	httpsPort        int64
+	keepaliveTimeout int64
+	minSSLProtocol   string
 	accessType       string
 	behaviors        map[string][]Function
+	methods          map[string]behaviorMethods
+	viewerPolicies   map[string]string
+	failover         *failoverConfig
+	isDefault        bool
+	controllerID     string
+}
+
+// failoverConfig represents the secondary origin of an Origin being built
+type failoverConfig struct {
+	host        string
+	path        string
+	accessType  string
+	statusCodes []int64
 }
 
 // NewOriginBuilder returns an OriginBuilder for a given host
@@ -130,10 +298,18 @@ func NewOriginBuilder(distributionName, host, accessType string, cfg config.Conf
 		distributionName: distributionName,
 		host:             host,
 		respTimeout:      defaultResponseTimeout,
+		protocolPolicy:   defaultProtocolPolicy,
+		httpPort:         defaultHTTPPort,
+		httpsPort:        defaultHTTPSPort,
+		keepaliveTimeout: defaultKeepaliveTimeout,
+		minSSLProtocol:   cfg.CloudFrontDefaultOriginMinSSLProtocol,
 		requestPolicy:    defaultRequestPolicyForType(accessType, cfg),
 		cachePolicy:      cfg.CloudFrontDefaultCachingPolicyID,
 		behaviors:        make(map[string][]Function),
+		methods:          make(map[string]behaviorMethods),
+		viewerPolicies:   make(map[string]string),
 		accessType:       accessType,
+		controllerID:     cfg.ControllerID,
 	}
 }
 
@@ -157,6 +333,45 @@ func (b OriginBuilder) WithBehavior(pathPattern string, functions ...Function) O
 	return b
 }
 
+// WithMethods sets the allowed and cached HTTP methods of the Behavior responding for a given path pattern.
+// Empty slices keep the defaults: all methods allowed and only GET and HEAD cached.
+func (b OriginBuilder) WithMethods(pathPattern string, allowed, cached []string) OriginBuilder {
+	m := b.methods[pathPattern]
+	if len(allowed) > 0 {
+		m.allowed = allowed
+	}
+	if len(cached) > 0 {
+		m.cached = cached
+	}
+	b.methods[pathPattern] = m
+	return b
+}
+
+// WithViewerProtocolPolicy sets the viewer protocol policy of the Behavior responding for a given path pattern.
+// An empty policy keeps the default: redirect HTTP requests to HTTPS.
+func (b OriginBuilder) WithViewerProtocolPolicy(pathPattern, policy string) OriginBuilder {
+	if len(policy) > 0 {
+		b.viewerPolicies[pathPattern] = policy
+	}
+	return b
+}
+
+// WithFailover configures a secondary origin CloudFront fails over to when the Origin being built can't be reached
+// or responds with one of the given status codes. Except for its host, path and access type, the secondary origin
+// has the same parameters as the Origin being built.
+func (b OriginBuilder) WithFailover(host, path, accessType string, statusCodes []int64) OriginBuilder {
+	b.failover = &failoverConfig{host: host, path: path, accessType: accessType, statusCodes: statusCodes}
+	return b
+}
+
+// WithDefaultBehavior makes the Origin being built the target of the default Behavior of its Distribution, in place
+// of the default origin. The default Behavior takes the configuration of the Origin's catch-all (/*) Behavior,
+// which is added with no functions and default methods if not set.
+func (b OriginBuilder) WithDefaultBehavior() OriginBuilder {
+	b.isDefault = true
+	return b
+}
+
 // WithRequestPolicy associates a given origin request policy ID with all Behaviors in the Origin being built
 func (b OriginBuilder) WithRequestPolicy(policy string) OriginBuilder {
 	if len(policy) > 0 {
@@ -189,6 +404,47 @@ func (b OriginBuilder) WithResponseTimeout(rpTimeout int64) OriginBuilder {
 	return b
 }
 
+// WithOriginPath sets the directory CloudFront requests content from within the Origin's host
+func (b OriginBuilder) WithOriginPath(path string) OriginBuilder {
+	b.path = path
+	return b
+}
+
+// WithProtocolPolicy sets the protocol CloudFront uses to connect to the Origin
+func (b OriginBuilder) WithProtocolPolicy(policy string) OriginBuilder {
+	if len(policy) > 0 {
+		b.protocolPolicy = policy
+	}
+	return b
+}
+
+// WithPorts sets the ports CloudFront uses for HTTP and HTTPS connections to the Origin
+func (b OriginBuilder) WithPorts(httpPort, httpsPort int64) OriginBuilder {
+	if httpPort > 0 {
+		b.httpPort = httpPort
+	}
+	if httpsPort > 0 {
+		b.httpsPort = httpsPort
+	}
+	return b
+}
+
+// WithKeepaliveTimeout sets how long CloudFront keeps idle connections to the Origin open
+func (b OriginBuilder) WithKeepaliveTimeout(timeout int64) OriginBuilder {
+	if timeout > 0 {
+		b.keepaliveTimeout = timeout
+	}
+	return b
+}
+
+// WithMinSSLProtocol sets the oldest SSL/TLS protocol CloudFront may use for HTTPS connections to the Origin
+func (b OriginBuilder) WithMinSSLProtocol(protocol string) OriginBuilder {
+	if len(protocol) > 0 {
+		b.minSSLProtocol = protocol
+	}
+	return b
+}
+
 // WithOriginHeaders associates a map of HTTP headers that CloudFront should add on every request to the Origin
 func (b OriginBuilder) WithOriginHeaders(headers map[string]string) OriginBuilder {
 	b.headers = headers
@@ -198,11 +454,18 @@ func (b OriginBuilder) WithOriginHeaders(headers map[string]string) OriginBuilde
 // Build creates an Origin based on configuration made so far
 func (b OriginBuilder) Build() Origin {
 	origin := Origin{
-		Host:            b.host,
-		ResponseTimeout: b.respTimeout,
+		Host:             b.host,
+		Path:             b.path,
+		ResponseTimeout:  b.respTimeout,
+		ProtocolPolicy:   b.protocolPolicy,
+		HTTPPort:         b.httpPort,
+		HTTPSPort:        b.httpsPort,
+		KeepaliveTimeout: b.keepaliveTimeout,
+		MinSSLProtocol:   b.minSSLProtocol,
 	}
 
 	origin.headers = newOriginHeaders(b.host, b.headers)
+	origin.isDefault = b.isDefault
 
 	origin = b.addBehaviors(origin)
 
@@ -214,16 +477,46 @@ func (b OriginBuilder) Build() Origin {
 
 	origin = b.addOriginAccessConfiguration(origin)
 
-	return origin
+	origin = b.addFailover(origin)
+
+	return origin.withID(b.host)
 }
 
 func (b OriginBuilder) addBehaviors(origin Origin) Origin {
 	for p, functions := range b.behaviors {
-		origin.Behaviors = append(origin.Behaviors, Behavior{PathPattern: p, OriginHost: b.host, FunctionAssociations: functions})
+		origin.Behaviors = append(origin.Behaviors, Behavior{
+			PathPattern:          p,
+			OriginHost:           b.host,
+			FunctionAssociations: functions,
+			AllowedMethods:       b.allowedMethods(p),
+			CachedMethods:        b.cachedMethods(p),
+			ViewerProtocolPolicy: b.viewerProtocolPolicy(p),
+		})
 	}
 	return origin
 }
 
+func (b OriginBuilder) allowedMethods(pathPattern string) []string {
+	if m := b.methods[pathPattern]; len(m.allowed) > 0 {
+		return m.allowed
+	}
+	return defaultAllowedMethods
+}
+
+func (b OriginBuilder) cachedMethods(pathPattern string) []string {
+	if m := b.methods[pathPattern]; len(m.cached) > 0 {
+		return m.cached
+	}
+	return defaultCachedMethods
+}
+
+func (b OriginBuilder) viewerProtocolPolicy(pathPattern string) string {
+	if policy, ok := b.viewerPolicies[pathPattern]; ok {
+		return policy
+	}
+	return defaultViewerProtocolPolicy
+}
+
 func (b OriginBuilder) addRequestPolicyToBehaviors(origin Origin) Origin {
 	for i := range origin.Behaviors {
 		origin.Behaviors[i].RequestPolicy = b.requestPolicy
@@ -245,11 +538,30 @@ func (b OriginBuilder) addResponsePolicyToBehaviors(origin Origin) Origin {
 	return origin
 }
 
+func (b OriginBuilder) addFailover(origin Origin) Origin {
+	if b.failover == nil {
+		return origin
+	}
+
+	secondary := b
+	secondary.host = b.failover.host
+	secondary.path = b.failover.path
+	secondary.accessType = b.failover.accessType
+	secondary.behaviors = make(map[string][]Function)
+	secondary.failover = nil
+
+	origin.Failover = &Failover{
+		Secondary:   secondary.Build(),
+		StatusCodes: b.failover.statusCodes,
+	}
+	return origin
+}
+
 func (b OriginBuilder) addOriginAccessConfiguration(origin Origin) Origin {
 	if origin.Access != OriginAccessBucket {
 		return origin
 	}
 
-	origin.OAC = NewOAC(b.distributionName, b.host)
+	origin.OAC = NewOAC(b.distributionName, b.host, b.controllerID)
 	return origin
 }
@@ -115,6 +115,144 @@ func (s *OriginTestSuite) TestNewOriginBuilder_WithBehavior_WithFunctions() {
 	s.Equal("some-other-arn", o.Behaviors[0].FunctionAssociations[1].ARN())
 }
 
+func (s *OriginTestSuite) TestNewOriginBuilder_WithDefaultBehavior() {
+	o := NewOriginBuilder("dist", "origin", "Public", s.cfg).WithBehavior("/foo").WithDefaultBehavior().Build()
+	s.True(o.isDefault)
+	s.Require().Len(o.Behaviors, 1, "a catch-all behavior should not be added")
+	s.Equal("/foo", o.Behaviors[0].PathPattern)
+
+	o = NewOriginBuilder("dist", "origin", "Public", s.cfg).
+		WithBehavior("/*", newResponseCloudfrontFunction("some-arn", awscloudfront.EventTypeViewerResponse)).
+		WithDefaultBehavior().
+		Build()
+	s.Len(o.Behaviors, 1)
+	s.Len(o.Behaviors[0].FunctionAssociations, 1, "the catch-all behavior should be kept as is")
+}
+
+func (s *OriginTestSuite) TestNewOriginBuilder_WithMethods() {
+	o := NewOriginBuilder("dist", "origin", "Public", s.cfg).
+		WithBehavior("/foo").
+		WithMethods("/foo", []string{"GET", "HEAD"}, []string{"GET", "HEAD"}).
+		Build()
+	s.Len(o.Behaviors, 1)
+	s.Equal([]string{"GET", "HEAD"}, o.Behaviors[0].AllowedMethods)
+	s.Equal([]string{"GET", "HEAD"}, o.Behaviors[0].CachedMethods)
+}
+
+func (s *OriginTestSuite) TestNewOriginBuilder_MethodsDefaultToAllAllowedAndReadOnlyCached() {
+	o := NewOriginBuilder("dist", "origin", "Public", s.cfg).
+		WithBehavior("/foo").
+		WithMethods("/foo", nil, []string{"GET", "HEAD", "OPTIONS"}).
+		WithBehavior("/bar").
+		Build()
+	s.Len(o.Behaviors, 2)
+	for _, b := range o.Behaviors {
+		s.Equal(defaultAllowedMethods, b.AllowedMethods)
+		if b.PathPattern == "/foo" {
+			s.Equal([]string{"GET", "HEAD", "OPTIONS"}, b.CachedMethods)
+		} else {
+			s.Equal(defaultCachedMethods, b.CachedMethods)
+		}
+	}
+}
+
+func (s *OriginTestSuite) TestNewOriginBuilder_WithViewerProtocolPolicy() {
+	o := NewOriginBuilder("dist", "origin", "Public", s.cfg).
+		WithBehavior("/foo").
+		WithViewerProtocolPolicy("/foo", "allow-all").
+		WithBehavior("/bar").
+		WithViewerProtocolPolicy("/bar", "").
+		Build()
+	s.Len(o.Behaviors, 2)
+	for _, b := range o.Behaviors {
+		if b.PathPattern == "/foo" {
+			s.Equal("allow-all", b.ViewerProtocolPolicy)
+		} else {
+			s.Equal("redirect-to-https", b.ViewerProtocolPolicy)
+		}
+	}
+}
+
+func (s *OriginTestSuite) TestNewOriginBuilder_ProtocolDefaults() {
+	s.cfg.CloudFrontDefaultOriginMinSSLProtocol = "TLSv1.1"
+	o := NewOriginBuilder("dist", "origin", "Public", s.cfg).Build()
+
+	s.Equal("match-viewer", o.ProtocolPolicy)
+	s.Equal(int64(80), o.HTTPPort)
+	s.Equal(int64(443), o.HTTPSPort)
+	s.Equal(int64(5), o.KeepaliveTimeout)
+	s.Equal("TLSv1.1", o.MinSSLProtocol)
+}
+
+func (s *OriginTestSuite) TestNewOriginBuilder_WithProtocolSettings() {
+	o := NewOriginBuilder("dist", "origin", "Public", s.cfg).
+		WithProtocolPolicy("http-only").
+		WithPorts(8080, 0).
+		WithKeepaliveTimeout(60).
+		WithMinSSLProtocol("TLSv1.2").
+		Build()
+
+	s.Equal("http-only", o.ProtocolPolicy)
+	s.Equal(int64(8080), o.HTTPPort)
+	s.Equal(int64(443), o.HTTPSPort)
+	s.Equal(int64(60), o.KeepaliveTimeout)
+	s.Equal("TLSv1.2", o.MinSSLProtocol)
+}
+
+func (s *OriginTestSuite) TestNewOriginBuilder_WithOriginPath() {
+	o := NewOriginBuilder("dist", "origin", "Public", s.cfg).
+		WithOriginPath("/prefix").
+		WithBehavior("/foo").
+		Build()
+
+	s.Equal("/prefix", o.Path)
+	s.Equal("origin", o.ID())
+	s.Equal("origin", o.Behaviors[0].OriginID)
+	s.False(o.HasEqualParameters(NewOriginBuilder("dist", "origin", "Public", s.cfg).Build()))
+}
+
+func (s *OriginTestSuite) TestNewOriginBuilder_IDDefaultsToHost() {
+	o := NewOriginBuilder("dist", "origin", "Public", s.cfg).WithBehavior("/foo").Build()
+
+	s.Equal("origin", o.ID())
+	s.Equal("origin", o.Behaviors[0].OriginID)
+}
+
+func (s *OriginTestSuite) TestHasEqualParameters_DifferentHeaders() {
+	o := NewOriginBuilder("dist", "origin", "Public", s.cfg).WithOriginHeaders(map[string]string{"foo": "bar"}).Build()
+	o2 := NewOriginBuilder("dist", "origin", "Public", s.cfg).WithOriginHeaders(map[string]string{"foo": "baz"}).Build()
+
+	s.False(o.HasEqualParameters(o2))
+	s.True(o.HasEqualParameters(o.withID("other")), "IDs are not parameters")
+	s.Equal("other", o.withID("other").ID())
+	s.NotEqual(o.hashedID(), o2.hashedID())
+	s.Equal(o.hashedID(), NewOriginBuilder("dist", "origin", "Public", s.cfg).WithOriginHeaders(map[string]string{"foo": "bar"}).Build().hashedID())
+}
+
+func (s *OriginTestSuite) TestNewOriginBuilder_WithFailover() {
+	o := NewOriginBuilder("dist", "origin", "Public", s.cfg).
+		WithResponseTimeout(10).
+		WithFailover("bucket.s3.amazonaws.com", "/maintenance", "Bucket", []int64{500, 503}).
+		WithBehavior("/foo").
+		Build()
+
+	s.Equal("origin", o.ID())
+	s.Equal("origin-failover", o.TargetID())
+	s.Equal("origin-failover", o.Behaviors[0].OriginID)
+
+	s.Equal([]int64{500, 503}, o.Failover.StatusCodes)
+	secondary := o.Failover.Secondary
+	s.Equal("bucket.s3.amazonaws.com", secondary.ID())
+	s.Equal("/maintenance", secondary.Path)
+	s.Equal("Bucket", secondary.Access)
+	s.Equal(NewOAC("dist", "bucket.s3.amazonaws.com", ""), secondary.OAC)
+	s.Equal(int64(10), secondary.ResponseTimeout)
+	s.Empty(secondary.Behaviors)
+	s.Nil(secondary.Failover)
+
+	s.False(o.HasEqualParameters(NewOriginBuilder("dist", "origin", "Public", s.cfg).WithResponseTimeout(10).Build()))
+}
+
 func (s *OriginTestSuite) TestNewOriginBuilder_WithRequestPolicy() {
 	o := NewOriginBuilder("dist", "origin", "Public", s.cfg).
 		WithBehavior("/").
@@ -165,10 +303,14 @@ func (s *OriginTestSuite) TestNewOriginBuilder_TestHasDifferentParameters() {
 		Build()
 	o3 := NewOriginBuilder("dist", "origin", "Public", s.cfg).
 		Build()
+	o4 := NewOriginBuilder("dist", "origin", "Bucket", s.cfg).
+		WithPorts(8080, 0).
+		Build()
 
 	s.False(o.HasEqualParameters(o1))
 	s.False(o.HasEqualParameters(o2))
 	s.False(o.HasEqualParameters(o3))
+	s.False(o.HasEqualParameters(o4))
 	s.True(o.HasEqualParameters(o))
 }
 
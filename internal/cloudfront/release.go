@@ -0,0 +1,126 @@
+// Copyright (c) 2023 GPBR Participacoes LTDA.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package cloudfront
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/Gympass/cdn-origin-controller/api/v1alpha1"
+	"github.com/Gympass/cdn-origin-controller/internal/k8s"
+	"github.com/Gympass/cdn-origin-controller/internal/route53"
+)
+
+const reasonReleased = "Released"
+
+// releaseIfRequested releases the group of the reconciling object if any of its members asks for it.
+// Returns whether the group is released, in which case it must not be reconciled any further.
+func (s *Service) releaseIfRequested(ctx context.Context, obj client.Object, reconciling k8s.CDNIngress) (bool, error) {
+	desiredIngresses, err := s.desiredIngresses(ctx, reconciling)
+	if err != nil {
+		return false, err
+	}
+
+	if !reconciling.Release && !k8s.IsGroupReleased(desiredIngresses) {
+		return false, nil
+	}
+
+	dryRun := s.Config.DryRun || reconciling.DryRun
+	for _, ing := range desiredIngresses {
+		dryRun = dryRun || ing.DryRun
+	}
+
+	return true, s.release(ctx, obj, reconciling, dryRun)
+}
+
+// release stops managing the group's distribution without deleting it or its DNS records.
+// Ownership tags and TXT records are removed, then finalizers of all group members and finally the CDNStatus.
+// Every step is skipped if already done, so releasing a group more than once is safe.
+func (s *Service) release(ctx context.Context, obj client.Object, reconciling k8s.CDNIngress, dryRun bool) error {
+	group := reconciling.Group
+	if dryRun {
+		s.Recorder.Eventf(obj, corev1.EventTypeNormal, reasonDryRun,
+			"Dry-run: group %s would be released, its distribution and DNS records would be kept but no longer managed", group)
+		return nil
+	}
+
+	log, _ := logr.FromContext(ctx)
+	log.V(1).Info("Releasing group, its distribution will no longer be managed.", "group", group)
+
+	cdnStatus := &v1alpha1.CDNStatus{}
+	err := s.Client.Get(ctx, client.ObjectKey{Name: group}, cdnStatus)
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return fmt.Errorf("fetching CDNStatus: %v", err)
+	}
+	cdnStatusExists := err == nil
+
+	arn, err := s.DistRepo.ARNByGroup(group)
+	if err == nil {
+		err = s.DistRepo.Release(arn)
+	}
+	if err != nil && !errors.Is(err, ErrDistNotFound) {
+		return fmt.Errorf("releasing distribution: %v", err)
+	}
+
+	if cdnStatusExists && reconciling.Class.CreateAlias && cdnStatus.Status.DNS != nil {
+		aliases := route53.NewAliases("", reconciling.Class.HostedZoneID, reconciling.Class.TXTOwnerValue, cdnStatus.Status.DNS.Records, false)
+		if err := s.AliasRepo.ReleaseOwnership(aliases); err != nil {
+			return fmt.Errorf("releasing ownership of DNS records: %v", err)
+		}
+	}
+
+	if err := s.removeGroupFinalizers(ctx, group); err != nil {
+		return err
+	}
+
+	if cdnStatusExists {
+		if err := s.deleteCDNStatus(ctx, cdnStatus); err != nil {
+			return fmt.Errorf("deleting CDNStatus: %v", err)
+		}
+		s.Recorder.Eventf(obj, corev1.EventTypeNormal, reasonReleased,
+			"Released group %s, its distribution and DNS records are no longer managed by the controller", group)
+	}
+
+	return nil
+}
+
+func (s *Service) removeGroupFinalizers(ctx context.Context, group string) error {
+	members, err := k8s.GroupMembers(ctx, s.Client, group)
+	if err != nil {
+		return fmt.Errorf("listing members of group %s: %v", group, err)
+	}
+
+	for _, m := range members {
+		if !k8s.HasFinalizer(m) {
+			continue
+		}
+		k8s.RemoveFinalizer(m)
+		if err := s.Client.Update(ctx, m); err != nil && !k8serrors.IsNotFound(err) {
+			return fmt.Errorf("removing finalizer from %s/%s: %v", m.GetNamespace(), m.GetName(), err)
+		}
+	}
+	return nil
+}
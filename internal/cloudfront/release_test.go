@@ -0,0 +1,171 @@
+// Copyright (c) 2023 GPBR Participacoes LTDA.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package cloudfront
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	networkingv1 "k8s.io/api/networking/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/Gympass/cdn-origin-controller/api/v1alpha1"
+	"github.com/Gympass/cdn-origin-controller/internal/k8s"
+	"github.com/Gympass/cdn-origin-controller/internal/route53"
+)
+
+func (m *distRepoMock) ARNByGroup(group string) (string, error) {
+	args := m.Called(group)
+	return args.String(0), args.Error(1)
+}
+
+func (m *distRepoMock) Release(arn string) error {
+	args := m.Called(arn)
+	return args.Error(0)
+}
+
+type aliasRepoMock struct {
+	mock.Mock
+	route53.AliasRepository
+}
+
+func (m *aliasRepoMock) ReleaseOwnership(aliases route53.Aliases) error {
+	args := m.Called(aliases)
+	return args.Error(0)
+}
+
+func TestRunReleaseTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, &ReleaseTestSuite{})
+}
+
+type ReleaseTestSuite struct {
+	suite.Suite
+}
+
+func (s *ReleaseTestSuite) newService(distRepo DistributionRepository, aliasRepo route53.AliasRepository, objs ...client.Object) (*Service, *record.FakeRecorder) {
+	scheme := runtime.NewScheme()
+	s.NoError(clientgoscheme.AddToScheme(scheme))
+	s.NoError(v1alpha1.AddToScheme(scheme))
+
+	k8sClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(objs...).
+		WithStatusSubresource(&v1alpha1.CDNStatus{}).
+		Build()
+
+	recorder := record.NewFakeRecorder(10)
+	return &Service{Client: k8sClient, Recorder: recorder, DistRepo: distRepo, AliasRepo: aliasRepo}, recorder
+}
+
+func newReleaseIngress(name, group string) *networkingv1.Ingress {
+	return &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   "default",
+			Annotations: map[string]string{k8s.CDNGroupAnnotation: group},
+			Finalizers:  []string{k8s.CDNFinalizer},
+		},
+	}
+}
+
+func (s *ReleaseTestSuite) TestRelease_StopsManagingGroup() {
+	cdnStatus := &v1alpha1.CDNStatus{
+		ObjectMeta: metav1.ObjectMeta{Name: "group"},
+		Status: v1alpha1.CDNStatusStatus{
+			ID:  "dist-id",
+			DNS: &v1alpha1.DNSStatus{Records: []string{"alias.foo.bar."}},
+		},
+	}
+	releasing := newReleaseIngress("releasing", "group")
+	member := newReleaseIngress("member", "group")
+	dist := &v1alpha1.Distribution{
+		ObjectMeta: metav1.ObjectMeta{Name: "dist", Namespace: "default", Finalizers: []string{k8s.CDNFinalizer}},
+		Spec:       v1alpha1.DistributionSpec{Group: "group"},
+	}
+	other := newReleaseIngress("other", "other-group")
+
+	distRepo := &distRepoMock{}
+	distRepo.On("ARNByGroup", "group").Return("arn:aws:cloudfront::000000000000:distribution/dist-id", nil).Once()
+	distRepo.On("Release", "arn:aws:cloudfront::000000000000:distribution/dist-id").Return(nil).Once()
+	aliasRepo := &aliasRepoMock{}
+	aliasRepo.On("ReleaseOwnership", route53.NewAliases("", "zone", "owner", []string{"alias.foo.bar."}, false)).Return(nil).Once()
+
+	svc, recorder := s.newService(distRepo, aliasRepo, cdnStatus, releasing, member, dist, other)
+	reconciling := k8s.CDNIngress{
+		Group: "group",
+		Class: k8s.CDNClass{CreateAlias: true, HostedZoneID: "zone", TXTOwnerValue: "owner"},
+	}
+
+	s.NoError(svc.release(context.Background(), releasing, reconciling, false))
+
+	err := svc.Get(context.Background(), client.ObjectKey{Name: "group"}, &v1alpha1.CDNStatus{})
+	s.True(k8serrors.IsNotFound(err))
+	s.False(s.hasFinalizer(svc, client.ObjectKeyFromObject(releasing), &networkingv1.Ingress{}))
+	s.False(s.hasFinalizer(svc, client.ObjectKeyFromObject(member), &networkingv1.Ingress{}))
+	s.False(s.hasFinalizer(svc, client.ObjectKeyFromObject(dist), &v1alpha1.Distribution{}))
+	s.True(s.hasFinalizer(svc, client.ObjectKeyFromObject(other), &networkingv1.Ingress{}))
+	s.Len(recorder.Events, 1)
+	distRepo.AssertExpectations(s.T())
+	aliasRepo.AssertExpectations(s.T())
+}
+
+func (s *ReleaseTestSuite) TestRelease_AlreadyReleased() {
+	distRepo := &distRepoMock{}
+	distRepo.On("ARNByGroup", "group").Return("", ErrDistNotFound).Once()
+	aliasRepo := &aliasRepoMock{}
+
+	releasing := newReleaseIngress("releasing", "group")
+	releasing.Finalizers = nil
+	svc, recorder := s.newService(distRepo, aliasRepo, releasing)
+
+	s.NoError(svc.release(context.Background(), releasing, k8s.CDNIngress{Group: "group"}, false))
+	s.Empty(recorder.Events)
+	distRepo.AssertNotCalled(s.T(), "Release", mock.Anything)
+	aliasRepo.AssertNotCalled(s.T(), "ReleaseOwnership", mock.Anything)
+}
+
+func (s *ReleaseTestSuite) TestRelease_DryRunChangesNothing() {
+	cdnStatus := &v1alpha1.CDNStatus{ObjectMeta: metav1.ObjectMeta{Name: "group"}}
+	releasing := newReleaseIngress("releasing", "group")
+	distRepo := &distRepoMock{}
+	aliasRepo := &aliasRepoMock{}
+	svc, recorder := s.newService(distRepo, aliasRepo, cdnStatus, releasing)
+
+	s.NoError(svc.release(context.Background(), releasing, k8s.CDNIngress{Group: "group"}, true))
+
+	s.NoError(svc.Get(context.Background(), client.ObjectKey{Name: "group"}, &v1alpha1.CDNStatus{}))
+	s.True(s.hasFinalizer(svc, client.ObjectKeyFromObject(releasing), &networkingv1.Ingress{}))
+	s.Len(recorder.Events, 1)
+	distRepo.AssertNotCalled(s.T(), "ARNByGroup", mock.Anything)
+}
+
+func (s *ReleaseTestSuite) hasFinalizer(svc *Service, key client.ObjectKey, obj client.Object) bool {
+	s.NoError(svc.Get(context.Background(), key, obj))
+	return k8s.HasFinalizer(obj)
+}
@@ -20,21 +20,19 @@
 package cloudfront
 
 import (
-	"context"
 	"errors"
 	"fmt"
 	"sort"
-	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	awscloudfront "github.com/aws/aws-sdk-go/service/cloudfront"
 	"github.com/aws/aws-sdk-go/service/cloudfront/cloudfrontiface"
 	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi"
 	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi/resourcegroupstaggingapiiface"
-	"k8s.io/apimachinery/pkg/util/wait"
 
 	cdnaws "github.com/Gympass/cdn-origin-controller/internal/aws"
 	"github.com/Gympass/cdn-origin-controller/internal/config"
+	"github.com/Gympass/cdn-origin-controller/internal/metrics"
 	"github.com/Gympass/cdn-origin-controller/internal/strhelper"
 )
 
@@ -54,14 +52,38 @@ type DistributionRepository interface {
 	// the given group.
 	// Returns ErrDistNotFound if no existing Distribution was found.
 	ARNByGroup(group string) (string, error)
+	// Adopt validates an existing Distribution not created by the operator and tags it as owned by the given group,
+	// so it's found by ARNByGroup from then on. Returns the ARN of the adopted Distribution.
+	// If dryRun is true the Distribution is only validated, not tagged.
+	Adopt(id, group string, dryRun bool) (string, error)
 	// Create creates the given Distribution on CloudFront. Returns the created dist.
 	Create(Distribution) (Distribution, error)
 	// Gets the Distribution configuration by ID.
 	DistributionConfigByID(id string) (*awscloudfront.GetDistributionConfigOutput, error)
 	// Sync ensures the given Distribution is correctly configured on CloudFront. Returns synced dist.
+	// No changes are made if the Distribution is already correctly configured.
+	// If the Distribution is in dry-run mode nothing is changed, and the returned dist holds the planned changes instead.
 	Sync(Distribution) (Distribution, error)
-	// Delete deletes the Distribution at AWS
-	Delete(Distribution) error
+	// Disable disables the Distribution of given ID, the first step of deleting it. Nothing is changed if it's
+	// already disabled. Returns the IDs of the OACs associated with the Distribution, which can only be deleted
+	// after it. Returns ErrDistNotFound if the Distribution doesn't exist.
+	Disable(id string) ([]string, error)
+	// IsDeployed returns whether the latest changes to the Distribution of given ID have been deployed to all edge
+	// locations. Returns ErrDistNotFound if the Distribution doesn't exist.
+	IsDeployed(id string) (bool, error)
+	// Delete deletes the Distribution of given ID at AWS, which must be disabled and deployed.
+	// Nothing is done if it doesn't exist.
+	Delete(id string) error
+	// DeleteOACs deletes the OACs of given IDs. OACs which don't exist are ignored.
+	DeleteOACs(ids []string) error
+	// ListOwned lists all Distributions owned by the operator and marked with its controller ID, with only their ID,
+	// ARN and group set
+	ListOwned() ([]Distribution, error)
+	// OACIDsInUse returns the IDs of all OACs associated with any distribution in the account, owned by the operator or not
+	OACIDsInUse() ([]string, error)
+	// Release removes the ownership tags from the Distribution of given ARN, so it's no longer found by ARNByGroup.
+	// The Distribution itself is kept unchanged.
+	Release(arn string) error
 }
 
 // PostCreationOperationsFunc executes necessary operations on a recently-created Distribution.
@@ -74,7 +96,6 @@ type DistRepository struct {
 	OACRepo                   OACRepository
 	TaggingClient             resourcegroupstaggingapiiface.ResourceGroupsTaggingAPIAPI
 	CallerRef                 CallerRefFn
-	WaitTimeout               time.Duration
 	RunPostCreationOperations PostCreationOperationsFunc
 	Cfg                       config.Config
 }
@@ -110,6 +131,133 @@ func (r DistRepository) ARNByGroup(group string) (string, error) {
 	return aws.StringValue(out.ResourceTagMappingList[0].ResourceARN), nil
 }
 
+func (r DistRepository) ListOwned() ([]Distribution, error) {
+	input := &resourcegroupstaggingapi.GetResourcesInput{
+		ResourceTypeFilters: []*string{aws.String("cloudfront:distribution")},
+		TagFilters: []*resourcegroupstaggingapi.TagFilter{
+			{
+				Key:    aws.String(ownershipTagKey),
+				Values: aws.StringSlice([]string{ownershipTagValue}),
+			},
+			{
+				Key:    aws.String(controllerIDTagKey),
+				Values: aws.StringSlice([]string{r.Cfg.ControllerID}),
+			},
+		},
+	}
+
+	var result []Distribution
+	for {
+		out, err := r.TaggingClient.GetResources(input)
+		if err != nil {
+			return nil, fmt.Errorf("listing CloudFronts: %v", err)
+		}
+
+		for _, mapping := range out.ResourceTagMappingList {
+			arn := aws.StringValue(mapping.ResourceARN)
+			d := Distribution{ARN: arn, ID: distributionIDFromARN(arn)}
+			for _, t := range mapping.Tags {
+				if aws.StringValue(t.Key) == groupTagKey {
+					d.Group = aws.StringValue(t.Value)
+				}
+			}
+			result = append(result, d)
+		}
+
+		if len(aws.StringValue(out.PaginationToken)) == 0 {
+			return result, nil
+		}
+		input.PaginationToken = out.PaginationToken
+	}
+}
+
+func (r DistRepository) OACIDsInUse() ([]string, error) {
+	var ids []string
+	err := r.CloudFrontClient.ListDistributionsPages(&awscloudfront.ListDistributionsInput{}, func(out *awscloudfront.ListDistributionsOutput, _ bool) bool {
+		if out.DistributionList == nil {
+			return true
+		}
+		for _, summary := range out.DistributionList.Items {
+			if summary.Origins == nil {
+				continue
+			}
+			for _, o := range summary.Origins.Items {
+				if !strhelper.IsEmptyOrNil(o.OriginAccessControlId) {
+					ids = append(ids, aws.StringValue(o.OriginAccessControlId))
+				}
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("listing distributions: %v", err)
+	}
+	return ids, nil
+}
+
+func (r DistRepository) Adopt(id, group string, dryRun bool) (string, error) {
+	out, err := r.distributionByID(id)
+	if err != nil {
+		return "", fmt.Errorf("getting distribution %s: %v", id, err)
+	}
+
+	if aws.BoolValue(out.Distribution.DistributionConfig.Staging) {
+		return "", fmt.Errorf("distribution %s is a staging distribution and can't be adopted", id)
+	}
+
+	arn := aws.StringValue(out.Distribution.ARN)
+	listOut, err := r.CloudFrontClient.ListTagsForResource(&awscloudfront.ListTagsForResourceInput{
+		Resource: aws.String(arn),
+	})
+	if err != nil {
+		return "", fmt.Errorf("listing tags: %v", err)
+	}
+
+	if owner, ok := owningGroup(listOut.Tags); ok && owner != group {
+		return "", fmt.Errorf("distribution %s is already owned by group %s", id, owner)
+	}
+
+	if dryRun {
+		return arn, nil
+	}
+
+	tags := []*awscloudfront.Tag{
+		{Key: aws.String(ownershipTagKey), Value: aws.String(ownershipTagValue)},
+		{Key: aws.String(groupTagKey), Value: aws.String(group)},
+	}
+	if len(r.Cfg.ControllerID) > 0 {
+		tags = append(tags, &awscloudfront.Tag{Key: aws.String(controllerIDTagKey), Value: aws.String(r.Cfg.ControllerID)})
+	}
+
+	_, err = r.CloudFrontClient.TagResource(&awscloudfront.TagResourceInput{
+		Resource: aws.String(arn),
+		Tags:     &awscloudfront.Tags{Items: tags},
+	})
+	if err != nil {
+		return "", fmt.Errorf("tagging distribution %s: %v", id, err)
+	}
+
+	return arn, nil
+}
+
+// owningGroup returns the group that owns a distribution based on its tags, and whether it's owned by the operator at all
+func owningGroup(tags *awscloudfront.Tags) (string, bool) {
+	var owned bool
+	var group string
+	if tags == nil {
+		return "", false
+	}
+	for _, t := range tags.Items {
+		switch aws.StringValue(t.Key) {
+		case ownershipTagKey:
+			owned = aws.StringValue(t.Value) == ownershipTagValue
+		case groupTagKey:
+			group = aws.StringValue(t.Value)
+		}
+	}
+	return group, owned
+}
+
 func (r DistRepository) Create(d Distribution) (Distribution, error) {
 	config := newAWSDistributionConfig(d, r.CallerRef, r.Cfg)
 	createInput := &awscloudfront.CreateDistributionWithTagsInput{
@@ -122,6 +270,7 @@ func (r DistRepository) Create(d Distribution) (Distribution, error) {
 	if err != nil {
 		return Distribution{}, fmt.Errorf("creating distribution: %v", err)
 	}
+	d.GeoRestriction = observedGeoRestriction(config.Restrictions)
 
 	observed, err := r.prepareAndRunPostCreationOperations(d, out)
 	if err != nil {
@@ -132,6 +281,10 @@ func (r DistRepository) Create(d Distribution) (Distribution, error) {
 }
 
 func (r DistRepository) Sync(d Distribution) (Distribution, error) {
+	if d.DryRun {
+		return r.plan(d)
+	}
+
 	config := newAWSDistributionConfig(d, r.CallerRef, r.Cfg)
 	output, err := r.DistributionConfigByID(d.ID)
 	if err != nil {
@@ -147,16 +300,19 @@ func (r DistRepository) Sync(d Distribution) (Distribution, error) {
 
 	r.forEachOrigin(config, func(o *awscloudfront.Origin) {
 		for _, oac := range syncedOACs {
-			if aws.StringValue(o.Id) == oac.OriginName {
+			if aws.StringValue(o.DomainName) == oac.OriginName {
 				o.SetOriginAccessControlId(oac.ID)
 			}
 		}
 	})
 
-	config.SetCallerReference(*output.DistributionConfig.CallerReference)
-	config.SetDefaultRootObject(*output.DistributionConfig.DefaultRootObject)
-	config.SetCustomErrorResponses(output.DistributionConfig.CustomErrorResponses)
-	config.SetRestrictions(output.DistributionConfig.Restrictions)
+	keepUnmanagedFields(config, output.DistributionConfig)
+	d.GeoRestriction = observedGeoRestriction(config.Restrictions)
+
+	if changes := diffDistributionConfigs(output.DistributionConfig, config); len(changes) == 0 {
+		metrics.DistributionUpdates.WithLabelValues(metrics.UpdateSkipped).Inc()
+		return r.runPostSkippedUpdateOperations(d)
+	}
 
 	updateInput := &awscloudfront.UpdateDistributionInput{
 		DistributionConfig: config,
@@ -168,6 +324,7 @@ func (r DistRepository) Sync(d Distribution) (Distribution, error) {
 	if err != nil {
 		return Distribution{}, fmt.Errorf("updating distribution: %v", err)
 	}
+	metrics.DistributionUpdates.WithLabelValues(metrics.UpdateApplied).Inc()
 
 	observed, err := r.runPostUpdateOperations(d, oacsToBeDeleted, updateOut)
 	if err != nil {
@@ -177,37 +334,117 @@ func (r DistRepository) Sync(d Distribution) (Distribution, error) {
 	return observed, nil
 }
 
-func (r DistRepository) Delete(d Distribution) error {
+// plan computes the changes needed for the Distribution to match the desired state, without applying them
+func (r DistRepository) plan(d Distribution) (Distribution, error) {
 	output, err := r.DistributionConfigByID(d.ID)
 	if err != nil {
-		return cdnaws.IgnoreErrorCodef("getting distribution config: %v", err, awscloudfront.ErrCodeNoSuchDistribution)
+		return Distribution{}, fmt.Errorf("getting distribution config: %v", err)
 	}
+	observed := output.DistributionConfig
 
-	if *output.DistributionConfig.Enabled {
-		err = r.disableDist(output.DistributionConfig, d.ID, *output.ETag)
-		if err != nil {
-			return cdnaws.IgnoreErrorCodef("disabling distribution: %v", err, awscloudfront.ErrCodeNoSuchDistribution)
-		}
+	desired := newAWSDistributionConfig(d, r.CallerRef, r.Cfg)
+	keepUnmanagedFields(desired, observed)
+
+	// OACs are only synced when changes are applied, so we assume existing OACs would be kept
+	r.forEachOrigin(observed, func(observedOrigin *awscloudfront.Origin) {
+		r.forEachOrigin(desired, func(desiredOrigin *awscloudfront.Origin) {
+			if aws.StringValue(desiredOrigin.DomainName) == aws.StringValue(observedOrigin.DomainName) && desiredOrigin.OriginAccessControlId != nil {
+				desiredOrigin.OriginAccessControlId = observedOrigin.OriginAccessControlId
+			}
+		})
+	})
+
+	d.PlannedChanges = diffDistributionConfigs(observed, desired)
+	return d, nil
+}
+
+// keepUnmanagedFields copies fields the controller doesn't manage from the observed to the desired config.
+// The default root object, custom error responses and geo restrictions are only managed if the desired config has them
+func keepUnmanagedFields(desired, observed *awscloudfront.DistributionConfig) {
+	desired.SetCallerReference(*observed.CallerReference)
+	if desired.DefaultRootObject == nil {
+		desired.DefaultRootObject = observed.DefaultRootObject
+	}
+	if desired.CustomErrorResponses == nil {
+		desired.SetCustomErrorResponses(observed.CustomErrorResponses)
 	}
+	if desired.Restrictions == nil {
+		desired.SetRestrictions(observed.Restrictions)
+	}
+	desired.ContinuousDeploymentPolicyId = observed.ContinuousDeploymentPolicyId
+	desired.Staging = observed.Staging
+}
+
+func (r DistRepository) Disable(id string) ([]string, error) {
+	output, err := r.DistributionConfigByID(id)
+	if cdnaws.IsErrorCode(err, awscloudfront.ErrCodeNoSuchDistribution) {
+		return nil, ErrDistNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("getting distribution config: %v", err)
+	}
+
+	oacIDs := r.allOACIDs(output.DistributionConfig)
+	if !aws.BoolValue(output.DistributionConfig.Enabled) {
+		return oacIDs, nil
+	}
+
+	err = r.disableDist(output.DistributionConfig, id, aws.StringValue(output.ETag))
+	if cdnaws.IsErrorCode(err, awscloudfront.ErrCodeNoSuchDistribution) {
+		return nil, ErrDistNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("disabling distribution: %v", err)
+	}
+	return oacIDs, nil
+}
+
+func (r DistRepository) IsDeployed(id string) (bool, error) {
+	output, err := r.distributionByID(id)
+	if cdnaws.IsErrorCode(err, awscloudfront.ErrCodeNoSuchDistribution) {
+		return false, ErrDistNotFound
+	}
+	if err != nil {
+		return false, fmt.Errorf("getting distribution: %v", err)
+	}
+	return aws.StringValue(output.Distribution.Status) == cfDeployedStatus, nil
+}
 
-	eTag, err := r.waitUntilDeployed(d.ID)
+func (r DistRepository) Delete(id string) error {
+	output, err := r.distributionByID(id)
 	if err != nil {
-		return cdnaws.IgnoreErrorCodef("waiting for distribution to be in deployed status: %w", err, awscloudfront.ErrCodeNoSuchDistribution)
+		return cdnaws.IgnoreErrorCodef("getting distribution: %v", err, awscloudfront.ErrCodeNoSuchDistribution)
 	}
 
 	input := &awscloudfront.DeleteDistributionInput{
-		Id:      aws.String(d.ID),
-		IfMatch: eTag,
+		Id:      aws.String(id),
+		IfMatch: output.ETag,
 	}
 	_, err = r.CloudFrontClient.DeleteDistribution(input)
 	if cdnaws.IgnoreErrorCode(err, awscloudfront.ErrCodeNoSuchDistribution) != nil {
-		return err
+		return fmt.Errorf("deleting distribution: %v", err)
 	}
+	return nil
+}
 
-	if err := r.deleteAllOACs(output.DistributionConfig); err != nil {
-		return fmt.Errorf("deleting OACs: %v", err)
+func (r DistRepository) DeleteOACs(ids []string) error {
+	var toBeDeleted []OAC
+	for _, id := range ids {
+		toBeDeleted = append(toBeDeleted, OAC{ID: id})
 	}
+	return r.deleteOACs(toBeDeleted)
+}
 
+func (r DistRepository) Release(arn string) error {
+	input := &awscloudfront.UntagResourceInput{
+		Resource: aws.String(arn),
+		TagKeys: &awscloudfront.TagKeys{
+			Items: aws.StringSlice([]string{ownershipTagKey, groupTagKey, controllerIDTagKey}),
+		},
+	}
+	if _, err := r.CloudFrontClient.UntagResource(input); err != nil {
+		return fmt.Errorf("removing ownership tags: %v", err)
+	}
 	return nil
 }
 
@@ -215,6 +452,8 @@ func (r DistRepository) prepareAndRunPostCreationOperations(d Distribution, out
 	d.ID = aws.StringValue(out.Distribution.Id)
 	d.ARN = aws.StringValue(out.Distribution.ARN)
 	d.Address = aws.StringValue(out.Distribution.DomainName)
+	d.DeploymentStatus = aws.StringValue(out.Distribution.Status)
+	d.LastModifiedTime = aws.TimeValue(out.Distribution.LastModifiedTime)
 	return r.RunPostCreationOperations(d)
 }
 
@@ -225,19 +464,78 @@ func (r DistRepository) runPostUpdateOperations(d Distribution, oacsToBeDeleted
 		return Distribution{}, fmt.Errorf("deleting unused OACs: %v", err)
 	}
 
+	if err := r.syncTags(d); err != nil {
+		return Distribution{}, err
+	}
+
+	d.ID = *updateOut.Distribution.Id
+	d.ARN = *updateOut.Distribution.ARN
+	d.Address = *updateOut.Distribution.DomainName
+	d.DeploymentStatus = aws.StringValue(updateOut.Distribution.Status)
+	d.LastModifiedTime = aws.TimeValue(updateOut.Distribution.LastModifiedTime)
+	return d, nil
+}
+
+// runPostSkippedUpdateOperations runs the operations that follow an update which was skipped because the
+// distribution config already matched the desired one
+func (r DistRepository) runPostSkippedUpdateOperations(d Distribution) (Distribution, error) {
+	if err := r.syncTags(d); err != nil {
+		return Distribution{}, err
+	}
+
+	output, err := r.distributionByID(d.ID)
+	if err != nil {
+		return Distribution{}, fmt.Errorf("getting distribution: %v", err)
+	}
+
+	d.ID = aws.StringValue(output.Distribution.Id)
+	d.ARN = aws.StringValue(output.Distribution.ARN)
+	d.Address = aws.StringValue(output.Distribution.DomainName)
+	d.DeploymentStatus = aws.StringValue(output.Distribution.Status)
+	d.LastModifiedTime = aws.TimeValue(output.Distribution.LastModifiedTime)
+	return d, nil
+}
+
+// syncTags ensures the distribution has all desired tags, only tagging it if any of them is missing or different
+func (r DistRepository) syncTags(d Distribution) error {
+	listOut, err := r.CloudFrontClient.ListTagsForResource(&awscloudfront.ListTagsForResourceInput{
+		Resource: aws.String(d.ARN),
+	})
+	if err != nil {
+		return fmt.Errorf("listing tags: %v", err)
+	}
+
+	if hasAllTags(listOut.Tags, d.Tags) {
+		return nil
+	}
+
 	tagsInput := &awscloudfront.TagResourceInput{
 		Resource: aws.String(d.ARN),
 		Tags:     r.distributionTags(d),
 	}
 
 	if _, err := r.CloudFrontClient.TagResource(tagsInput); err != nil {
-		return Distribution{}, fmt.Errorf("updating tags: %v", err)
+		return fmt.Errorf("updating tags: %v", err)
 	}
+	return nil
+}
 
-	d.ID = *updateOut.Distribution.Id
-	d.ARN = *updateOut.Distribution.ARN
-	d.Address = *updateOut.Distribution.DomainName
-	return d, nil
+// hasAllTags returns whether the observed tags contain all desired tags with matching values.
+// Tags not present in the desired ones are ignored, as tagging doesn't remove them.
+func hasAllTags(observed *awscloudfront.Tags, desired map[string]string) bool {
+	observedTags := make(map[string]string)
+	if observed != nil {
+		for _, t := range observed.Items {
+			observedTags[aws.StringValue(t.Key)] = aws.StringValue(t.Value)
+		}
+	}
+
+	for k, v := range desired {
+		if observedValue, ok := observedTags[k]; !ok || observedValue != v {
+			return false
+		}
+	}
+	return true
 }
 
 func (r DistRepository) distributionTags(d Distribution) *awscloudfront.Tags {
@@ -279,30 +577,6 @@ func (r DistRepository) disableDist(config *awscloudfront.DistributionConfig, id
 
 const cfDeployedStatus = "Deployed"
 
-func (r DistRepository) waitUntilDeployed(id string) (*string, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), (time.Second * 60))
-	defer cancel()
-
-	var eTag *string
-	condition := func(context.Context) (done bool, err error) {
-		out, err := r.distributionByID(id)
-		if err != nil {
-			if cdnaws.IsErrorCode(err, awscloudfront.ErrCodeNoSuchDistribution) {
-				return false, err
-			}
-			return false, nil
-		}
-		eTag = out.ETag
-		return *out.Distribution.Status == cfDeployedStatus, nil
-	}
-
-	interval := time.Second * 10
-	err := wait.PollUntilContextTimeout(ctx, interval, r.WaitTimeout, true, condition)
-	if err != nil {
-		return nil, err
-	}
-	return eTag, nil
-}
 func (r DistRepository) distributionByID(id string) (*awscloudfront.GetDistributionOutput, error) {
 	input := &awscloudfront.GetDistributionInput{
 		Id: aws.String(id),
@@ -322,12 +596,16 @@ func (r DistRepository) syncOACs(oacs []OAC) ([]OAC, error) {
 	return oacs, nil
 }
 
-func (r DistRepository) deleteAllOACs(distCfg *awscloudfront.DistributionConfig) error {
-	toBeDeleted := r.filterOACs(distCfg, func(o *awscloudfront.Origin) bool {
+func (r DistRepository) allOACIDs(distCfg *awscloudfront.DistributionConfig) []string {
+	all := r.filterOACs(distCfg, func(o *awscloudfront.Origin) bool {
 		return !strhelper.IsEmptyOrNil(o.OriginAccessControlId)
 	})
 
-	return r.deleteOACs(toBeDeleted)
+	var ids []string
+	for _, oac := range all {
+		ids = append(ids, oac.ID)
+	}
+	return ids
 }
 
 func (r DistRepository) deleteOACs(toBeDeleted []OAC) error {
@@ -344,7 +622,7 @@ func (r DistRepository) diffDesiredAndObservedOACs(desired Distribution, observe
 
 	toBeDeleted = r.filterOACs(observed, func(o *awscloudfront.Origin) bool {
 		originHasOAC := !strhelper.IsEmptyOrNil(o.OriginAccessControlId)
-		originIsDesired := desired.HasOrigin(aws.StringValue(o.Id))
+		originIsDesired := desired.HasOrigin(aws.StringValue(o.DomainName))
 
 		return originHasOAC && !originIsDesired
 	})
@@ -20,10 +20,8 @@
 package cloudfront
 
 import (
-	"context"
 	"errors"
 	"testing"
-	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
@@ -85,6 +83,11 @@ func (m *mockOACRepo) Delete(toBeDeleted OAC) (OAC, error) {
 	return m.expectedDeleteOutput, args.Error(0)
 }
 
+func (m *mockOACRepo) ListManaged() ([]OAC, error) {
+	args := m.Called()
+	return args.Get(0).([]OAC), args.Error(1)
+}
+
 func TestRunDistributionRepositoryTestSuite(t *testing.T) {
 	t.Parallel()
 	suite.Run(t, &DistributionRepositoryTestSuite{})
@@ -125,7 +128,6 @@ func (s *DistributionRepositoryTestSuite) TestARNByGroup_CloudFrontExists() {
 		OACRepo:          s.oacRepo,
 		TaggingClient:    s.taggingClient,
 		CallerRef:        testCallerRefFn,
-		WaitTimeout:      time.Second,
 		Cfg:              s.cfg,
 	}
 
@@ -142,7 +144,6 @@ func (s *DistributionRepositoryTestSuite) TestARNByGroup_ErrorGettingResources()
 		OACRepo:          s.oacRepo,
 		TaggingClient:    s.taggingClient,
 		CallerRef:        testCallerRefFn,
-		WaitTimeout:      time.Second,
 		Cfg:              s.cfg,
 	}
 
@@ -162,7 +163,6 @@ func (s *DistributionRepositoryTestSuite) TestARNByGroup_DistributionDoesNotExis
 		OACRepo:          s.oacRepo,
 		TaggingClient:    s.taggingClient,
 		CallerRef:        testCallerRefFn,
-		WaitTimeout:      time.Second,
 		Cfg:              s.cfg,
 	}
 
@@ -191,7 +191,6 @@ func (s *DistributionRepositoryTestSuite) TestARNByGroup_MoreThanOneCloudFrontEx
 		OACRepo:          s.oacRepo,
 		TaggingClient:    s.taggingClient,
 		CallerRef:        testCallerRefFn,
-		WaitTimeout:      time.Second,
 		Cfg:              s.cfg,
 	}
 
@@ -200,6 +199,217 @@ func (s *DistributionRepositoryTestSuite) TestARNByGroup_MoreThanOneCloudFrontEx
 	s.Equal("", arn)
 }
 
+func (s *DistributionRepositoryTestSuite) TestAdopt_TagsUnownedDistribution() {
+	arn := "arn:aws:cloudfront::000000000000:distribution/AAAAAAAAAAAAAA"
+	s.cfClient.ExpectedGetDistributionOutput = &awscloudfront.GetDistributionOutput{
+		Distribution: &awscloudfront.Distribution{
+			ARN:                aws.String(arn),
+			DistributionConfig: &awscloudfront.DistributionConfig{},
+		},
+	}
+	s.cfClient.ExpectedListTagsForResourceOutput = &awscloudfront.ListTagsForResourceOutput{
+		Tags: &awscloudfront.Tags{Items: []*awscloudfront.Tag{{Key: aws.String("team"), Value: aws.String("foo")}}},
+	}
+
+	var noError error
+	s.cfClient.On("GetDistribution", mock.Anything).Return(noError)
+	s.cfClient.On("ListTagsForResource", mock.Anything).Return(noError)
+	expectedTagInput := &awscloudfront.TagResourceInput{
+		Resource: aws.String(arn),
+		Tags: &awscloudfront.Tags{
+			Items: []*awscloudfront.Tag{
+				{Key: aws.String(ownershipTagKey), Value: aws.String(ownershipTagValue)},
+				{Key: aws.String(groupTagKey), Value: aws.String("group")},
+				{Key: aws.String(controllerIDTagKey), Value: aws.String("cluster-a")},
+			},
+		},
+	}
+	s.cfClient.On("TagResource", expectedTagInput).Return(noError)
+
+	s.cfg.ControllerID = "cluster-a"
+	repo := DistRepository{CloudFrontClient: s.cfClient, Cfg: s.cfg}
+
+	got, err := repo.Adopt("AAAAAAAAAAAAAA", "group", false)
+	s.NoError(err)
+	s.Equal(arn, got)
+	s.cfClient.AssertExpectations(s.T())
+}
+
+func (s *DistributionRepositoryTestSuite) TestAdopt_DryRunDoesNotTag() {
+	arn := "arn:aws:cloudfront::000000000000:distribution/AAAAAAAAAAAAAA"
+	s.cfClient.ExpectedGetDistributionOutput = &awscloudfront.GetDistributionOutput{
+		Distribution: &awscloudfront.Distribution{
+			ARN:                aws.String(arn),
+			DistributionConfig: &awscloudfront.DistributionConfig{},
+		},
+	}
+
+	var noError error
+	s.cfClient.On("GetDistribution", mock.Anything).Return(noError)
+	s.cfClient.On("ListTagsForResource", mock.Anything).Return(noError)
+
+	repo := DistRepository{CloudFrontClient: s.cfClient, Cfg: s.cfg}
+
+	got, err := repo.Adopt("AAAAAAAAAAAAAA", "group", true)
+	s.NoError(err)
+	s.Equal(arn, got)
+	s.cfClient.AssertNotCalled(s.T(), "TagResource", mock.Anything)
+}
+
+func (s *DistributionRepositoryTestSuite) TestAdopt_DistributionOwnedByAnotherGroup() {
+	s.cfClient.ExpectedGetDistributionOutput = &awscloudfront.GetDistributionOutput{
+		Distribution: &awscloudfront.Distribution{
+			ARN:                aws.String("arn:aws:cloudfront::000000000000:distribution/AAAAAAAAAAAAAA"),
+			DistributionConfig: &awscloudfront.DistributionConfig{},
+		},
+	}
+	s.cfClient.ExpectedListTagsForResourceOutput = &awscloudfront.ListTagsForResourceOutput{
+		Tags: &awscloudfront.Tags{
+			Items: []*awscloudfront.Tag{
+				{Key: aws.String(ownershipTagKey), Value: aws.String(ownershipTagValue)},
+				{Key: aws.String(groupTagKey), Value: aws.String("other-group")},
+			},
+		},
+	}
+
+	var noError error
+	s.cfClient.On("GetDistribution", mock.Anything).Return(noError)
+	s.cfClient.On("ListTagsForResource", mock.Anything).Return(noError)
+
+	repo := DistRepository{CloudFrontClient: s.cfClient, Cfg: s.cfg}
+
+	got, err := repo.Adopt("AAAAAAAAAAAAAA", "group", false)
+	s.ErrorContains(err, "already owned by group other-group")
+	s.Equal("", got)
+	s.cfClient.AssertNotCalled(s.T(), "TagResource", mock.Anything)
+}
+
+func (s *DistributionRepositoryTestSuite) TestAdopt_StagingDistribution() {
+	s.cfClient.ExpectedGetDistributionOutput = &awscloudfront.GetDistributionOutput{
+		Distribution: &awscloudfront.Distribution{
+			ARN:                aws.String("arn:aws:cloudfront::000000000000:distribution/AAAAAAAAAAAAAA"),
+			DistributionConfig: &awscloudfront.DistributionConfig{Staging: aws.Bool(true)},
+		},
+	}
+
+	var noError error
+	s.cfClient.On("GetDistribution", mock.Anything).Return(noError)
+
+	repo := DistRepository{CloudFrontClient: s.cfClient, Cfg: s.cfg}
+
+	_, err := repo.Adopt("AAAAAAAAAAAAAA", "group", false)
+	s.Error(err)
+}
+
+func (s *DistributionRepositoryTestSuite) TestAdopt_DistributionDoesNotExist() {
+	s.cfClient.On("GetDistribution", mock.Anything).Return(errors.New("mock err"))
+
+	repo := DistRepository{CloudFrontClient: s.cfClient, Cfg: s.cfg}
+
+	_, err := repo.Adopt("AAAAAAAAAAAAAA", "group", false)
+	s.Error(err)
+}
+
+func (s *DistributionRepositoryTestSuite) TestRelease_RemovesOwnershipTags() {
+	arn := "arn:aws:cloudfront::000000000000:distribution/AAAAAAAAAAAAAA"
+	expectedInput := &awscloudfront.UntagResourceInput{
+		Resource: aws.String(arn),
+		TagKeys:  &awscloudfront.TagKeys{Items: aws.StringSlice([]string{ownershipTagKey, groupTagKey, controllerIDTagKey})},
+	}
+	var noError error
+	s.cfClient.On("UntagResource", expectedInput).Return(noError)
+
+	repo := DistRepository{CloudFrontClient: s.cfClient, Cfg: s.cfg}
+
+	s.NoError(repo.Release(arn))
+	s.cfClient.AssertExpectations(s.T())
+}
+
+func (s *DistributionRepositoryTestSuite) TestRelease_FailsToUntag() {
+	s.cfClient.On("UntagResource", mock.Anything).Return(errors.New("mock err"))
+
+	repo := DistRepository{CloudFrontClient: s.cfClient, Cfg: s.cfg}
+
+	s.Error(repo.Release("arn:aws:cloudfront::000000000000:distribution/AAAAAAAAAAAAAA"))
+}
+
+func (s *DistributionRepositoryTestSuite) TestListOwned_ReturnsGroupOfEachDistribution() {
+	s.taggingClient.ExpectedGetResourcesOutput = &resourcegroupstaggingapi.GetResourcesOutput{
+		ResourceTagMappingList: []*resourcegroupstaggingapi.ResourceTagMapping{
+			{
+				ResourceARN: aws.String("arn:aws:cloudfront::000000000000:distribution/AAAAAAAAAAAAAA"),
+				Tags: []*resourcegroupstaggingapi.Tag{
+					{Key: aws.String(ownershipTagKey), Value: aws.String(ownershipTagValue)},
+					{Key: aws.String(groupTagKey), Value: aws.String("group")},
+				},
+			},
+		},
+	}
+	var noError error
+	expectedInput := &resourcegroupstaggingapi.GetResourcesInput{
+		ResourceTypeFilters: aws.StringSlice([]string{"cloudfront:distribution"}),
+		TagFilters: []*resourcegroupstaggingapi.TagFilter{
+			{Key: aws.String(ownershipTagKey), Values: aws.StringSlice([]string{ownershipTagValue})},
+			{Key: aws.String(controllerIDTagKey), Values: aws.StringSlice([]string{"cluster-a"})},
+		},
+	}
+	s.taggingClient.On("GetResources", expectedInput).Return(noError).Once()
+
+	s.cfg.ControllerID = "cluster-a"
+	repo := DistRepository{TaggingClient: s.taggingClient, Cfg: s.cfg}
+
+	got, err := repo.ListOwned()
+	s.NoError(err)
+	s.Equal([]Distribution{{ID: "AAAAAAAAAAAAAA", ARN: "arn:aws:cloudfront::000000000000:distribution/AAAAAAAAAAAAAA", Group: "group"}}, got)
+}
+
+func (s *DistributionRepositoryTestSuite) TestListOwned_ErrorGettingResources() {
+	s.taggingClient.On("GetResources", mock.Anything).Return(errors.New("mock err"))
+
+	repo := DistRepository{TaggingClient: s.taggingClient, Cfg: s.cfg}
+
+	_, err := repo.ListOwned()
+	s.Error(err)
+}
+
+func (s *DistributionRepositoryTestSuite) TestOACIDsInUse() {
+	s.cfClient.ExpectedListDistributionsPages = []*awscloudfront.ListDistributionsOutput{
+		{
+			DistributionList: &awscloudfront.DistributionList{
+				Items: []*awscloudfront.DistributionSummary{
+					{
+						Origins: &awscloudfront.Origins{
+							Items: []*awscloudfront.Origin{
+								{Id: aws.String("public")},
+								{Id: aws.String("bucket"), OriginAccessControlId: aws.String("oac-1")},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			DistributionList: &awscloudfront.DistributionList{
+				Items: []*awscloudfront.DistributionSummary{
+					{
+						Origins: &awscloudfront.Origins{
+							Items: []*awscloudfront.Origin{{Id: aws.String("bucket"), OriginAccessControlId: aws.String("oac-2")}},
+						},
+					},
+				},
+			},
+		},
+	}
+	var noError error
+	s.cfClient.On("ListDistributionsPages", mock.Anything).Return(noError)
+
+	repo := DistRepository{CloudFrontClient: s.cfClient, Cfg: s.cfg}
+
+	got, err := repo.OACIDsInUse()
+	s.NoError(err)
+	s.Equal([]string{"oac-1", "oac-2"}, got)
+}
+
 func (s *DistributionRepositoryTestSuite) TestCreate_Success() {
 	s.cfClient.ExpectedCreateDistributionWithTagsOutput = &awscloudfront.CreateDistributionWithTagsOutput{
 		Distribution: &awscloudfront.Distribution{
@@ -231,7 +441,6 @@ func (s *DistributionRepositoryTestSuite) TestCreate_Success() {
 		OACRepo:                   s.oacRepo,
 		TaggingClient:             s.taggingClient,
 		CallerRef:                 testCallerRefFn,
-		WaitTimeout:               time.Second,
 		RunPostCreationOperations: noOpPostCreationFunc,
 		Cfg:                       s.cfg,
 	}
@@ -265,7 +474,6 @@ func (s *DistributionRepositoryTestSuite) TestCreate_ErrorWhenCreatingDistributi
 		OACRepo:          s.oacRepo,
 		TaggingClient:    s.taggingClient,
 		CallerRef:        testCallerRefFn,
-		WaitTimeout:      time.Second,
 		Cfg:              s.cfg,
 	}
 	dist, err := repo.Create(distribution)
@@ -281,7 +489,6 @@ func (s *DistributionRepositoryTestSuite) TestSync_CantFetchDistribution() {
 		OACRepo:          s.oacRepo,
 		TaggingClient:    s.taggingClient,
 		CallerRef:        testCallerRefFn,
-		WaitTimeout:      time.Second,
 		Cfg:              s.cfg,
 	}
 	gotDist, err := repo.Sync(Distribution{})
@@ -312,7 +519,6 @@ func (s *DistributionRepositoryTestSuite) TestSync_CantUpdateDistribution() {
 		OACRepo:          s.oacRepo,
 		TaggingClient:    s.taggingClient,
 		CallerRef:        testCallerRefFn,
-		WaitTimeout:      time.Second,
 		Cfg:              s.cfg,
 	}
 	gotDist, err := repo.Sync(Distribution{})
@@ -337,6 +543,7 @@ func (s *DistributionRepositoryTestSuite) TestSync_CantSaveTags() {
 	var noError error
 	s.cfClient.On("GetDistributionConfig", mock.Anything).Return(noError).Once()
 	s.cfClient.On("UpdateDistribution", mock.Anything).Return(noError).Once()
+	s.cfClient.On("ListTagsForResource", mock.Anything).Return(noError).Once()
 	s.cfClient.On("TagResource", mock.Anything).Return(errors.New("mock err")).Once()
 
 	repo := DistRepository{
@@ -344,10 +551,9 @@ func (s *DistributionRepositoryTestSuite) TestSync_CantSaveTags() {
 		OACRepo:          s.oacRepo,
 		TaggingClient:    s.taggingClient,
 		CallerRef:        testCallerRefFn,
-		WaitTimeout:      time.Second,
 		Cfg:              s.cfg,
 	}
-	gotDist, err := repo.Sync(Distribution{})
+	gotDist, err := repo.Sync(Distribution{Tags: map[string]string{"foo": "bar"}})
 	s.Error(err)
 	s.Equal(Distribution{}, gotDist)
 }
@@ -375,6 +581,7 @@ func (s *DistributionRepositoryTestSuite) TestSync_OriginDoesNotExistYet() {
 	var noError error
 	s.cfClient.On("GetDistributionConfig", mock.Anything).Return(noError).Once()
 	s.cfClient.On("UpdateDistribution", mock.Anything).Return(noError).Once()
+	s.cfClient.On("ListTagsForResource", mock.Anything).Return(noError).Once()
 	s.cfClient.On("TagResource", mock.Anything).Return(noError).Once()
 
 	distribution := Distribution{
@@ -396,7 +603,6 @@ func (s *DistributionRepositoryTestSuite) TestSync_OriginDoesNotExistYet() {
 		OACRepo:          s.oacRepo,
 		TaggingClient:    s.taggingClient,
 		CallerRef:        testCallerRefFn,
-		WaitTimeout:      time.Second,
 		Cfg:              s.cfg,
 	}
 	_, err := repo.Sync(distribution)
@@ -427,6 +633,7 @@ func (s *DistributionRepositoryTestSuite) TestSync_OriginAlreadyExists() {
 	var noError error
 	s.cfClient.On("GetDistributionConfig", mock.Anything).Return(noError).Once()
 	s.cfClient.On("UpdateDistribution", mock.Anything).Return(noError).Once()
+	s.cfClient.On("ListTagsForResource", mock.Anything).Return(noError).Once()
 	s.cfClient.On("TagResource", mock.Anything).Return(noError).Once()
 
 	distribution := Distribution{
@@ -448,13 +655,132 @@ func (s *DistributionRepositoryTestSuite) TestSync_OriginAlreadyExists() {
 		OACRepo:          s.oacRepo,
 		TaggingClient:    s.taggingClient,
 		CallerRef:        testCallerRefFn,
-		WaitTimeout:      time.Second,
 		Cfg:              s.cfg,
 	}
 	_, err := repo.Sync(distribution)
 	s.NoError(err)
 }
 
+func (s *DistributionRepositoryTestSuite) TestSync_NothingChangedShouldNotUpdate() {
+	distribution := Distribution{
+		ID:  "id",
+		ARN: "arn",
+		DefaultOrigin: Origin{
+			Host:            "default.origin",
+			ResponseTimeout: 30,
+		},
+		CustomOrigins: []Origin{
+			{
+				Host:            "origin",
+				ResponseTimeout: 30,
+			},
+		},
+		Tags: map[string]string{"foo": "bar"},
+	}
+
+	observedConfig := newAWSDistributionConfig(distribution, testCallerRefFn, s.cfg)
+	observedConfig.SetDefaultRootObject("/")
+	// CloudFront doesn't guarantee the order of origins
+	items := observedConfig.Origins.Items
+	items[0], items[1] = items[1], items[0]
+
+	s.cfClient.ExpectedGetDistributionConfigOutput = &awscloudfront.GetDistributionConfigOutput{
+		ETag:               aws.String(""),
+		DistributionConfig: observedConfig,
+	}
+	s.cfClient.ExpectedListTagsForResourceOutput = &awscloudfront.ListTagsForResourceOutput{
+		Tags: &awscloudfront.Tags{Items: []*awscloudfront.Tag{
+			{Key: aws.String("foo"), Value: aws.String("bar")},
+			{Key: aws.String("unmanaged"), Value: aws.String("tag")},
+		}},
+	}
+	s.cfClient.ExpectedGetDistributionOutput = &awscloudfront.GetDistributionOutput{
+		Distribution: &awscloudfront.Distribution{
+			Id: aws.String("id"), ARN: aws.String("arn"), DomainName: aws.String("domain"), Status: aws.String("InProgress"),
+		},
+	}
+
+	var noError error
+	s.cfClient.On("GetDistributionConfig", mock.Anything).Return(noError).Once()
+	s.cfClient.On("ListTagsForResource", mock.Anything).Return(noError).Once()
+	s.cfClient.On("GetDistribution", mock.Anything).Return(noError).Once()
+
+	repo := DistRepository{
+		CloudFrontClient: s.cfClient,
+		OACRepo:          s.oacRepo,
+		TaggingClient:    s.taggingClient,
+		CallerRef:        testCallerRefFn,
+		Cfg:              s.cfg,
+	}
+	synced, err := repo.Sync(distribution)
+	s.NoError(err)
+	s.Equal("domain", synced.Address)
+	s.Equal("InProgress", synced.DeploymentStatus)
+	s.cfClient.AssertNotCalled(s.T(), "UpdateDistribution", mock.Anything)
+	s.cfClient.AssertNotCalled(s.T(), "TagResource", mock.Anything)
+}
+
+func (s *DistributionRepositoryTestSuite) Test_hasAllTags() {
+	observed := &awscloudfront.Tags{Items: []*awscloudfront.Tag{
+		{Key: aws.String("foo"), Value: aws.String("bar")},
+		{Key: aws.String("baz"), Value: aws.String("qux")},
+	}}
+
+	s.True(hasAllTags(observed, map[string]string{"foo": "bar"}))
+	s.True(hasAllTags(observed, nil))
+	s.False(hasAllTags(observed, map[string]string{"foo": "other"}))
+	s.False(hasAllTags(observed, map[string]string{"missing": "tag"}))
+	s.False(hasAllTags(nil, map[string]string{"foo": "bar"}))
+}
+
+func (s *DistributionRepositoryTestSuite) TestSync_DryRunPlansChangesWithoutApplyingThem() {
+	someIncorrectOrigin := &awscloudfront.Origin{Id: aws.String("origin"), DomainName: aws.String("incorrect domain name")}
+
+	s.cfClient.ExpectedGetDistributionConfigOutput = &awscloudfront.GetDistributionConfigOutput{
+		ETag: aws.String(""),
+		DistributionConfig: &awscloudfront.DistributionConfig{
+			Origins:              &awscloudfront.Origins{Items: []*awscloudfront.Origin{defaultOrigin, someIncorrectOrigin}, Quantity: aws.Int64(2)},
+			CacheBehaviors:       &awscloudfront.CacheBehaviors{Quantity: aws.Int64(0)},
+			CallerReference:      aws.String(testCallerRefFn()),
+			DefaultRootObject:    aws.String("/"),
+			CustomErrorResponses: &awscloudfront.CustomErrorResponses{},
+			Restrictions:         &awscloudfront.Restrictions{},
+		},
+	}
+
+	var noError error
+	s.cfClient.On("GetDistributionConfig", mock.Anything).Return(noError).Once()
+
+	distribution := Distribution{
+		ID: "id",
+		DefaultOrigin: Origin{
+			Host:            "default.origin",
+			ResponseTimeout: 30,
+		},
+		CustomOrigins: []Origin{
+			{
+				Host:            "origin",
+				ResponseTimeout: 30,
+			},
+		},
+		DryRun: true,
+	}
+
+	repo := DistRepository{
+		CloudFrontClient: s.cfClient,
+		OACRepo:          s.oacRepo,
+		TaggingClient:    s.taggingClient,
+		CallerRef:        testCallerRefFn,
+		Cfg:              s.cfg,
+	}
+	planned, err := repo.Sync(distribution)
+	s.NoError(err)
+	s.Contains(planned.PlannedChanges, `Origins.Items[Id="origin"].DomainName: "incorrect domain name" -> "origin"`)
+	s.cfClient.AssertNotCalled(s.T(), "UpdateDistribution", mock.Anything)
+	s.cfClient.AssertNotCalled(s.T(), "TagResource", mock.Anything)
+	s.oacRepo.AssertNotCalled(s.T(), "Sync", mock.Anything)
+}
+
 func (s *DistributionRepositoryTestSuite) TestSync_BehaviorDoesNotExistYet() {
 
 	lowerPrecedenceExistingBehavior := &awscloudfront.CacheBehavior{
@@ -525,6 +851,7 @@ func (s *DistributionRepositoryTestSuite) TestSync_BehaviorDoesNotExistYet() {
 
 	s.cfClient.On("GetDistributionConfig", mock.Anything).Return(noError).Once()
 	s.cfClient.On("UpdateDistribution", mock.Anything).Return(noError).Once()
+	s.cfClient.On("ListTagsForResource", mock.Anything).Return(noError).Once()
 	s.cfClient.On("TagResource", mock.Anything).Return(noError).Once()
 
 	distribution := Distribution{
@@ -551,7 +878,6 @@ func (s *DistributionRepositoryTestSuite) TestSync_BehaviorDoesNotExistYet() {
 		OACRepo:          s.oacRepo,
 		TaggingClient:    s.taggingClient,
 		CallerRef:        testCallerRefFn,
-		WaitTimeout:      time.Second,
 		Cfg:              s.cfg,
 	}
 	_, err := repo.Sync(distribution)
@@ -605,6 +931,7 @@ func (s *DistributionRepositoryTestSuite) TestSync_BehaviorAlreadyExists() {
 	var noError error
 	s.cfClient.On("GetDistributionConfig", mock.Anything).Return(noError).Once()
 	s.cfClient.On("UpdateDistribution", mock.Anything).Return(noError).Once()
+	s.cfClient.On("ListTagsForResource", mock.Anything).Return(noError).Once()
 	s.cfClient.On("TagResource", mock.Anything).Return(noError).Once()
 
 	s.oacRepo.On("Sync", mock.Anything).Return(noError)
@@ -614,7 +941,6 @@ func (s *DistributionRepositoryTestSuite) TestSync_BehaviorAlreadyExists() {
 		OACRepo:          s.oacRepo,
 		TaggingClient:    s.taggingClient,
 		CallerRef:        testCallerRefFn,
-		WaitTimeout:      time.Second,
 		Cfg:              s.cfg,
 	}
 
@@ -639,8 +965,8 @@ func (s *DistributionRepositoryTestSuite) TestSync_BehaviorAlreadyExists() {
 func (s *DistributionRepositoryTestSuite) TestUpdate_ShouldSyncOneOACAndDeleteOneOAC() {
 	origins := &awscloudfront.Origins{
 		Items: []*awscloudfront.Origin{
-			{OriginAccessControlId: aws.String("some oac"), Id: aws.String("host")},
-			{OriginAccessControlId: aws.String("another oac"), Id: aws.String(" some other host")},
+			{OriginAccessControlId: aws.String("some oac"), Id: aws.String("host"), DomainName: aws.String("host")},
+			{OriginAccessControlId: aws.String("another oac"), Id: aws.String(" some other host"), DomainName: aws.String(" some other host")},
 		},
 	}
 	distConfig := &awscloudfront.DistributionConfig{
@@ -664,6 +990,7 @@ func (s *DistributionRepositoryTestSuite) TestUpdate_ShouldSyncOneOACAndDeleteOn
 	var noError error
 	s.cfClient.On("GetDistributionConfig", mock.Anything).Return(noError).Once()
 	s.cfClient.On("UpdateDistribution", mock.Anything).Return(noError).Once()
+	s.cfClient.On("ListTagsForResource", mock.Anything).Return(noError).Once()
 	s.cfClient.On("TagResource", mock.Anything).Return(noError).Once()
 
 	s.oacRepo.On("Delete", mock.Anything).Return(noError).Once()
@@ -674,7 +1001,6 @@ func (s *DistributionRepositoryTestSuite) TestUpdate_ShouldSyncOneOACAndDeleteOn
 		OACRepo:          s.oacRepo,
 		TaggingClient:    s.taggingClient,
 		CallerRef:        testCallerRefFn,
-		WaitTimeout:      time.Second,
 		Cfg:              s.cfg,
 	}
 	_, err := repo.Sync(Distribution{
@@ -688,369 +1014,206 @@ func (s *DistributionRepositoryTestSuite) TestUpdate_ShouldSyncOneOACAndDeleteOn
 	s.NoError(err)
 }
 
-func (s *DistributionRepositoryTestSuite) TestDelete_SuccessWithPublicOrigins() {
-	enabledDistConfig := &awscloudfront.DistributionConfig{Enabled: aws.Bool(true)}
-	disabledDistConfig := &awscloudfront.DistributionConfig{Enabled: aws.Bool(false)}
-	s.cfClient.ExpectedGetDistributionConfigOutput = &awscloudfront.GetDistributionConfigOutput{
-		ETag:               aws.String("etag1"),
-		DistributionConfig: enabledDistConfig,
-	}
-	s.cfClient.ExpectedUpdateDistributionOutput = &awscloudfront.UpdateDistributionOutput{
-		ETag:         aws.String("etag2"),
-		Distribution: &awscloudfront.Distribution{DistributionConfig: disabledDistConfig},
-	}
-
-	s.cfClient.ExpectedGetDistributionOutput = &awscloudfront.GetDistributionOutput{
-		ETag: aws.String("etag2"),
-		Distribution: &awscloudfront.Distribution{
-			DistributionConfig: disabledDistConfig,
-			Status:             aws.String("Deployed"),
-		},
-	}
-
-	var noError error
-	s.cfClient.On("GetDistributionConfig", mock.Anything).Return(noError).Once()
-	s.cfClient.On("UpdateDistribution", mock.Anything).Return(noError).Once()
-	s.cfClient.On("GetDistribution", mock.Anything).Return(noError).Once()
-	s.cfClient.On("DeleteDistribution", mock.Anything).Return(noError).Once()
-
-	repo := DistRepository{
-		CloudFrontClient: s.cfClient,
-		OACRepo:          s.oacRepo,
-		TaggingClient:    s.taggingClient,
-		CallerRef:        testCallerRefFn,
-		WaitTimeout:      time.Second,
-		Cfg:              s.cfg,
-	}
-	s.NoError(repo.Delete(Distribution{ID: "id"}))
-}
-
-func (s *DistributionRepositoryTestSuite) TestDelete_SuccessWithS3Origins() {
+func (s *DistributionRepositoryTestSuite) TestDisable_DisablesEnabledDistribution() {
 	origins := &awscloudfront.Origins{
 		Items: []*awscloudfront.Origin{
+			{Id: aws.String("public")},
 			{OriginAccessControlId: aws.String("some oac")},
 			{OriginAccessControlId: aws.String("another oac")},
 		},
 	}
-	enabledDistConfig := &awscloudfront.DistributionConfig{Enabled: aws.Bool(true), Origins: origins}
-	disabledDistConfig := &awscloudfront.DistributionConfig{Enabled: aws.Bool(false), Origins: origins}
-
 	s.cfClient.ExpectedGetDistributionConfigOutput = &awscloudfront.GetDistributionConfigOutput{
 		ETag:               aws.String("etag1"),
-		DistributionConfig: enabledDistConfig,
-	}
-	s.cfClient.ExpectedUpdateDistributionOutput = &awscloudfront.UpdateDistributionOutput{
-		ETag:         aws.String("etag2"),
-		Distribution: &awscloudfront.Distribution{DistributionConfig: disabledDistConfig},
+		DistributionConfig: &awscloudfront.DistributionConfig{Enabled: aws.Bool(true), Origins: origins},
 	}
 
-	s.cfClient.ExpectedGetDistributionOutput = &awscloudfront.GetDistributionOutput{
-		ETag: aws.String("etag2"),
-		Distribution: &awscloudfront.Distribution{
-			DistributionConfig: disabledDistConfig,
-			Status:             aws.String("Deployed"),
-		},
+	expectedUpdateDistributionInput := &awscloudfront.UpdateDistributionInput{
+		DistributionConfig: &awscloudfront.DistributionConfig{Enabled: aws.Bool(false), Origins: origins},
+		Id:                 aws.String("id"),
+		IfMatch:            aws.String("etag1"),
 	}
 
 	var noError error
-	s.cfClient.On("GetDistributionConfig", mock.Anything).Return(noError).Once()
-	s.cfClient.On("UpdateDistribution", mock.Anything).Return(noError).Once()
-	s.cfClient.On("GetDistribution", mock.Anything).Return(noError).Once()
-	s.cfClient.On("DeleteDistribution", mock.Anything).Return(noError).Once()
+	s.cfClient.On("GetDistributionConfig", &awscloudfront.GetDistributionConfigInput{Id: aws.String("id")}).Return(noError).Once()
+	s.cfClient.On("UpdateDistribution", expectedUpdateDistributionInput).Return(noError).Once()
 
-	s.oacRepo.On("Delete", mock.Anything).Return(noError).Twice()
-
-	repo := DistRepository{
-		CloudFrontClient: s.cfClient,
-		OACRepo:          s.oacRepo,
-		TaggingClient:    s.taggingClient,
-		CallerRef:        testCallerRefFn,
-		WaitTimeout:      time.Second,
-		Cfg:              s.cfg,
-	}
-	s.NoError(repo.Delete(Distribution{ID: "id"}))
+	repo := DistRepository{CloudFrontClient: s.cfClient, OACRepo: s.oacRepo, Cfg: s.cfg}
+	oacIDs, err := repo.Disable("id")
+	s.NoError(err)
+	s.Equal([]string{"some oac", "another oac"}, oacIDs)
+	s.cfClient.AssertExpectations(s.T())
 }
 
-func (s *DistributionRepositoryTestSuite) TestDelete_FailsToDeleteOACs() {
-	origins := &awscloudfront.Origins{
-		Items: []*awscloudfront.Origin{
-			{OriginAccessControlId: aws.String("some oac")},
-			{OriginAccessControlId: aws.String("another oac")},
-		},
-	}
-	enabledDistConfig := &awscloudfront.DistributionConfig{Enabled: aws.Bool(true), Origins: origins}
-	disabledDistConfig := &awscloudfront.DistributionConfig{Enabled: aws.Bool(false), Origins: origins}
-
+func (s *DistributionRepositoryTestSuite) TestDisable_AlreadyDisabled() {
 	s.cfClient.ExpectedGetDistributionConfigOutput = &awscloudfront.GetDistributionConfigOutput{
 		ETag:               aws.String("etag1"),
-		DistributionConfig: enabledDistConfig,
-	}
-	s.cfClient.ExpectedUpdateDistributionOutput = &awscloudfront.UpdateDistributionOutput{
-		ETag:         aws.String("etag2"),
-		Distribution: &awscloudfront.Distribution{DistributionConfig: disabledDistConfig},
-	}
-
-	s.cfClient.ExpectedGetDistributionOutput = &awscloudfront.GetDistributionOutput{
-		ETag: aws.String("etag2"),
-		Distribution: &awscloudfront.Distribution{
-			DistributionConfig: disabledDistConfig,
-			Status:             aws.String("Deployed"),
-		},
+		DistributionConfig: &awscloudfront.DistributionConfig{Enabled: aws.Bool(false)},
 	}
 
 	var noError error
 	s.cfClient.On("GetDistributionConfig", mock.Anything).Return(noError).Once()
-	s.cfClient.On("UpdateDistribution", mock.Anything).Return(noError).Once()
-	s.cfClient.On("GetDistribution", mock.Anything).Return(noError).Once()
-	s.cfClient.On("DeleteDistribution", mock.Anything).Return(noError).Once()
 
-	s.oacRepo.On("Delete", mock.Anything).Return(errors.New("some err")).Once()
+	repo := DistRepository{CloudFrontClient: s.cfClient, OACRepo: s.oacRepo, Cfg: s.cfg}
+	oacIDs, err := repo.Disable("id")
+	s.NoError(err)
+	s.Empty(oacIDs)
+	s.cfClient.AssertNotCalled(s.T(), "UpdateDistribution", mock.Anything)
+}
 
-	repo := DistRepository{
-		CloudFrontClient: s.cfClient,
-		OACRepo:          s.oacRepo,
-		TaggingClient:    s.taggingClient,
-		CallerRef:        testCallerRefFn,
-		WaitTimeout:      time.Second,
-		Cfg:              s.cfg,
-	}
-	s.Error(repo.Delete(Distribution{ID: "id"}))
+func (s *DistributionRepositoryTestSuite) TestDisable_FailsToGetDistributionConfig() {
+	s.cfClient.On("GetDistributionConfig", mock.Anything).Return(errors.New("mock err")).Once()
+
+	repo := DistRepository{CloudFrontClient: s.cfClient, OACRepo: s.oacRepo, Cfg: s.cfg}
+	_, err := repo.Disable("id")
+	s.Error(err)
+	s.NotErrorIs(err, ErrDistNotFound)
 }
 
-func (s *DistributionRepositoryTestSuite) TestDelete_FailsToGetDistributionConfig() {
-	expectedGetDistributionConfigInput := &awscloudfront.GetDistributionConfigInput{
-		Id: aws.String("id"),
-	}
-	s.cfClient.On("GetDistributionConfig", expectedGetDistributionConfigInput).Return(errors.New("mock err")).Once()
+func (s *DistributionRepositoryTestSuite) TestDisable_NoSuchDistributionGettingConfig() {
+	awsErr := awserr.New(awscloudfront.ErrCodeNoSuchDistribution, "msg", nil)
+	s.cfClient.On("GetDistributionConfig", mock.Anything).Return(awsErr).Once()
 
-	repo := DistRepository{
-		CloudFrontClient: s.cfClient,
-		OACRepo:          s.oacRepo,
-		TaggingClient:    s.taggingClient,
-		CallerRef:        testCallerRefFn,
-		WaitTimeout:      time.Second,
-		Cfg:              s.cfg,
-	}
-	s.Error(repo.Delete(Distribution{ID: "id"}))
+	repo := DistRepository{CloudFrontClient: s.cfClient, OACRepo: s.oacRepo, Cfg: s.cfg}
+	_, err := repo.Disable("id")
+	s.ErrorIs(err, ErrDistNotFound)
 }
 
-func (s *DistributionRepositoryTestSuite) TestDelete_FailsToDisableDistribution() {
-	enabledDistConfig := &awscloudfront.DistributionConfig{Enabled: aws.Bool(true)}
-	disabledDistConfig := &awscloudfront.DistributionConfig{Enabled: aws.Bool(false)}
+func (s *DistributionRepositoryTestSuite) TestDisable_FailsToDisableDistribution() {
 	s.cfClient.ExpectedGetDistributionConfigOutput = &awscloudfront.GetDistributionConfigOutput{
 		ETag:               aws.String("etag1"),
-		DistributionConfig: enabledDistConfig,
-	}
-
-	expectedGetDistributionConfigInput := &awscloudfront.GetDistributionConfigInput{
-		Id: aws.String("id"),
-	}
-	expectedUpdateDistributionInput := &awscloudfront.UpdateDistributionInput{
-		DistributionConfig: disabledDistConfig,
-		Id:                 aws.String("id"),
-		IfMatch:            aws.String("etag1"),
+		DistributionConfig: &awscloudfront.DistributionConfig{Enabled: aws.Bool(true)},
 	}
 
 	var noError error
-	s.cfClient.On("GetDistributionConfig", expectedGetDistributionConfigInput).Return(noError).Once()
-	s.cfClient.On("UpdateDistribution", expectedUpdateDistributionInput).Return(errors.New("mock err")).Once()
+	s.cfClient.On("GetDistributionConfig", mock.Anything).Return(noError).Once()
+	s.cfClient.On("UpdateDistribution", mock.Anything).Return(errors.New("mock err")).Once()
 
-	repo := DistRepository{
-		CloudFrontClient: s.cfClient,
-		OACRepo:          s.oacRepo,
-		TaggingClient:    s.taggingClient,
-		CallerRef:        testCallerRefFn,
-		WaitTimeout:      time.Second,
-		Cfg:              s.cfg,
-	}
-	s.Error(repo.Delete(Distribution{ID: "id"}))
+	repo := DistRepository{CloudFrontClient: s.cfClient, OACRepo: s.oacRepo, Cfg: s.cfg}
+	_, err := repo.Disable("id")
+	s.Error(err)
+	s.NotErrorIs(err, ErrDistNotFound)
 }
 
-func (s *DistributionRepositoryTestSuite) TestDelete_TimesOutWaitingDistributionDeployment() {
-	enabledDistConfig := &awscloudfront.DistributionConfig{Enabled: aws.Bool(true)}
-	disabledDistConfig := &awscloudfront.DistributionConfig{Enabled: aws.Bool(false)}
+func (s *DistributionRepositoryTestSuite) TestDisable_NoSuchDistributionDisablingDist() {
 	s.cfClient.ExpectedGetDistributionConfigOutput = &awscloudfront.GetDistributionConfigOutput{
 		ETag:               aws.String("etag1"),
-		DistributionConfig: enabledDistConfig,
-	}
-	s.cfClient.ExpectedUpdateDistributionOutput = &awscloudfront.UpdateDistributionOutput{
-		ETag:         aws.String("etag2"),
-		Distribution: &awscloudfront.Distribution{DistributionConfig: disabledDistConfig},
-	}
-	s.cfClient.ExpectedGetDistributionOutput = &awscloudfront.GetDistributionOutput{
-		ETag: aws.String("etag2"),
-		Distribution: &awscloudfront.Distribution{
-			DistributionConfig: disabledDistConfig,
-			Status:             aws.String("Pending"),
-		},
+		DistributionConfig: &awscloudfront.DistributionConfig{Enabled: aws.Bool(true)},
 	}
 
 	var noError error
+	awsErr := awserr.New(awscloudfront.ErrCodeNoSuchDistribution, "msg", nil)
 	s.cfClient.On("GetDistributionConfig", mock.Anything).Return(noError).Once()
-	s.cfClient.On("UpdateDistribution", mock.Anything).Return(noError).Once()
-	s.cfClient.On("GetDistribution", mock.Anything).Return(errors.New("mock err"))
+	s.cfClient.On("UpdateDistribution", mock.Anything).Return(awsErr).Once()
 
-	repo := DistRepository{
-		CloudFrontClient: s.cfClient,
-		OACRepo:          s.oacRepo,
-		TaggingClient:    s.taggingClient,
-		CallerRef:        testCallerRefFn,
-		WaitTimeout:      time.Second,
-		Cfg:              s.cfg,
-	}
-	s.ErrorIs(repo.Delete(Distribution{ID: "id"}), context.DeadlineExceeded)
+	repo := DistRepository{CloudFrontClient: s.cfClient, OACRepo: s.oacRepo, Cfg: s.cfg}
+	_, err := repo.Disable("id")
+	s.ErrorIs(err, ErrDistNotFound)
 }
 
-func (s *DistributionRepositoryTestSuite) TestDelete_FailsToDeleteDistribution() {
-	enabledDistConfig := &awscloudfront.DistributionConfig{Enabled: aws.Bool(true)}
-	disabledDistConfig := &awscloudfront.DistributionConfig{Enabled: aws.Bool(false)}
-	s.cfClient.ExpectedGetDistributionConfigOutput = &awscloudfront.GetDistributionConfigOutput{
-		ETag:               aws.String("etag1"),
-		DistributionConfig: enabledDistConfig,
+func (s *DistributionRepositoryTestSuite) TestIsDeployed() {
+	testCases := []struct {
+		status string
+		want   bool
+	}{
+		{status: "Deployed", want: true},
+		{status: "InProgress", want: false},
 	}
-	s.cfClient.ExpectedUpdateDistributionOutput = &awscloudfront.UpdateDistributionOutput{
-		ETag:         aws.String("etag2"),
-		Distribution: &awscloudfront.Distribution{DistributionConfig: disabledDistConfig},
+
+	for _, tc := range testCases {
+		cfClient := &test.MockCloudFrontAPI{}
+		cfClient.ExpectedGetDistributionOutput = &awscloudfront.GetDistributionOutput{
+			Distribution: &awscloudfront.Distribution{Status: aws.String(tc.status)},
+		}
+		var noError error
+		cfClient.On("GetDistribution", &awscloudfront.GetDistributionInput{Id: aws.String("id")}).Return(noError).Once()
+
+		repo := DistRepository{CloudFrontClient: cfClient, Cfg: s.cfg}
+		got, err := repo.IsDeployed("id")
+		s.NoError(err, tc.status)
+		s.Equal(tc.want, got, tc.status)
 	}
+}
+
+func (s *DistributionRepositoryTestSuite) TestIsDeployed_NoSuchDistribution() {
+	awsErr := awserr.New(awscloudfront.ErrCodeNoSuchDistribution, "msg", nil)
+	s.cfClient.On("GetDistribution", mock.Anything).Return(awsErr).Once()
+
+	repo := DistRepository{CloudFrontClient: s.cfClient, Cfg: s.cfg}
+	_, err := repo.IsDeployed("id")
+	s.ErrorIs(err, ErrDistNotFound)
+}
+
+func (s *DistributionRepositoryTestSuite) TestDelete_Success() {
 	s.cfClient.ExpectedGetDistributionOutput = &awscloudfront.GetDistributionOutput{
 		ETag: aws.String("etag2"),
 		Distribution: &awscloudfront.Distribution{
-			DistributionConfig: disabledDistConfig,
+			DistributionConfig: &awscloudfront.DistributionConfig{Enabled: aws.Bool(false)},
 			Status:             aws.String("Deployed"),
 		},
 	}
 
 	var noError error
-	s.cfClient.On("GetDistributionConfig", mock.Anything).Return(noError).Once()
-	s.cfClient.On("UpdateDistribution", mock.Anything).Return(noError).Once()
 	s.cfClient.On("GetDistribution", mock.Anything).Return(noError).Once()
-	s.cfClient.On("DeleteDistribution", mock.Anything).Return(errors.New("mock err")).Once()
-
-	repo := DistRepository{
-		CloudFrontClient: s.cfClient,
-		OACRepo:          s.oacRepo,
-		TaggingClient:    s.taggingClient,
-		CallerRef:        testCallerRefFn,
-		WaitTimeout:      time.Second,
-		Cfg:              s.cfg,
-	}
-	s.Error(repo.Delete(Distribution{ID: "id"}))
-}
-
-func (s *DistributionRepositoryTestSuite) TestDelete_NoSuchDistributionGettingConfig() {
-	awsErr := awserr.New(awscloudfront.ErrCodeNoSuchDistribution, "msg", nil)
-	s.cfClient.On("GetDistributionConfig", mock.Anything).Return(awsErr).Once()
+	s.cfClient.On("DeleteDistribution", &awscloudfront.DeleteDistributionInput{Id: aws.String("id"), IfMatch: aws.String("etag2")}).Return(noError).Once()
 
-	repo := DistRepository{
-		CloudFrontClient: s.cfClient,
-		OACRepo:          s.oacRepo,
-		TaggingClient:    s.taggingClient,
-		CallerRef:        testCallerRefFn,
-		WaitTimeout:      time.Second,
-		Cfg:              s.cfg,
-	}
-	s.NoError(repo.Delete(Distribution{ID: "id"}))
+	repo := DistRepository{CloudFrontClient: s.cfClient, Cfg: s.cfg}
+	s.NoError(repo.Delete("id"))
+	s.cfClient.AssertExpectations(s.T())
 }
 
-func (s *DistributionRepositoryTestSuite) TestDelete_NoSuchDistributionDisablingDist() {
-	enabledDistConfig := &awscloudfront.DistributionConfig{Enabled: aws.Bool(true)}
-	disabledDistConfig := &awscloudfront.DistributionConfig{Enabled: aws.Bool(false)}
-	s.cfClient.ExpectedGetDistributionConfigOutput = &awscloudfront.GetDistributionConfigOutput{
-		ETag:               aws.String("etag1"),
-		DistributionConfig: enabledDistConfig,
-	}
-	s.cfClient.ExpectedUpdateDistributionOutput = &awscloudfront.UpdateDistributionOutput{
+func (s *DistributionRepositoryTestSuite) TestDelete_FailsToDeleteDistribution() {
+	s.cfClient.ExpectedGetDistributionOutput = &awscloudfront.GetDistributionOutput{
 		ETag:         aws.String("etag2"),
-		Distribution: &awscloudfront.Distribution{DistributionConfig: disabledDistConfig},
+		Distribution: &awscloudfront.Distribution{Status: aws.String("Deployed")},
 	}
 
 	var noError error
-	awsErr := awserr.New(awscloudfront.ErrCodeNoSuchDistribution, "msg", nil)
-	s.cfClient.On("GetDistributionConfig", mock.Anything).Return(noError).Once()
-	s.cfClient.On("UpdateDistribution", mock.Anything).Return(awsErr).Once()
+	s.cfClient.On("GetDistribution", mock.Anything).Return(noError).Once()
+	s.cfClient.On("DeleteDistribution", mock.Anything).Return(errors.New("mock err")).Once()
 
-	repo := DistRepository{
-		CloudFrontClient: s.cfClient,
-		OACRepo:          s.oacRepo,
-		TaggingClient:    s.taggingClient,
-		CallerRef:        testCallerRefFn,
-		WaitTimeout:      time.Second,
-		Cfg:              s.cfg,
-	}
-	s.NoError(repo.Delete(Distribution{ID: "id"}))
+	repo := DistRepository{CloudFrontClient: s.cfClient, Cfg: s.cfg}
+	s.Error(repo.Delete("id"))
 }
 
-func (s *DistributionRepositoryTestSuite) TestDelete_NoSuchDistributionWaitingForItToBeDeployed() {
-	enabledDistConfig := &awscloudfront.DistributionConfig{Enabled: aws.Bool(true)}
-	disabledDistConfig := &awscloudfront.DistributionConfig{Enabled: aws.Bool(false)}
-	s.cfClient.ExpectedGetDistributionConfigOutput = &awscloudfront.GetDistributionConfigOutput{
-		ETag:               aws.String("etag1"),
-		DistributionConfig: enabledDistConfig,
-	}
-	s.cfClient.ExpectedUpdateDistributionOutput = &awscloudfront.UpdateDistributionOutput{
-		ETag:         aws.String("etag2"),
-		Distribution: &awscloudfront.Distribution{DistributionConfig: disabledDistConfig},
-	}
-	s.cfClient.ExpectedGetDistributionOutput = &awscloudfront.GetDistributionOutput{
-		ETag: aws.String("etag2"),
-		Distribution: &awscloudfront.Distribution{
-			DistributionConfig: disabledDistConfig,
-			Status:             aws.String("Deployed"),
-		},
-	}
-
-	var noError error
+func (s *DistributionRepositoryTestSuite) TestDelete_NoSuchDistributionGettingIt() {
 	awsErr := awserr.New(awscloudfront.ErrCodeNoSuchDistribution, "msg", nil)
-	s.cfClient.On("GetDistributionConfig", mock.Anything).Return(noError).Once()
-	s.cfClient.On("UpdateDistribution", mock.Anything).Return(noError).Once()
 	s.cfClient.On("GetDistribution", mock.Anything).Return(awsErr).Once()
 
-	repo := DistRepository{
-		CloudFrontClient: s.cfClient,
-		OACRepo:          s.oacRepo,
-		TaggingClient:    s.taggingClient,
-		CallerRef:        testCallerRefFn,
-		WaitTimeout:      time.Second,
-		Cfg:              s.cfg,
-	}
-	s.NoError(repo.Delete(Distribution{ID: "id"}))
+	repo := DistRepository{CloudFrontClient: s.cfClient, Cfg: s.cfg}
+	s.NoError(repo.Delete("id"))
+	s.cfClient.AssertNotCalled(s.T(), "DeleteDistribution", mock.Anything)
 }
 
 func (s *DistributionRepositoryTestSuite) TestDelete_NoSuchDistributionDeletingIt() {
-	enabledDistConfig := &awscloudfront.DistributionConfig{Enabled: aws.Bool(true)}
-	disabledDistConfig := &awscloudfront.DistributionConfig{Enabled: aws.Bool(false)}
-	s.cfClient.ExpectedGetDistributionConfigOutput = &awscloudfront.GetDistributionConfigOutput{
-		ETag:               aws.String("etag1"),
-		DistributionConfig: enabledDistConfig,
-	}
-	s.cfClient.ExpectedUpdateDistributionOutput = &awscloudfront.UpdateDistributionOutput{
-		ETag:         aws.String("etag2"),
-		Distribution: &awscloudfront.Distribution{DistributionConfig: disabledDistConfig},
-	}
 	s.cfClient.ExpectedGetDistributionOutput = &awscloudfront.GetDistributionOutput{
-		ETag: aws.String("etag2"),
-		Distribution: &awscloudfront.Distribution{
-			DistributionConfig: disabledDistConfig,
-			Status:             aws.String("Deployed"),
-		},
+		ETag:         aws.String("etag2"),
+		Distribution: &awscloudfront.Distribution{Status: aws.String("Deployed")},
 	}
 
 	var noError error
 	awsErr := awserr.New(awscloudfront.ErrCodeNoSuchDistribution, "msg", nil)
-	s.cfClient.On("GetDistributionConfig", mock.Anything).Return(noError).Once()
-	s.cfClient.On("UpdateDistribution", mock.Anything).Return(noError).Once()
 	s.cfClient.On("GetDistribution", mock.Anything).Return(noError).Once()
 	s.cfClient.On("DeleteDistribution", mock.Anything).Return(awsErr).Once()
 
-	repo := DistRepository{
-		CloudFrontClient: s.cfClient,
-		OACRepo:          s.oacRepo,
-		TaggingClient:    s.taggingClient,
-		CallerRef:        testCallerRefFn,
-		WaitTimeout:      time.Second,
-		Cfg:              s.cfg,
-	}
-	s.NoError(repo.Delete(Distribution{ID: "id"}))
+	repo := DistRepository{CloudFrontClient: s.cfClient, Cfg: s.cfg}
+	s.NoError(repo.Delete("id"))
+}
+
+func (s *DistributionRepositoryTestSuite) TestDeleteOACs_Success() {
+	var noError error
+	s.oacRepo.On("Delete", OAC{ID: "some oac"}).Return(noError).Once()
+	s.oacRepo.On("Delete", OAC{ID: "another oac"}).Return(noError).Once()
+
+	repo := DistRepository{OACRepo: s.oacRepo, Cfg: s.cfg}
+	s.NoError(repo.DeleteOACs([]string{"some oac", "another oac"}))
+	s.oacRepo.AssertExpectations(s.T())
+}
+
+func (s *DistributionRepositoryTestSuite) TestDeleteOACs_FailsToDeleteOACs() {
+	s.oacRepo.On("Delete", mock.Anything).Return(errors.New("some err")).Once()
+
+	repo := DistRepository{OACRepo: s.oacRepo, Cfg: s.cfg}
+	s.Error(repo.DeleteOACs([]string{"some oac", "another oac"}))
 }
 
 func (s *DistributionRepositoryTestSuite) Test_baseCacheBehavior_PolicySet() {
@@ -1106,3 +1269,262 @@ func (s *DistributionRepositoryTestSuite) Test_baseCacheBehavior_ResponsePolicyE
 	)
 	s.Nil(cb.ResponseHeadersPolicyId)
 }
+
+func (s *DistributionRepositoryTestSuite) Test_baseCacheBehavior_ViewerProtocolPolicySet() {
+	cb := baseCacheBehavior(
+		Behavior{
+			OriginHost:           "host",
+			PathPattern:          "path",
+			ViewerProtocolPolicy: "https-only",
+		},
+	)
+	s.Equal("https-only", *cb.ViewerProtocolPolicy)
+}
+
+func (s *DistributionRepositoryTestSuite) Test_baseCacheBehavior_ViewerProtocolPolicyEmpty() {
+	cb := baseCacheBehavior(
+		Behavior{
+			OriginHost:  "host",
+			PathPattern: "path",
+		},
+	)
+	s.Equal("redirect-to-https", *cb.ViewerProtocolPolicy)
+}
+
+func (s *DistributionRepositoryTestSuite) Test_newAWSOrigin_ProtocolSettings() {
+	o := NewOriginBuilder("dist", "origin", OriginAccessPublic, config.Config{CloudFrontDefaultOriginMinSSLProtocol: "TLSv1.1"}).
+		WithProtocolPolicy("https-only").
+		WithPorts(8080, 8443).
+		WithKeepaliveTimeout(30).
+		Build()
+
+	got := newAWSOrigin(o).CustomOriginConfig
+	s.Equal("https-only", *got.OriginProtocolPolicy)
+	s.Equal(int64(8080), *got.HTTPPort)
+	s.Equal(int64(8443), *got.HTTPSPort)
+	s.Equal(int64(30), *got.OriginKeepaliveTimeout)
+	s.Equal([]string{"TLSv1.1", "TLSv1.2"}, aws.StringValueSlice(got.OriginSslProtocols.Items))
+	s.Equal(int64(2), *got.OriginSslProtocols.Quantity)
+}
+
+func (s *DistributionRepositoryTestSuite) Test_originSSLProtocolsFrom() {
+	s.Equal([]string{"SSLv3", "TLSv1", "TLSv1.1", "TLSv1.2"}, originSSLProtocolsFrom("SSLv3"))
+	s.Equal([]string{"TLSv1.2"}, originSSLProtocolsFrom("TLSv1.2"))
+	s.Equal([]string{"TLSv1.2"}, originSSLProtocolsFrom(""))
+}
+
+func (s *DistributionRepositoryTestSuite) Test_newAWSOrigin_OriginPath() {
+	o := NewOriginBuilder("dist", "origin", OriginAccessPublic, s.cfg).
+		WithOriginPath("/prefix").
+		WithBehavior("/foo").
+		Build()
+
+	got := newAWSOrigin(o)
+	s.Equal("origin", *got.Id)
+	s.Equal("origin", *got.DomainName)
+	s.Equal("/prefix", *got.OriginPath)
+	s.Equal("origin", *baseCacheBehavior(o.Behaviors[0]).TargetOriginId)
+}
+
+func (s *DistributionRepositoryTestSuite) Test_newAWSDistributionConfig_OriginsSharingHost() {
+	dist, err := NewDistributionBuilder("group", s.cfg).
+		WithOrigin(NewOriginBuilder("dist", "origin", OriginAccessPublic, s.cfg).WithBehavior("/foo").Build()).
+		WithOrigin(NewOriginBuilder("dist", "origin", OriginAccessPublic, s.cfg).WithResponseTimeout(10).WithBehavior("/bar").Build()).
+		WithOrigin(NewOriginBuilder("dist", s.cfg.DefaultOriginDomain, OriginAccessPublic, s.cfg).WithBehavior("/baz").Build()).
+		Build()
+	s.NoError(err)
+
+	got := newAWSDistributionConfig(dist, testCallerRefFn, s.cfg)
+	s.Len(got.Origins.Items, 3, "a custom origin equal to the default one should not be duplicated")
+
+	ids := make(map[string]bool)
+	for _, o := range got.Origins.Items {
+		ids[*o.Id] = true
+	}
+	s.Len(ids, 3)
+	for _, b := range got.CacheBehaviors.Items {
+		s.True(ids[*b.TargetOriginId])
+	}
+	s.True(ids[s.cfg.DefaultOriginDomain])
+	s.True(ids["origin"], "one of the origins sharing a host should keep it as ID")
+}
+
+func (s *DistributionRepositoryTestSuite) Test_newAWSDistributionConfig_DefaultBehavior() {
+	dist, err := NewDistributionBuilder("group", s.cfg).
+		WithOrigin(NewOriginBuilder("dist", "spa", OriginAccessPublic, s.cfg).
+			WithCachePolicy("spa-cache-policy").
+			WithRequestPolicy("None").
+			WithResponsePolicy("spa-response-policy").
+			WithBehavior("/*", newResponseCloudfrontFunction("some-arn", awscloudfront.EventTypeViewerResponse)).
+			WithBehavior("/app/*").
+			WithDefaultBehavior().
+			Build()).
+		WithOrigin(NewOriginBuilder("dist", "api", OriginAccessPublic, s.cfg).WithBehavior("/api/*").Build()).
+		Build()
+	s.NoError(err)
+
+	got := newAWSDistributionConfig(dist, testCallerRefFn, s.cfg)
+	s.Len(got.Origins.Items, 2, "the default origin should not be duplicated nor the global one added")
+	s.Equal("spa", *got.Origins.Items[0].Id)
+	s.Len(got.CacheBehaviors.Items, 2)
+
+	def := got.DefaultCacheBehavior
+	s.Equal("spa", *def.TargetOriginId)
+	s.Equal("spa-cache-policy", *def.CachePolicyId)
+	s.Nil(def.OriginRequestPolicyId)
+	s.Equal("spa-response-policy", *def.ResponseHeadersPolicyId)
+	s.Equal(int64(1), *def.FunctionAssociations.Quantity)
+	s.Equal("some-arn", *def.FunctionAssociations.Items[0].FunctionARN)
+	s.Equal(int64(0), *def.LambdaFunctionAssociations.Quantity)
+	s.Equal(awscloudfront.ViewerProtocolPolicyRedirectToHttps, *def.ViewerProtocolPolicy)
+}
+
+func (s *DistributionRepositoryTestSuite) Test_newAWSDistributionConfig_OriginGroups() {
+	dist, err := NewDistributionBuilder("group", s.cfg).
+		WithOrigin(NewOriginBuilder("dist", "origin", OriginAccessPublic, s.cfg).
+			WithFailover("secondary", "", OriginAccessPublic, []int64{500, 503}).
+			WithBehavior("/foo").
+			Build()).
+		WithOrigin(NewOriginBuilder("dist", "secondary", OriginAccessPublic, s.cfg).WithBehavior("/bar").Build()).
+		Build()
+	s.NoError(err)
+
+	got := newAWSDistributionConfig(dist, testCallerRefFn, s.cfg)
+	s.Len(got.Origins.Items, 3, "a secondary origin equal to a custom origin should not be duplicated")
+
+	s.Equal(int64(1), *got.OriginGroups.Quantity)
+	group := got.OriginGroups.Items[0]
+	s.Equal("origin-failover", *group.Id)
+	s.Equal([]int64{500, 503}, aws.Int64ValueSlice(group.FailoverCriteria.StatusCodes.Items))
+	s.Equal(int64(2), *group.FailoverCriteria.StatusCodes.Quantity)
+	s.Equal("origin", *group.Members.Items[0].OriginId)
+	s.Equal("secondary", *group.Members.Items[1].OriginId)
+
+	targets := make(map[string]string)
+	for _, b := range got.CacheBehaviors.Items {
+		targets[*b.PathPattern] = *b.TargetOriginId
+	}
+	s.Equal("origin-failover", targets["/foo"])
+	s.Equal("secondary", targets["/bar"])
+}
+
+func (s *DistributionRepositoryTestSuite) Test_newAWSDistributionConfig_NoOriginGroups() {
+	got := newAWSDistributionConfig(Distribution{DefaultOrigin: Origin{Host: "default.origin"}}, testCallerRefFn, s.cfg)
+	s.Nil(got.OriginGroups)
+}
+
+func (s *DistributionRepositoryTestSuite) Test_newAWSDistributionConfig_DefaultRootObject() {
+	got := newAWSDistributionConfig(Distribution{DefaultOrigin: Origin{Host: "default.origin"}}, testCallerRefFn, s.cfg)
+	s.Nil(got.DefaultRootObject)
+
+	d := Distribution{DefaultOrigin: Origin{Host: "default.origin"}, DefaultRootObject: aws.String("index.html")}
+	got = newAWSDistributionConfig(d, testCallerRefFn, s.cfg)
+	s.Equal(aws.String("index.html"), got.DefaultRootObject)
+}
+
+func (s *DistributionRepositoryTestSuite) Test_newAWSCustomErrorResponses() {
+	s.Nil(newAWSCustomErrorResponses(nil))
+
+	got := newAWSCustomErrorResponses([]CustomErrorResponse{
+		{ErrorCode: 404, ResponsePagePath: "/index.html", ResponseCode: 200},
+		{ErrorCode: 503, CachingMinTTL: aws.Int64(0)},
+	})
+	s.Equal(&awscloudfront.CustomErrorResponses{
+		Items: []*awscloudfront.CustomErrorResponse{
+			{ErrorCode: aws.Int64(404), ResponsePagePath: aws.String("/index.html"), ResponseCode: aws.String("200")},
+			{ErrorCode: aws.Int64(503), ErrorCachingMinTTL: aws.Int64(0)},
+		},
+		Quantity: aws.Int64(2),
+	}, got)
+}
+
+func (s *DistributionRepositoryTestSuite) Test_keepUnmanagedFields_CustomErrorResponses() {
+	observed := &awscloudfront.DistributionConfig{
+		CallerReference:   aws.String("ref"),
+		DefaultRootObject: aws.String(""),
+		CustomErrorResponses: &awscloudfront.CustomErrorResponses{
+			Items:    []*awscloudfront.CustomErrorResponse{{ErrorCode: aws.Int64(500)}},
+			Quantity: aws.Int64(1),
+		},
+	}
+
+	desired := &awscloudfront.DistributionConfig{}
+	keepUnmanagedFields(desired, observed)
+	s.Equal(observed.CustomErrorResponses, desired.CustomErrorResponses, "unmanaged error responses should be kept")
+
+	managed := newAWSCustomErrorResponses([]CustomErrorResponse{{ErrorCode: 404}})
+	desired = &awscloudfront.DistributionConfig{CustomErrorResponses: managed}
+	keepUnmanagedFields(desired, observed)
+	s.Equal(managed, desired.CustomErrorResponses)
+
+	cleared := newAWSCustomErrorResponses([]CustomErrorResponse{})
+	desired = &awscloudfront.DistributionConfig{CustomErrorResponses: cleared}
+	keepUnmanagedFields(desired, observed)
+	s.Equal(&awscloudfront.CustomErrorResponses{Quantity: aws.Int64(0)}, desired.CustomErrorResponses, "error responses no longer managed should be removed")
+}
+
+func (s *DistributionRepositoryTestSuite) Test_newAWSRestrictions() {
+	s.Nil(newAWSRestrictions(nil))
+
+	s.Equal(&awscloudfront.Restrictions{GeoRestriction: &awscloudfront.GeoRestriction{
+		RestrictionType: aws.String(awscloudfront.GeoRestrictionTypeWhitelist),
+		Items:           aws.StringSlice([]string{"BR", "MX"}),
+		Quantity:        aws.Int64(2),
+	}}, newAWSRestrictions(&GeoRestriction{Type: GeoRestrictionAllow, Countries: []string{"BR", "MX"}}))
+
+	s.Equal(&awscloudfront.Restrictions{GeoRestriction: &awscloudfront.GeoRestriction{
+		RestrictionType: aws.String(awscloudfront.GeoRestrictionTypeBlacklist),
+		Items:           aws.StringSlice([]string{"US"}),
+		Quantity:        aws.Int64(1),
+	}}, newAWSRestrictions(&GeoRestriction{Type: GeoRestrictionDeny, Countries: []string{"US"}}))
+
+	s.Equal(&awscloudfront.Restrictions{GeoRestriction: &awscloudfront.GeoRestriction{
+		RestrictionType: aws.String(awscloudfront.GeoRestrictionTypeNone),
+		Quantity:        aws.Int64(0),
+	}}, newAWSRestrictions(&GeoRestriction{Type: GeoRestrictionNone}))
+}
+
+func (s *DistributionRepositoryTestSuite) Test_observedGeoRestriction() {
+	s.Equal(&GeoRestriction{Type: GeoRestrictionNone}, observedGeoRestriction(nil))
+
+	for _, want := range []GeoRestriction{
+		{Type: GeoRestrictionAllow, Countries: []string{"BR", "MX"}},
+		{Type: GeoRestrictionDeny, Countries: []string{"US"}},
+		{Type: GeoRestrictionNone},
+	} {
+		want := want
+		s.Equal(&want, observedGeoRestriction(newAWSRestrictions(&want)))
+	}
+}
+
+func (s *DistributionRepositoryTestSuite) Test_keepUnmanagedFields_Restrictions() {
+	observed := &awscloudfront.DistributionConfig{
+		CallerReference:   aws.String("ref"),
+		DefaultRootObject: aws.String(""),
+		Restrictions:      newAWSRestrictions(&GeoRestriction{Type: GeoRestrictionDeny, Countries: []string{"US"}}),
+	}
+
+	desired := &awscloudfront.DistributionConfig{}
+	keepUnmanagedFields(desired, observed)
+	s.Equal(observed.Restrictions, desired.Restrictions, "unmanaged restrictions should be kept")
+
+	managed := newAWSRestrictions(&GeoRestriction{Type: GeoRestrictionNone})
+	desired = &awscloudfront.DistributionConfig{Restrictions: managed}
+	keepUnmanagedFields(desired, observed)
+	s.Equal(managed, desired.Restrictions)
+}
+
+func (s *DistributionRepositoryTestSuite) Test_keepUnmanagedFields_DefaultRootObject() {
+	observed := &awscloudfront.DistributionConfig{
+		CallerReference:   aws.String("ref"),
+		DefaultRootObject: aws.String("index.html"),
+	}
+
+	desired := &awscloudfront.DistributionConfig{}
+	keepUnmanagedFields(desired, observed)
+	s.Equal(aws.String("index.html"), desired.DefaultRootObject, "unmanaged default root object should be kept")
+
+	desired = &awscloudfront.DistributionConfig{DefaultRootObject: aws.String("")}
+	keepUnmanagedFields(desired, observed)
+	s.Equal(aws.String(""), desired.DefaultRootObject)
+}
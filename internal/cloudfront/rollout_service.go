@@ -0,0 +1,245 @@
+// Copyright (c) 2023 GPBR Participacoes LTDA.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package cloudfront
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"sort"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/Gympass/cdn-origin-controller/api/v1alpha1"
+	"github.com/Gympass/cdn-origin-controller/internal/k8s"
+)
+
+const (
+	reasonRolloutInvalidation = "RolloutInvalidationCreated"
+
+	// rolloutIngressLabel labels Invalidations created on rollout with the UID of the Ingress they were created for
+	rolloutIngressLabel = "cdn-origin-controller.gympass.com/rollout-ingress"
+
+	// finishedRolloutInvalidationsKept is how many finished Invalidations created on rollout are kept per Ingress.
+	// Older ones are deleted whenever a new one is created.
+	finishedRolloutInvalidationsKept = 5
+)
+
+// RolloutService creates Invalidations for the paths of Ingresses which opted in to it
+// whenever a Deployment backing them finishes rolling out a new revision
+type RolloutService struct {
+	client.Client
+
+	Recorder record.EventRecorder
+}
+
+// Reconcile a Deployment, invalidating the paths of the Ingresses it backs if its latest revision finished rolling out.
+// The last revision seen is recorded on each Ingress, and paths are only invalidated when it changes, so the revision
+// which is live when an Ingress opts in or starts being backed by the Deployment is not invalidated.
+func (s *RolloutService) Reconcile(ctx context.Context, deploy *appsv1.Deployment) error {
+	if !k8s.IsRolloutComplete(deploy) {
+		return nil
+	}
+
+	ingresses, err := s.ingressesBackedBy(ctx, deploy)
+	if err != nil {
+		return err
+	}
+
+	for i := range ingresses {
+		if err := s.reconcileIngress(ctx, &ingresses[i], deploy); err != nil {
+			return fmt.Errorf("invalidating paths of Ingress %s/%s: %v", ingresses[i].Namespace, ingresses[i].Name, err)
+		}
+	}
+	return nil
+}
+
+func (s *RolloutService) reconcileIngress(ctx context.Context, ing *networkingv1.Ingress, deploy *appsv1.Deployment) error {
+	revision := k8s.DeploymentRevision(deploy)
+	lastSeen, seen := k8s.RolloutRevisions(ing)[deploy.Name]
+	if seen && lastSeen == revision {
+		return nil
+	}
+
+	if seen {
+		if err := s.invalidate(ctx, ing, deploy); err != nil {
+			return err
+		}
+	}
+
+	patch := client.MergeFrom(ing.DeepCopy())
+	k8s.SetRolloutRevision(ing, deploy.Name, revision)
+	if err := s.Patch(ctx, ing, patch); err != nil {
+		return fmt.Errorf("recording revision %s of Deployment %s: %v", revision, deploy.Name, err)
+	}
+	return nil
+}
+
+// ingressesBackedBy returns the Ingresses which opted in to invalidation on rollout and have a Service
+// selecting pods of the given Deployment as one of their backends
+func (s *RolloutService) ingressesBackedBy(ctx context.Context, deploy *appsv1.Deployment) ([]networkingv1.Ingress, error) {
+	ingList := &networkingv1.IngressList{}
+	if err := s.List(ctx, ingList, client.InNamespace(deploy.Namespace)); err != nil {
+		return nil, fmt.Errorf("listing Ingresses: %v", err)
+	}
+
+	var result []networkingv1.Ingress
+	for _, ing := range ingList.Items {
+		if !k8s.InvalidatesOnRollout(&ing) || !k8s.HasGroupAnnotation(&ing) || ing.DeletionTimestamp != nil {
+			continue
+		}
+
+		backed, err := s.isBackedBy(ctx, &ing, deploy)
+		if err != nil {
+			return nil, err
+		}
+		if backed {
+			result = append(result, ing)
+		}
+	}
+	return result, nil
+}
+
+func (s *RolloutService) isBackedBy(ctx context.Context, ing *networkingv1.Ingress, deploy *appsv1.Deployment) (bool, error) {
+	for _, name := range k8s.BackendServiceNames(ing) {
+		svc := &corev1.Service{}
+		err := s.Get(ctx, client.ObjectKey{Namespace: ing.Namespace, Name: name}, svc)
+		if k8serrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return false, fmt.Errorf("fetching Service %s: %v", name, err)
+		}
+		if k8s.SelectsDeployment(svc, deploy) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (s *RolloutService) invalidate(ctx context.Context, ing *networkingv1.Ingress, deploy *appsv1.Deployment) error {
+	paths, err := invalidationPaths(ctx, ing)
+	if err != nil {
+		return err
+	}
+	if len(paths) == 0 {
+		return nil
+	}
+
+	revision := k8s.DeploymentRevision(deploy)
+	inv := &v1alpha1.Invalidation{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      rolloutInvalidationName(ing.Name, deploy.Name, revision),
+			Namespace: ing.Namespace,
+			Labels:    map[string]string{rolloutIngressLabel: string(ing.UID)},
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: networkingv1.SchemeGroupVersion.String(),
+				Kind:       "Ingress",
+				Name:       ing.Name,
+				UID:        ing.UID,
+			}},
+		},
+		Spec: v1alpha1.InvalidationSpec{
+			Group: ing.GetAnnotations()[k8s.CDNGroupAnnotation],
+			Paths: paths,
+		},
+	}
+
+	err = s.Create(ctx, inv)
+	if k8serrors.IsAlreadyExists(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("creating Invalidation: %v", err)
+	}
+
+	log, _ := logr.FromContext(ctx)
+	log.V(1).Info("Created Invalidation for rollout.", "invalidation", inv.Name, "ingress", ing.Name)
+	s.Recorder.Eventf(ing, corev1.EventTypeNormal, reasonRolloutInvalidation,
+		"Created Invalidation %s after Deployment %s rolled out revision %s", inv.Name, deploy.Name, revision)
+	return s.pruneInvalidations(ctx, ing)
+}
+
+// pruneInvalidations deletes the finished Invalidations created on rollout for the given Ingress,
+// except for the most recent ones
+func (s *RolloutService) pruneInvalidations(ctx context.Context, ing *networkingv1.Ingress) error {
+	invList := &v1alpha1.InvalidationList{}
+	err := s.List(ctx, invList, client.InNamespace(ing.Namespace), client.MatchingLabels{rolloutIngressLabel: string(ing.UID)})
+	if err != nil {
+		return fmt.Errorf("listing Invalidations: %v", err)
+	}
+
+	var finished []v1alpha1.Invalidation
+	for _, inv := range invList.Items {
+		if inv.IsFinished() {
+			finished = append(finished, inv)
+		}
+	}
+	if len(finished) <= finishedRolloutInvalidationsKept {
+		return nil
+	}
+
+	sort.Slice(finished, func(i, j int) bool {
+		if !finished[i].CreationTimestamp.Equal(&finished[j].CreationTimestamp) {
+			return finished[i].CreationTimestamp.Before(&finished[j].CreationTimestamp)
+		}
+		return finished[i].Name < finished[j].Name
+	})
+
+	for i := range finished[:len(finished)-finishedRolloutInvalidationsKept] {
+		if err := s.Delete(ctx, &finished[i]); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("deleting Invalidation %s: %v", finished[i].Name, err)
+		}
+	}
+	return nil
+}
+
+// invalidationPaths returns the path patterns CloudFront behaviors are created with for the given Ingress
+func invalidationPaths(ctx context.Context, ing *networkingv1.Ingress) ([]string, error) {
+	cdnIng, err := k8s.NewCDNIngressFromV1(ctx, ing, k8s.CDNClass{})
+	if err != nil {
+		return nil, err
+	}
+
+	patterns := sets.NewString()
+	for _, p := range cdnIng.UnmergedPaths {
+		patterns.Insert(pathPatternsForPath(p)...)
+	}
+	return patterns.List(), nil
+}
+
+// rolloutInvalidationName returns a name which is unique per Ingress, Deployment and revision,
+// falling back to a hash of it when it's too long to be a valid name
+func rolloutInvalidationName(ingName, deployName, revision string) string {
+	name := fmt.Sprintf("%s-%s-%s", ingName, deployName, revision)
+	if len(name) <= validation.DNS1123SubdomainMaxLength {
+		return name
+	}
+	return fmt.Sprintf("rollout-%x", sha256.Sum256([]byte(name)))
+}
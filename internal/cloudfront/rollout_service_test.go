@@ -0,0 +1,232 @@
+// Copyright (c) 2023 GPBR Participacoes LTDA.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package cloudfront
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/Gympass/cdn-origin-controller/api/v1alpha1"
+	"github.com/Gympass/cdn-origin-controller/internal/k8s"
+)
+
+func TestRunRolloutServiceTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, &RolloutServiceTestSuite{})
+}
+
+type RolloutServiceTestSuite struct {
+	suite.Suite
+}
+
+func (s *RolloutServiceTestSuite) newService(objs ...client.Object) *RolloutService {
+	scheme := runtime.NewScheme()
+	s.NoError(clientgoscheme.AddToScheme(scheme))
+	s.NoError(v1alpha1.AddToScheme(scheme))
+
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+	return &RolloutService{Client: k8sClient, Recorder: &record.FakeRecorder{}}
+}
+
+func newRolloutDeployment(revision string, updatedReplicas int32) *appsv1.Deployment {
+	replicas := int32(1)
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "deploy",
+			Namespace:   "default",
+			Annotations: map[string]string{"deployment.kubernetes.io/revision": revision},
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Template: corev1.PodTemplateSpec{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "foo"}}},
+		},
+		Status: appsv1.DeploymentStatus{Replicas: updatedReplicas, UpdatedReplicas: updatedReplicas, AvailableReplicas: updatedReplicas},
+	}
+}
+
+func newRolloutService(name string, selector map[string]string) *corev1.Service {
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec:       corev1.ServiceSpec{Selector: selector},
+	}
+}
+
+func newRolloutIngress(name string, annotations map[string]string, svcName string) *networkingv1.Ingress {
+	prefix := networkingv1.PathTypePrefix
+	exact := networkingv1.PathTypeExact
+	backend := networkingv1.IngressBackend{Service: &networkingv1.IngressServiceBackend{Name: svcName}}
+	return &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Annotations: annotations},
+		Spec: networkingv1.IngressSpec{
+			Rules: []networkingv1.IngressRule{{IngressRuleValue: networkingv1.IngressRuleValue{HTTP: &networkingv1.HTTPIngressRuleValue{
+				Paths: []networkingv1.HTTPIngressPath{
+					{Path: "/static", PathType: &prefix, Backend: backend},
+					{Path: "/index.html", PathType: &exact, Backend: backend},
+				},
+			}}}},
+		},
+	}
+}
+
+func optedIn() map[string]string {
+	return map[string]string{
+		k8s.CDNGroupAnnotation: "group",
+		"cdn-origin-controller.gympass.com/cf.invalidate-on-rollout": "true",
+	}
+}
+
+func (s *RolloutServiceTestSuite) listInvalidations(svc *RolloutService) []v1alpha1.Invalidation {
+	list := &v1alpha1.InvalidationList{}
+	s.NoError(svc.List(context.Background(), list))
+	return list.Items
+}
+
+func (s *RolloutServiceTestSuite) getIngress(svc *RolloutService, name string) *networkingv1.Ingress {
+	ing := &networkingv1.Ingress{}
+	s.NoError(svc.Get(context.Background(), client.ObjectKey{Namespace: "default", Name: name}, ing))
+	return ing
+}
+
+func (s *RolloutServiceTestSuite) TestReconcile_FirstRevisionSeenIsOnlyRecorded() {
+	deploy := newRolloutDeployment("3", 1)
+	svc := s.newService(
+		deploy,
+		newRolloutService("svc", map[string]string{"app": "foo"}),
+		newRolloutIngress("opted-in", optedIn(), "svc"),
+	)
+
+	s.NoError(svc.Reconcile(context.Background(), deploy))
+
+	s.Empty(s.listInvalidations(svc))
+	s.Equal(map[string]string{"deploy": "3"}, k8s.RolloutRevisions(s.getIngress(svc, "opted-in")))
+}
+
+func (s *RolloutServiceTestSuite) TestReconcile_CreatesInvalidationForOptedInIngresses() {
+	deploy := newRolloutDeployment("3", 1)
+	seen := func(name string, annotations map[string]string, svcName string) *networkingv1.Ingress {
+		ing := newRolloutIngress(name, annotations, svcName)
+		k8s.SetRolloutRevision(ing, "deploy", "2")
+		return ing
+	}
+	svc := s.newService(
+		deploy,
+		newRolloutService("svc", map[string]string{"app": "foo"}),
+		newRolloutService("other-svc", map[string]string{"app": "bar"}),
+		seen("opted-in", optedIn(), "svc"),
+		seen("not-opted-in", map[string]string{k8s.CDNGroupAnnotation: "group"}, "svc"),
+		seen("other-backend", optedIn(), "other-svc"),
+		seen("missing-backend", optedIn(), "missing-svc"),
+	)
+
+	s.NoError(svc.Reconcile(context.Background(), deploy))
+
+	invalidations := s.listInvalidations(svc)
+	s.Len(invalidations, 1)
+	s.Equal("opted-in-deploy-3", invalidations[0].Name)
+	s.Equal("group", invalidations[0].Spec.Group)
+	s.Equal([]string{"/index.html", "/static", "/static/*"}, invalidations[0].Spec.Paths)
+	s.Len(invalidations[0].OwnerReferences, 1)
+	s.Equal("opted-in", invalidations[0].OwnerReferences[0].Name)
+	s.Equal(map[string]string{"deploy": "3"}, k8s.RolloutRevisions(s.getIngress(svc, "opted-in")))
+
+	// reconciling the same revision again does not invalidate twice
+	s.NoError(svc.Reconcile(context.Background(), deploy))
+	s.Len(s.listInvalidations(svc), 1)
+}
+
+func (s *RolloutServiceTestSuite) TestReconcile_PrunesFinishedInvalidations() {
+	deploy := newRolloutDeployment("10", 1)
+	ing := newRolloutIngress("opted-in", optedIn(), "svc")
+	ing.UID = "ing-uid"
+	k8s.SetRolloutRevision(ing, "deploy", "9")
+
+	objs := []client.Object{deploy, newRolloutService("svc", map[string]string{"app": "foo"}), ing}
+	for i := 1; i <= 8; i++ {
+		phase := v1alpha1.InvalidationPhaseCompleted
+		if i == 1 {
+			phase = v1alpha1.InvalidationPhaseInProgress
+		}
+		objs = append(objs, &v1alpha1.Invalidation{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              fmt.Sprintf("opted-in-deploy-%d", i),
+				Namespace:         "default",
+				Labels:            map[string]string{rolloutIngressLabel: "ing-uid"},
+				CreationTimestamp: metav1.NewTime(time.Unix(int64(i), 0)),
+			},
+			Status: v1alpha1.InvalidationStatus{Phase: phase},
+		})
+	}
+	objs = append(objs, &v1alpha1.Invalidation{
+		ObjectMeta: metav1.ObjectMeta{Name: "manual", Namespace: "default"},
+		Status:     v1alpha1.InvalidationStatus{Phase: v1alpha1.InvalidationPhaseCompleted},
+	})
+	svc := s.newService(objs...)
+
+	s.NoError(svc.Reconcile(context.Background(), deploy))
+
+	var names []string
+	for _, inv := range s.listInvalidations(svc) {
+		names = append(names, inv.Name)
+	}
+	s.ElementsMatch([]string{
+		"manual",
+		"opted-in-deploy-1",
+		"opted-in-deploy-4",
+		"opted-in-deploy-5",
+		"opted-in-deploy-6",
+		"opted-in-deploy-7",
+		"opted-in-deploy-8",
+		"opted-in-deploy-10",
+	}, names)
+}
+
+func (s *RolloutServiceTestSuite) TestReconcile_RolloutNotCompleteDoesNothing() {
+	deploy := newRolloutDeployment("3", 0)
+	svc := s.newService(
+		deploy,
+		newRolloutService("svc", map[string]string{"app": "foo"}),
+		newRolloutIngress("opted-in", optedIn(), "svc"),
+	)
+
+	s.NoError(svc.Reconcile(context.Background(), deploy))
+	s.Empty(s.listInvalidations(svc))
+}
+
+func (s *RolloutServiceTestSuite) Test_rolloutInvalidationName() {
+	s.Equal("ing-deploy-1", rolloutInvalidationName("ing", "deploy", "1"))
+
+	long := rolloutInvalidationName(strings.Repeat("a", 200), strings.Repeat("b", 200), "1")
+	s.True(strings.HasPrefix(long, "rollout-"))
+	s.LessOrEqual(len(long), 253)
+}
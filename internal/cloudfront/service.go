@@ -23,6 +23,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/hashicorp/go-multierror"
@@ -32,11 +34,13 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	"github.com/Gympass/cdn-origin-controller/api/v1alpha1"
 	"github.com/Gympass/cdn-origin-controller/internal/certificate"
 	"github.com/Gympass/cdn-origin-controller/internal/config"
 	"github.com/Gympass/cdn-origin-controller/internal/k8s"
+	"github.com/Gympass/cdn-origin-controller/internal/metrics"
 	"github.com/Gympass/cdn-origin-controller/internal/route53"
 	"github.com/Gympass/cdn-origin-controller/internal/strhelper"
 )
@@ -44,8 +48,14 @@ import (
 const (
 	reasonFailed  = "FailedToReconcile"
 	reasonSuccess = "SuccessfullyReconciled"
+	reasonDryRun  = "DryRun"
+
+	reasonDeployed = "DistributionDeployed"
 )
 
+// deploymentPollInterval is how often the deployment status of a distribution is checked while changes are being deployed
+const deploymentPollInterval = 30 * time.Second
+
 // Service handles operations involving CloudFront
 type Service struct {
 	client.Client
@@ -58,15 +68,16 @@ type Service struct {
 	CertService certificate.Service
 }
 
-// Reconcile an Ingress resource of any version
-func (s *Service) Reconcile(ctx context.Context, ing *networkingv1.Ingress, class k8s.CDNClass) error {
+// Reconcile an Ingress resource of any version. The returned result asks for the Ingress to be
+// reconciled again while changes to the distribution are being deployed or the distribution is being deleted.
+func (s *Service) Reconcile(ctx context.Context, ing *networkingv1.Ingress, class k8s.CDNClass) (reconcile.Result, error) {
 	if err := s.validateIngress(ing); err != nil {
-		return s.handleFailure(fmt.Errorf("validating Ingress: %v", err), ing)
+		return reconcile.Result{}, s.handleFailure(fmt.Errorf("validating Ingress: %v", err), ing)
 	}
 
 	reconciling, err := k8s.NewCDNIngressFromV1(ctx, ing, class)
 	if err != nil {
-		return s.handleFailure(err, ing)
+		return reconcile.Result{}, s.handleFailure(err, ing)
 	}
 
 	log, _ := logr.FromContext(ctx)
@@ -74,57 +85,120 @@ func (s *Service) Reconcile(ctx context.Context, ing *networkingv1.Ingress, clas
 	if k8s.HasFinalizer(ing) && !k8s.HasGroupAnnotation(ing) {
 		err := errors.New("ingress has no group annotation but has finalizer, can't continue without a group")
 		log.Error(err, "Faced invalid Ingress, removing finalizer. State may be inconsistent but should eventually self-heal.")
-		return s.reconcileFinalizer(ing, false)
+		return reconcile.Result{}, s.reconcileFinalizer(ing, false)
+	}
+
+	return s.reconcile(ctx, ing, reconciling)
+}
+
+// ReconcileDistribution reconciles a Distribution resource. The returned result asks for the Distribution to be
+// reconciled again while changes to the distribution are being deployed or the distribution is being deleted.
+func (s *Service) ReconcileDistribution(ctx context.Context, dist *v1alpha1.Distribution, class k8s.CDNClass) (reconcile.Result, error) {
+	origins, err := k8s.NewCDNIngressesFromDistribution(dist, class)
+	if err != nil {
+		return reconcile.Result{}, s.handleFailure(fmt.Errorf("validating Distribution: %v", err), dist)
+	}
+
+	return s.reconcile(ctx, dist, origins[0])
+}
+
+func (s *Service) reconcile(ctx context.Context, obj client.Object, reconciling k8s.CDNIngress) (reconcile.Result, error) {
+	released, err := s.releaseIfRequested(ctx, obj, reconciling)
+	if err != nil {
+		return reconcile.Result{}, s.handleFailure(fmt.Errorf("releasing group: %v", err), obj)
+	}
+	if released {
+		return reconcile.Result{}, nil
 	}
 
 	desiredIngresses, desiredDist, err := s.desiredState(ctx, reconciling)
 	if err != nil {
-		return s.handleFailure(fmt.Errorf("computing desired state: %v", err), ing)
+		metrics.Reconciliations.WithLabelValues(reconciling.Group, metrics.ReconcileFailed).Inc()
+		err = fmt.Errorf("computing desired state: %w", err)
+		s.reportFailureOnExistingCDNStatus(ctx, reconciling.Group, err)
+		return reconcile.Result{}, s.handleFailure(err, obj)
 	}
 
-	if err := s.validateCreation(desiredDist, ing); err != nil {
-		return s.handleFailure(err, ing)
+	if err := s.validateCreation(desiredDist, obj); err != nil {
+		metrics.Reconciliations.WithLabelValues(reconciling.Group, metrics.ReconcileFailed).Inc()
+		return reconcile.Result{}, s.handleFailure(err, obj)
 	}
 
 	cdnStatus, err := s.fetchOrGenerateCDNStatus(desiredIngresses, desiredDist)
 	if err != nil {
-		return s.handleFailure(fmt.Errorf("validating creation: %v", err), ing)
+		metrics.Reconciliations.WithLabelValues(reconciling.Group, metrics.ReconcileFailed).Inc()
+		return reconcile.Result{}, s.handleFailure(fmt.Errorf("validating creation: %v", err), obj)
 	}
 
+	setCertificateCondition(cdnStatus, desiredDist)
+
 	errs := &multierror.Error{}
 
-	existingDist, err := s.syncDist(ctx, desiredDist, cdnStatus, ing)
+	existingDist, err := s.syncDist(ctx, desiredDist, cdnStatus, obj)
 	errs = multierror.Append(errs, err)
+	setDistributionConditions(cdnStatus, desiredDist, existingDist, err)
+	isDeploying := false
+	if err == nil && !desiredDist.DryRun && !desiredDist.IsEmpty() {
+		recordDistributionSize(existingDist)
+		s.trackDeployment(cdnStatus, existingDist)
+		isDeploying = !existingDist.IsDeployed()
+	}
 
-	if reconciling.Class.CreateAlias {
+	if reconciling.Class.CreateAlias && !desiredDist.DryRun {
 		err := s.syncAliases(cdnStatus, existingDist, reconciling.Class)
 		errs = multierror.Append(errs, err)
+		setDNSCondition(cdnStatus, err)
+	} else {
+		cdnStatus.RemoveCondition(v1alpha1.ConditionDNSReady)
 	}
+	cdnStatus.UpdateReadyCondition()
 
-	shouldHaveFinalizer := errs.Len() > 0 || !k8s.IsBeingRemovedFromDesiredState(ing)
-	if err := s.reconcileFinalizer(ing, shouldHaveFinalizer); err != nil {
-		errs = multierror.Append(errs, fmt.Errorf("reconciling finalizer for ingress (%s/%s): %v", ing.GetNamespace(), ing.GetName(), err))
+	// the finalizer and the CDNStatus are kept until the distribution is deleted, since deletion goes on across
+	// reconciliations of the object
+	isDeleting := cdnStatus.DeletionInProgress()
+	shouldHaveFinalizer := errs.Len() > 0 || !reconciling.IsBeingRemoved || isDeleting
+	if err := s.reconcileFinalizer(obj, shouldHaveFinalizer); err != nil {
+		errs = multierror.Append(errs, fmt.Errorf("reconciling finalizer for %s/%s: %v", obj.GetNamespace(), obj.GetName(), err))
 	}
 
-	if errs.Len() == 0 && k8s.IsBeingRemovedFromDesiredState(ing) {
-		cdnStatus.RemoveIngressRef(ing)
+	if errs.Len() == 0 && reconciling.IsBeingRemoved && !isDeleting {
+		cdnStatus.RemoveIngressRef(obj)
 	}
 
-	if errs.Len() == 0 && desiredDist.IsEmpty() {
+	// in dry-run the CDNStatus is kept, since it holds the plan
+	if errs.Len() == 0 && desiredDist.IsEmpty() && !desiredDist.DryRun && !isDeleting {
 		errs = multierror.Append(errs, s.deleteCDNStatus(ctx, cdnStatus))
 	} else {
 		errs = multierror.Append(errs, s.upsertCDNStatus(ctx, cdnStatus))
 	}
 
-	return s.handleResult(ing, cdnStatus, errs)
+	if err := s.handleResult(obj, cdnStatus, errs); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if isDeploying || isDeleting {
+		return reconcile.Result{RequeueAfter: deploymentPollInterval}, nil
+	}
+	return reconcile.Result{}, nil
+}
+
+// trackDeployment records the deployment status of the distribution, emitting an event once changes which were
+// being deployed are live in all edge locations
+func (s *Service) trackDeployment(cdnStatus *v1alpha1.CDNStatus, dist Distribution) {
+	wasDeploying := len(cdnStatus.Status.DeploymentStatus) > 0 && cdnStatus.Status.DeploymentStatus != cfDeployedStatus
+	if wasDeploying && dist.IsDeployed() {
+		s.Recorder.Event(cdnStatus, corev1.EventTypeNormal, reasonDeployed, "Changes to the distribution are deployed to all edge locations")
+	}
+
+	cdnStatus.SetDeployment(dist.DeploymentStatus, dist.LastModifiedTime)
 }
 
-func (s *Service) validateCreation(desiredDist Distribution, ing *networkingv1.Ingress) error {
-	if desiredDist.Exists() || desiredDist.IsEmpty() || ing.DeletionTimestamp != nil {
+func (s *Service) validateCreation(desiredDist Distribution, obj client.Object) error {
+	if desiredDist.Exists() || desiredDist.IsEmpty() || obj.GetDeletionTimestamp() != nil {
 		return nil
 	}
 
-	if !s.Config.IsCreationAllowed(ing) {
+	if !s.Config.IsCreationAllowed(obj) {
 		return errors.New("creation of new CloudFront distributions is blocked")
 	}
 
@@ -140,33 +214,98 @@ func (s *Service) validateIngress(ing *networkingv1.Ingress) error {
 			"Using deprecated fields/annotations: %v", df)
 	}
 
-	return k8s.ValidateIngressFunctionAssociations(ing)
+	if err := k8s.ValidateIngressFunctionAssociations(ing); err != nil {
+		return err
+	}
+	if err := k8s.ValidateIngressMethods(ing); err != nil {
+		return err
+	}
+	if err := k8s.ValidateIngressViewerProtocolPolicies(ing); err != nil {
+		return err
+	}
+	if err := k8s.ValidateIngressOriginProtocol(ing); err != nil {
+		return err
+	}
+	if err := k8s.ValidateIngressOriginPath(ing); err != nil {
+		return err
+	}
+	if err := k8s.ValidateIngressFailover(ing); err != nil {
+		return err
+	}
+	if err := k8s.ValidateIngressCustomErrorResponses(ing); err != nil {
+		return err
+	}
+	if err := k8s.ValidateIngressGeoRestriction(ing); err != nil {
+		return err
+	}
+	return k8s.ValidateIngressDefaultRootObject(ing)
 }
 
 func (s *Service) desiredState(ctx context.Context, reconciling k8s.CDNIngress) ([]k8s.CDNIngress, Distribution, error) {
-	desiredIngresses, err := s.desiredIngresses(ctx, reconciling)
+	desiredIngresses, sharedParams, err := s.desiredParams(ctx, reconciling)
 	if err != nil {
 		return nil, Distribution{}, err
 	}
 
-	sharedParams, err := k8s.NewSharedIngressParams(desiredIngresses)
-	if err != nil {
-		return nil, Distribution{}, fmt.Errorf("shared ingress params: %v", err)
-	}
-
 	existingDistARN, err := s.DistRepo.ARNByGroup(reconciling.Group)
 	if err != nil && !errors.Is(err, ErrDistNotFound) {
 		return nil, Distribution{}, fmt.Errorf("fetching existing CloudFront ID based on group (%s): %v", reconciling.Group, err)
 	}
 
-	desiredDist, err := s.newDistribution(desiredIngresses, reconciling.Group, sharedParams, existingDistARN)
+	if errors.Is(err, ErrDistNotFound) && len(sharedParams.AdoptDistributionID) > 0 {
+		dryRun := s.Config.DryRun || sharedParams.DryRun
+		existingDistARN, err = s.DistRepo.Adopt(sharedParams.AdoptDistributionID, reconciling.Group, dryRun)
+		if err != nil {
+			return nil, Distribution{}, fmt.Errorf("adopting distribution %s into group (%s): %v", sharedParams.AdoptDistributionID, reconciling.Group, err)
+		}
+	}
+
+	desiredDist, err := s.newDistribution(ctx, desiredIngresses, reconciling.Group, sharedParams, existingDistARN)
 	if err != nil {
-		return nil, Distribution{}, fmt.Errorf("building desired distribution: %v", err)
+		return nil, Distribution{}, fmt.Errorf("building desired distribution: %w", err)
 	}
 
 	return desiredIngresses, desiredDist, nil
 }
 
+// existingDesiredState computes the desired state of a group whose distribution already exists, without side effects
+// such as adopting a distribution. An empty Distribution is returned if the group has no distribution yet.
+func (s *Service) existingDesiredState(ctx context.Context, reconciling k8s.CDNIngress) (Distribution, error) {
+	desiredIngresses, sharedParams, err := s.desiredParams(ctx, reconciling)
+	if err != nil {
+		return Distribution{}, err
+	}
+
+	existingDistARN, err := s.DistRepo.ARNByGroup(reconciling.Group)
+	if errors.Is(err, ErrDistNotFound) {
+		return Distribution{}, nil
+	}
+	if err != nil {
+		return Distribution{}, fmt.Errorf("fetching existing CloudFront ID based on group (%s): %v", reconciling.Group, err)
+	}
+
+	desiredDist, err := s.newDistribution(ctx, desiredIngresses, reconciling.Group, sharedParams, existingDistARN)
+	if err != nil {
+		return Distribution{}, fmt.Errorf("building desired distribution: %w", err)
+	}
+
+	return desiredDist, nil
+}
+
+func (s *Service) desiredParams(ctx context.Context, reconciling k8s.CDNIngress) ([]k8s.CDNIngress, k8s.SharedIngressParams, error) {
+	desiredIngresses, err := s.desiredIngresses(ctx, reconciling)
+	if err != nil {
+		return nil, k8s.SharedIngressParams{}, err
+	}
+
+	sharedParams, err := k8s.NewSharedIngressParams(desiredIngresses)
+	if err != nil {
+		return nil, k8s.SharedIngressParams{}, fmt.Errorf("shared ingress params: %v", err)
+	}
+
+	return desiredIngresses, sharedParams, nil
+}
+
 func (s *Service) desiredIngresses(ctx context.Context, reconciling k8s.CDNIngress) ([]k8s.CDNIngress, error) {
 	desiredIngresses, err := s.Fetcher.FetchBy(ctx, reconciling.Class, s.isPartOfDesiredState(reconciling))
 	if err != nil {
@@ -212,7 +351,7 @@ func newCDNStatus(ings []k8s.CDNIngress, dist Distribution) *v1alpha1.CDNStatus
 	return status
 }
 
-func (s *Service) newDistribution(ingresses []k8s.CDNIngress, group string, shared k8s.SharedIngressParams, distARN string) (Distribution, error) {
+func (s *Service) newDistribution(ctx context.Context, ingresses []k8s.CDNIngress, group string, shared k8s.SharedIngressParams, distARN string) (Distribution, error) {
 	b := NewDistributionBuilder(
 		group,
 		s.Config,
@@ -222,7 +361,7 @@ func (s *Service) newDistribution(ingresses []k8s.CDNIngress, group string, shar
 	if s.Config.TLSIsEnabled() {
 		cert, err = s.discoverCert(ingresses)
 		if err != nil {
-			return Distribution{}, fmt.Errorf("discovering TLS cert: %v", err)
+			return Distribution{}, newConditionError(v1alpha1.ConditionCertificateResolved, "CertificateNotFound", fmt.Errorf("discovering TLS cert: %v", err))
 		}
 		b = b.WithTLS(cert.ARN(), s.Config.CloudFrontSecurityPolicy)
 	}
@@ -250,17 +389,45 @@ func (s *Service) newDistribution(ingresses []k8s.CDNIngress, group string, shar
 	} else if len(distARN) > 0 {
 		b, err = s.keepCurrentWebACLConfig(b, distARN)
 		if err != nil {
-			return Distribution{}, fmt.Errorf("setting webacl config: %v", err)
+			return Distribution{}, newConditionError(v1alpha1.ConditionWAFAttached, "WebACLConfigUnavailable", fmt.Errorf("setting webacl config: %v", err))
 		}
 	}
 
+	stored, err := s.storedCDNStatus(ctx, group)
+	if err != nil {
+		return Distribution{}, err
+	}
+
+	b = b.WithCustomErrorResponses(newCustomErrorResponses(shared.CustomErrorResponses, stored.IsDeclaredField(v1alpha1.OptionalFieldCustomErrorResponses)))
+	b = b.WithGeoRestriction(newGeoRestriction(shared.GeoRestriction, stored.IsDeclaredField(v1alpha1.OptionalFieldGeoRestriction)))
+	b = b.WithDefaultRootObject(newDefaultRootObject(shared.DefaultRootObject, stored.IsDeclaredField(v1alpha1.OptionalFieldDefaultRootObject)))
+	b = b.WithKeptFields(shared.InvalidOptionalFields)
+
 	if len(distARN) > 0 {
 		b = b.WithARN(distARN)
 	}
 
+	if s.Config.DryRun || shared.DryRun {
+		b = b.WithDryRun()
+	}
+
 	return b.Build()
 }
 
+// storedCDNStatus returns the CDNStatus of the group as it was when the group was last synced, which tells the optional
+// fields of the distribution the group managed. It's empty if the group has no CDNStatus yet.
+func (s *Service) storedCDNStatus(ctx context.Context, group string) (*v1alpha1.CDNStatus, error) {
+	status := &v1alpha1.CDNStatus{}
+	err := s.Client.Get(ctx, client.ObjectKey{Name: group}, status)
+	if k8serrors.IsNotFound(err) {
+		return &v1alpha1.CDNStatus{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("fetching CDNStatus: %v", err)
+	}
+	return status, nil
+}
+
 // keepCurrentWebACLConfig checks the current WebACL configuration on distribution and updates the desired state accordingly.
 func (s *Service) keepCurrentWebACLConfig(b DistributionBuilder, distARN string) (DistributionBuilder, error) {
 	distibutionID := b.extractID(distARN)
@@ -301,12 +468,59 @@ func (s *Service) s3Prefix(group string) string {
 }
 
 func (s *Service) syncDist(ctx context.Context, desiredDist Distribution, cdnStatus *v1alpha1.CDNStatus, ing client.Object) (Distribution, error) {
+	if desiredDist.DryRun {
+		return s.planDistribution(desiredDist, cdnStatus, ing)
+	}
+
+	cdnStatus.SetPlan(nil)
 	if desiredDist.IsEmpty() {
-		return desiredDist, s.deleteDistribution(ctx, desiredDist)
+		return desiredDist, s.deleteDistribution(ctx, desiredDist, cdnStatus)
+	}
+
+	if cdnStatus.DeletionInProgress() {
+		log, _ := logr.FromContext(ctx)
+		log.V(1).Info("Group has origins again, abandoning deletion of its distribution.", "phase", cdnStatus.Status.Deletion.Phase)
+		cdnStatus.FinishDeletion()
 	}
 	return s.upsertDistribution(ctx, desiredDist, cdnStatus, ing)
 }
 
+// planDistribution computes the changes that syncing the Distribution would cause, without applying any of them.
+// The planned changes are reported in the CDNStatus, both in its status and as an event.
+func (s *Service) planDistribution(desiredDist Distribution, cdnStatus *v1alpha1.CDNStatus, ing client.Object) (Distribution, error) {
+	plannedDist := desiredDist
+	var err error
+
+	switch {
+	case desiredDist.IsEmpty() && desiredDist.Exists() && s.Config.DeletionEnabled:
+		plannedDist.PlannedChanges = []string{fmt.Sprintf("distribution %s would be deleted", desiredDist.ID)}
+	case desiredDist.IsEmpty():
+		plannedDist.PlannedChanges = nil
+	case !desiredDist.Exists():
+		plannedDist.PlannedChanges = []string{"distribution would be created"}
+	default:
+		plannedDist, err = s.DistRepo.Sync(desiredDist)
+		if err != nil {
+			return Distribution{}, fmt.Errorf("planning Distribution changes: %v", err)
+		}
+	}
+
+	plan := plannedDist.PlannedChanges
+	if plan == nil {
+		plan = []string{}
+	}
+	cdnStatus.SetPlan(plan)
+	cdnStatus.SetIngressRef(true, ing)
+
+	msg := "Dry-run: no changes planned"
+	if len(plan) > 0 {
+		msg = fmt.Sprintf("Dry-run: %d change(s) planned: %s", len(plan), strings.Join(plan, "; "))
+	}
+	s.Recorder.Event(cdnStatus, corev1.EventTypeNormal, reasonDryRun, msg)
+
+	return plannedDist, nil
+}
+
 func (s *Service) upsertDistribution(ctx context.Context, dist Distribution, status *v1alpha1.CDNStatus, ing client.Object) (Distribution, error) {
 	var err error
 	var existingDist Distribution
@@ -321,6 +535,10 @@ func (s *Service) upsertDistribution(ctx context.Context, dist Distribution, sta
 	if err == nil {
 		status.SetInfo(existingDist.ID, existingDist.ARN, existingDist.Address)
 		status.SetAliases(existingDist.AlternateDomains)
+		if g := existingDist.GeoRestriction; g != nil {
+			status.SetGeoRestriction(g.Type, g.Countries)
+		}
+		status.SetDeclaredFields(declaredFields(dist, status))
 	}
 
 	return existingDist, err
@@ -350,21 +568,6 @@ func (s *Service) createDistribution(ctx context.Context, dist Distribution) (Di
 	return existingDist, nil
 }
 
-func (s *Service) deleteDistribution(ctx context.Context, dist Distribution) error {
-	if !dist.Exists() {
-		return nil
-	}
-
-	log, _ := logr.FromContext(ctx)
-	if !s.Config.DeletionEnabled {
-		log.V(1).Info("In a deletion operation, but configured not to delete Distributions. Will not delete.")
-		return nil
-	}
-
-	log.V(1).Info("Disabling and deleting distribution on AWS, this may take a few minutes.")
-	return s.DistRepo.Delete(dist)
-}
-
 func (s *Service) upsertCDNStatus(ctx context.Context, status *v1alpha1.CDNStatus) error {
 	if !status.Exists() {
 		// create does not touch the .status subresource, so we need to create, then update the status
@@ -372,7 +575,28 @@ func (s *Service) upsertCDNStatus(ctx context.Context, status *v1alpha1.CDNStatu
 			return err
 		}
 	}
-	return s.Status().Update(ctx, status)
+	if err := s.Status().Update(ctx, status); err != nil {
+		return err
+	}
+
+	metrics.FailedIngresses.WithLabelValues(status.Name).Set(float64(status.FailedIngresses()))
+	return nil
+}
+
+// reportFailureOnExistingCDNStatus updates the conditions of the group's CDNStatus, if it exists, to reflect a failure
+// which happened before the CDNStatus could be reconciled
+func (s *Service) reportFailureOnExistingCDNStatus(ctx context.Context, group string, err error) {
+	cdnStatus := &v1alpha1.CDNStatus{}
+	if getErr := s.Client.Get(ctx, client.ObjectKey{Name: group}, cdnStatus); getErr != nil {
+		return
+	}
+
+	setFailedCondition(cdnStatus, err)
+	cdnStatus.UpdateReadyCondition()
+	if updateErr := s.Status().Update(ctx, cdnStatus); updateErr != nil {
+		log, _ := logr.FromContext(ctx)
+		log.V(1).Error(updateErr, "Could not update CDNStatus conditions", "cdnStatus", cdnStatus.Name)
+	}
 }
 
 func (s *Service) deleteCDNStatus(ctx context.Context, cdnStatus *v1alpha1.CDNStatus) error {
@@ -381,6 +605,7 @@ func (s *Service) deleteCDNStatus(ctx context.Context, cdnStatus *v1alpha1.CDNSt
 		log.V(1).Error(err, "Could not delete CDNStatus resource", "cdnStatus", cdnStatus)
 		return err
 	}
+	metrics.DeleteGroup(cdnStatus.Name)
 	return nil
 }
 
@@ -443,11 +668,23 @@ func (s *Service) reconcileFinalizer(obj client.Object, shouldHaveFinalizer bool
 
 func (s *Service) handleResult(obj client.Object, cdnStatus *v1alpha1.CDNStatus, errs *multierror.Error) error {
 	if errs.Len() > 0 {
+		metrics.Reconciliations.WithLabelValues(cdnStatus.Name, metrics.ReconcileFailed).Inc()
 		return s.handleFailureWithStatus(errs, obj, cdnStatus)
 	}
+	metrics.Reconciliations.WithLabelValues(cdnStatus.Name, metrics.ReconcileSucceeded).Inc()
 	return s.handleSuccess(obj, cdnStatus)
 }
 
+// recordDistributionSize records how many origins and behaviors the distribution has, including the default ones
+func recordDistributionSize(dist Distribution) {
+	origins := len(dist.CustomOrigins)
+	if dist.DefaultBehavior == nil {
+		origins++
+	}
+	metrics.DistributionOrigins.WithLabelValues(dist.Group).Set(float64(origins))
+	metrics.DistributionBehaviors.WithLabelValues(dist.Group).Set(float64(len(dist.SortedCustomBehaviors()) + 1))
+}
+
 func (s *Service) handleFailure(err error, ingress client.Object) error {
 	s.recordFailureOnIngress(err, ingress)
 	return err
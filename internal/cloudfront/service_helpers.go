@@ -22,8 +22,10 @@ package cloudfront
 import (
 	"strings"
 
+	"github.com/aws/aws-sdk-go/aws"
 	networkingv1 "k8s.io/api/networking/v1"
 
+	"github.com/Gympass/cdn-origin-controller/api/v1alpha1"
 	"github.com/Gympass/cdn-origin-controller/internal/config"
 	"github.com/Gympass/cdn-origin-controller/internal/k8s"
 )
@@ -36,21 +38,95 @@ func renderDescription(template, group string) string {
 
 func newOrigin(ing k8s.CDNIngress, cfg config.Config, shared k8s.SharedIngressParams) Origin {
 	builder := NewOriginBuilder(ing.Group, ing.OriginHost, ing.OriginAccess, cfg).
+		WithOriginPath(ing.OriginPath).
 		WithResponseTimeout(ing.OriginRespTimeout).
+		WithProtocolPolicy(ing.OriginProtocol.Policy).
+		WithPorts(ing.OriginProtocol.HTTPPort, ing.OriginProtocol.HTTPSPort).
+		WithKeepaliveTimeout(ing.OriginProtocol.KeepaliveTimeout).
+		WithMinSSLProtocol(ing.OriginProtocol.MinSSLProtocol).
 		WithRequestPolicy(ing.OriginReqPolicy).
 		WithCachePolicy(ing.CachePolicy).
 		WithResponsePolicy(ing.ResponsePolicy).
 		WithOriginHeaders(ing.OriginHeaders)
 
-	for _, p := range shared.PathsFromOrigin(ing.OriginHost) {
+	if ing.Failover != nil {
+		builder = builder.WithFailover(ing.Failover.Host, ing.Failover.OriginPath, ing.Failover.OriginAccess, ing.Failover.StatusCodes)
+	}
+
+	if shared.IsDefaultOrigin(ing) {
+		builder = builder.WithDefaultBehavior()
+	}
+
+	for _, p := range shared.PathsFromOrigin(ing) {
 		for _, pp := range pathPatternsForPath(p) {
-			builder = builder.WithBehavior(pp, NewFunctions(p.FunctionAssociations)...)
+			builder = builder.WithBehavior(pp, NewFunctions(p.FunctionAssociations)...).
+				WithMethods(pp, p.Methods.Allowed, p.Methods.Cached).
+				WithViewerProtocolPolicy(pp, p.ViewerProtocolPolicy)
 		}
 	}
 
 	return builder.Build()
 }
 
+// newCustomErrorResponses returns the custom error responses declared by the group. If none are declared but they were
+// managed before, an empty list is returned so that they're removed, otherwise the existing ones are kept.
+func newCustomErrorResponses(responses []k8s.CustomErrorResponse, managedBefore bool) []CustomErrorResponse {
+	if len(responses) == 0 && managedBefore {
+		return []CustomErrorResponse{}
+	}
+
+	var result []CustomErrorResponse
+	for _, r := range responses {
+		result = append(result, CustomErrorResponse{
+			ErrorCode:        r.ErrorCode,
+			ResponsePagePath: r.ResponsePagePath,
+			ResponseCode:     r.ResponseCode,
+			CachingMinTTL:    r.CachingMinTTL,
+		})
+	}
+	return result
+}
+
+// declaredFields returns the optional fields the group declares for the Distribution, which are managed by the
+// controller until they're no longer declared. Fields kept as they are remain declared if they were before.
+func declaredFields(d Distribution, stored *v1alpha1.CDNStatus) []v1alpha1.OptionalField {
+	var result []v1alpha1.OptionalField
+	add := func(field v1alpha1.OptionalField, declared bool) {
+		if d.IsKeptField(field) {
+			declared = stored.IsDeclaredField(field)
+		}
+		if declared {
+			result = append(result, field)
+		}
+	}
+
+	add(v1alpha1.OptionalFieldCustomErrorResponses, len(d.CustomErrorResponses) > 0)
+	add(v1alpha1.OptionalFieldGeoRestriction, d.GeoRestriction != nil && d.GeoRestriction.Type != GeoRestrictionNone)
+	add(v1alpha1.OptionalFieldDefaultRootObject, len(aws.StringValue(d.DefaultRootObject)) > 0)
+	return result
+}
+
+// newGeoRestriction returns the geo restriction declared by the group. If none is declared but one was managed before,
+// a restriction of type none is returned so that it's lifted, otherwise the existing one is kept.
+func newGeoRestriction(g *k8s.GeoRestriction, managedBefore bool) *GeoRestriction {
+	if g == nil && managedBefore {
+		return &GeoRestriction{Type: GeoRestrictionNone}
+	}
+	if g == nil {
+		return nil
+	}
+	return &GeoRestriction{Type: g.Type, Countries: g.Countries}
+}
+
+// newDefaultRootObject returns the default root object declared by the group. If none is declared but one was managed
+// before, an empty one is returned so that it's removed, otherwise the existing one is kept.
+func newDefaultRootObject(object *string, managedBefore bool) *string {
+	if object == nil && managedBefore {
+		return aws.String("")
+	}
+	return object
+}
+
 func pathPatternsForPath(p k8s.Path) []string {
 	if p.PathType == prefixPathType {
 		return buildPatternsForPrefix(p.PathPattern)
@@ -20,13 +20,23 @@
 package cloudfront
 
 import (
+	"context"
+	"errors"
 	"testing"
+	"time"
 
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/hashicorp/go-multierror"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/suite"
 	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
 
+	"github.com/Gympass/cdn-origin-controller/api/v1alpha1"
 	"github.com/Gympass/cdn-origin-controller/internal/config"
+	"github.com/Gympass/cdn-origin-controller/internal/k8s"
+	"github.com/Gympass/cdn-origin-controller/internal/metrics"
 )
 
 func TestRunCloudFrontServiceTestSuite(t *testing.T) {
@@ -130,3 +140,112 @@ func (s *CloudFrontServiceTestSuite) Test_s3Prefix_PrefixSetShouldConcatenateWit
 
 	s.Equal("foo/bar/group", svc.s3Prefix("group"))
 }
+
+func (s *CloudFrontServiceTestSuite) Test_validateCreation_BlockedDistributionResourceReturnsError() {
+	dist := &v1alpha1.Distribution{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "foo"}}
+
+	svc := Service{
+		Config: config.Config{
+			IsCreateBlocked: true,
+		},
+	}
+
+	desired := Distribution{CustomOrigins: []Origin{{Host: "foo.com"}}}
+	s.Error(svc.validateCreation(desired, dist))
+}
+
+func (s *CloudFrontServiceTestSuite) Test_syncDist_DryRunOfNewDistributionPlansCreation() {
+	ing := &networkingv1.Ingress{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "foo"}}
+	cdnStatus := &v1alpha1.CDNStatus{}
+	recorder := record.NewFakeRecorder(1)
+
+	svc := Service{Recorder: recorder}
+
+	desired := Distribution{CustomOrigins: []Origin{{Host: "foo.com"}}, DryRun: true}
+	planned, err := svc.syncDist(context.Background(), desired, cdnStatus, ing)
+
+	s.NoError(err)
+	s.Equal([]string{"distribution would be created"}, planned.PlannedChanges)
+	s.Equal([]string{"distribution would be created"}, cdnStatus.Status.Plan)
+	s.Contains(<-recorder.Events, "1 change(s) planned")
+}
+
+func (s *CloudFrontServiceTestSuite) Test_handleResult_RecordsOutcomeForGroup() {
+	ing := &networkingv1.Ingress{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "foo"}}
+	cdnStatus := &v1alpha1.CDNStatus{ObjectMeta: metav1.ObjectMeta{Name: "handle-result-group"}}
+	svc := Service{Recorder: record.NewFakeRecorder(10)}
+
+	s.NoError(svc.handleResult(ing, cdnStatus, &multierror.Error{}))
+	s.Error(svc.handleResult(ing, cdnStatus, multierror.Append(&multierror.Error{}, errors.New("some error"))))
+	s.Error(svc.handleResult(ing, cdnStatus, multierror.Append(&multierror.Error{}, errors.New("some error"))))
+
+	s.Equal(float64(1), testutil.ToFloat64(metrics.Reconciliations.WithLabelValues("handle-result-group", metrics.ReconcileSucceeded)))
+	s.Equal(float64(2), testutil.ToFloat64(metrics.Reconciliations.WithLabelValues("handle-result-group", metrics.ReconcileFailed)))
+}
+
+func (s *CloudFrontServiceTestSuite) Test_trackDeployment_EmitsEventOnceDeployed() {
+	recorder := record.NewFakeRecorder(10)
+	svc := Service{Recorder: recorder}
+	cdnStatus := &v1alpha1.CDNStatus{}
+	lastModified := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	svc.trackDeployment(cdnStatus, Distribution{DeploymentStatus: "InProgress", LastModifiedTime: lastModified})
+	s.Equal("InProgress", cdnStatus.Status.DeploymentStatus)
+	s.Equal(lastModified, cdnStatus.Status.LastModifiedTime.Time.UTC())
+	s.Len(recorder.Events, 0)
+
+	svc.trackDeployment(cdnStatus, Distribution{DeploymentStatus: cfDeployedStatus, LastModifiedTime: lastModified})
+	s.Equal(cfDeployedStatus, cdnStatus.Status.DeploymentStatus)
+	s.Len(recorder.Events, 1)
+	s.Contains(<-recorder.Events, reasonDeployed)
+
+	svc.trackDeployment(cdnStatus, Distribution{DeploymentStatus: cfDeployedStatus, LastModifiedTime: lastModified})
+	s.Len(recorder.Events, 0)
+}
+
+func (s *CloudFrontServiceTestSuite) Test_newCustomErrorResponses() {
+	declared := []k8s.CustomErrorResponse{{ErrorCode: 404}}
+	s.Equal([]CustomErrorResponse{{ErrorCode: 404}}, newCustomErrorResponses(declared, true))
+	s.Equal([]CustomErrorResponse{{ErrorCode: 404}}, newCustomErrorResponses(declared, false))
+	s.Equal([]CustomErrorResponse{}, newCustomErrorResponses(nil, true), "error responses managed before should be removed")
+	s.Nil(newCustomErrorResponses(nil, false), "error responses never managed should be kept")
+}
+
+func (s *CloudFrontServiceTestSuite) Test_newGeoRestriction() {
+	declared := &k8s.GeoRestriction{Type: k8s.GeoRestrictionAllow, Countries: []string{"BR"}}
+	s.Equal(&GeoRestriction{Type: GeoRestrictionAllow, Countries: []string{"BR"}}, newGeoRestriction(declared, true))
+	s.Equal(&GeoRestriction{Type: GeoRestrictionNone}, newGeoRestriction(nil, true), "restrictions managed before should be lifted")
+	s.Nil(newGeoRestriction(nil, false), "restrictions never managed should be kept")
+}
+
+func (s *CloudFrontServiceTestSuite) Test_newDefaultRootObject() {
+	s.Equal(aws.String("index.html"), newDefaultRootObject(aws.String("index.html"), true))
+	s.Equal(aws.String(""), newDefaultRootObject(nil, true), "default root objects managed before should be removed")
+	s.Nil(newDefaultRootObject(nil, false), "default root objects never managed should be kept")
+}
+
+func (s *CloudFrontServiceTestSuite) Test_declaredFields() {
+	s.Empty(declaredFields(Distribution{
+		CustomErrorResponses: []CustomErrorResponse{},
+		GeoRestriction:       &GeoRestriction{Type: GeoRestrictionNone},
+		DefaultRootObject:    aws.String(""),
+	}, &v1alpha1.CDNStatus{}))
+	s.Equal([]v1alpha1.OptionalField{
+		v1alpha1.OptionalFieldCustomErrorResponses,
+		v1alpha1.OptionalFieldGeoRestriction,
+		v1alpha1.OptionalFieldDefaultRootObject,
+	}, declaredFields(Distribution{
+		CustomErrorResponses: []CustomErrorResponse{{ErrorCode: 404}},
+		GeoRestriction:       &GeoRestriction{Type: GeoRestrictionDeny, Countries: []string{"US"}},
+		DefaultRootObject:    aws.String("index.html"),
+	}, &v1alpha1.CDNStatus{}))
+
+	stored := &v1alpha1.CDNStatus{}
+	stored.SetDeclaredFields([]v1alpha1.OptionalField{v1alpha1.OptionalFieldCustomErrorResponses})
+	s.Equal([]v1alpha1.OptionalField{v1alpha1.OptionalFieldCustomErrorResponses}, declaredFields(Distribution{
+		KeptFields: []v1alpha1.OptionalField{v1alpha1.OptionalFieldCustomErrorResponses},
+	}, stored), "kept fields declared before should remain declared")
+	s.Empty(declaredFields(Distribution{
+		KeptFields: []v1alpha1.OptionalField{v1alpha1.OptionalFieldCustomErrorResponses},
+	}, &v1alpha1.CDNStatus{}), "kept fields never declared should remain undeclared")
+}
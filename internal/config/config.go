@@ -22,11 +22,14 @@ package config
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	awscloudfront "github.com/aws/aws-sdk-go/service/cloudfront"
 	"github.com/spf13/viper"
-	networkingv1 "k8s.io/api/networking/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/Gympass/cdn-origin-controller/internal/strhelper"
 )
 
 const (
@@ -46,8 +49,16 @@ const (
 	cfDefaultCacheRequestPolicyIDKey              = "cf_default_cache_request_policy_id"
 	cfDefaultPublicOriginAccessRequestPolicyIDKey = "cf_default_public_origin_access_request_policy_id"
 	cfDefaultBucketOriginAccessRequestPolicyIDKey = "cf_default_bucket_origin_access_request_policy_id"
+	cfDefaultOriginMinSSLProtocolKey              = "cf_default_origin_min_ssl_protocol"
 	createBlockedKey                              = "block_creation"
 	createBlockedAllowListKey                     = "block_creation_allow_list"
+	enableWebhookKey                              = "enable_webhook"
+	dryRunKey                                     = "dry_run"
+	driftDetectionIntervalKey                     = "drift_detection_interval"
+	driftDetectionReportOnlyKey                   = "drift_detection_report_only"
+	garbageCollectionIntervalKey                  = "garbage_collection_interval"
+	garbageCollectionDeletionKey                  = "enable_garbage_collection_deletion"
+	controllerIDKey                               = "controller_id"
 )
 
 func init() {
@@ -79,7 +90,16 @@ func initDefaults() {
 	// https://docs.aws.amazon.com/AmazonCloudFront/latest/DeveloperGuide/using-managed-origin-request-policies.html#managed-origin-request-policy-cors-s3
 	// Default is CORS S3
 	viper.SetDefault(cfDefaultBucketOriginAccessRequestPolicyIDKey, "88a5eaf4-2fd4-4709-b370-b4c650ea3fcf")
+	// SSLv3 and TLSv1 are considered insecure, so they're not used to connect to origins unless configured otherwise
+	viper.SetDefault(cfDefaultOriginMinSSLProtocolKey, awscloudfront.SslProtocolTlsv11)
 	viper.SetDefault(createBlockedKey, false)
+	viper.SetDefault(enableWebhookKey, false)
+	viper.SetDefault(dryRunKey, false)
+	viper.SetDefault(driftDetectionIntervalKey, "0s")
+	viper.SetDefault(driftDetectionReportOnlyKey, false)
+	viper.SetDefault(garbageCollectionIntervalKey, "0s")
+	viper.SetDefault(garbageCollectionDeletionKey, false)
+	viper.SetDefault(controllerIDKey, "")
 
 	viper.AutomaticEnv()
 }
@@ -121,10 +141,31 @@ type Config struct {
 	CloudFrontDefaultPublicOriginAccessRequestPolicyID string
 	// CloudFrontDefaultBucketOriginAccessRequestPolicyID is the default request policy for bucket origin access.
 	CloudFrontDefaultBucketOriginAccessRequestPolicyID string
+	// CloudFrontDefaultOriginMinSSLProtocol is the oldest SSL/TLS protocol CloudFront may use for HTTPS connections to
+	// origins which don't specify one.
+	CloudFrontDefaultOriginMinSSLProtocol string
 	// IsCreateBlocked configure whether to block creation of new CloudFront distributions. Useful when phasing out clusters or accounts, for example
 	IsCreateBlocked bool
-	// CreateAllowList holds a list of Ingresses namespaced names for which we should allow creation, even if IsCreateBlocked is true
+	// CreateAllowList holds a list of Ingresses (or Distributions) namespaced names for which we should allow creation, even if IsCreateBlocked is true
 	CreateAllowList []types.NamespacedName
+	// WebhookEnabled configures whether the validating admission webhook for Ingresses should be served
+	WebhookEnabled bool
+	// DryRun configures the controller to only plan changes to CloudFront distributions, reporting them instead of applying them
+	DryRun bool
+	// DriftDetectionInterval is how often distributions are compared with their desired state to detect changes made outside
+	// of the controller. Zero disables drift detection.
+	DriftDetectionInterval time.Duration
+	// DriftDetectionReportOnly configures drift detection to only report changes made outside of the controller, instead of reverting them
+	DriftDetectionReportOnly bool
+	// GarbageCollectionInterval is how often AWS resources owned by the controller are checked for groups which no
+	// longer exist in the cluster. Zero disables garbage collection.
+	GarbageCollectionInterval time.Duration
+	// GarbageCollectionDeletionEnabled configures whether orphaned AWS resources are deleted, instead of only reported.
+	// It requires DeletionEnabled.
+	GarbageCollectionDeletionEnabled bool
+	// ControllerID identifies the controller among others sharing the same AWS account. Distributions and OACs are
+	// marked with it, and garbage collection only considers resources marked with it. Required by garbage collection.
+	ControllerID string
 }
 
 // TLSIsEnabled returns whether TLS is enabled
@@ -132,15 +173,15 @@ func (c Config) TLSIsEnabled() bool {
 	return len(c.CloudFrontSecurityPolicy) > 0
 }
 
-// IsCreationAllowed returns whether the creation of a new CloudFront distribution for the given Ingress should be allowed
-func (c Config) IsCreationAllowed(ing *networkingv1.Ingress) bool {
+// IsCreationAllowed returns whether the creation of a new CloudFront distribution for the given object should be allowed
+func (c Config) IsCreationAllowed(obj client.Object) bool {
 	if !c.IsCreateBlocked {
 		return true
 	}
 
 	ingName := types.NamespacedName{
-		Namespace: ing.Namespace,
-		Name:      ing.Name,
+		Namespace: obj.GetNamespace(),
+		Name:      obj.GetName(),
 	}
 
 	for _, candidate := range c.CreateAllowList {
@@ -165,6 +206,23 @@ func Parse() (Config, error) {
 		return Config{}, fmt.Errorf("invalid %q: %v", createBlockedAllowListKey, err)
 	}
 
+	minSSLProtocol := viper.GetString(cfDefaultOriginMinSSLProtocolKey)
+	if !strhelper.Contains(awscloudfront.SslProtocol_Values(), minSSLProtocol) {
+		return Config{}, fmt.Errorf("invalid %q: %q is not one of %v",
+			cfDefaultOriginMinSSLProtocolKey, minSSLProtocol, awscloudfront.SslProtocol_Values())
+	}
+
+	gcInterval := viper.GetDuration(garbageCollectionIntervalKey)
+	controllerID := viper.GetString(controllerIDKey)
+	if gcInterval > 0 && controllerID == "" {
+		return Config{}, fmt.Errorf("%q must be set to enable garbage collection", controllerIDKey)
+	}
+
+	gcDeletionEnabled := viper.GetBool(garbageCollectionDeletionKey)
+	if gcDeletionEnabled && !viper.GetBool(enableDeletionKey) {
+		return Config{}, fmt.Errorf("%q requires %q", garbageCollectionDeletionKey, enableDeletionKey)
+	}
+
 	return Config{
 		LogLevel:                              logLvl,
 		DevMode:                               devMode,
@@ -182,8 +240,16 @@ func Parse() (Config, error) {
 		CloudFrontDefaultCacheRequestPolicyID: viper.GetString(cfDefaultCacheRequestPolicyIDKey),
 		IsCreateBlocked:                       viper.GetBool(createBlockedKey),
 		CreateAllowList:                       createAllowList,
+		WebhookEnabled:                        viper.GetBool(enableWebhookKey),
+		DryRun:                                viper.GetBool(dryRunKey),
+		DriftDetectionInterval:                viper.GetDuration(driftDetectionIntervalKey),
+		DriftDetectionReportOnly:              viper.GetBool(driftDetectionReportOnlyKey),
+		GarbageCollectionInterval:             gcInterval,
+		GarbageCollectionDeletionEnabled:      gcDeletionEnabled,
+		ControllerID:                          controllerID,
 		CloudFrontDefaultPublicOriginAccessRequestPolicyID: viper.GetString(cfDefaultPublicOriginAccessRequestPolicyIDKey),
 		CloudFrontDefaultBucketOriginAccessRequestPolicyID: viper.GetString(cfDefaultBucketOriginAccessRequestPolicyIDKey),
+		CloudFrontDefaultOriginMinSSLProtocol:              minSSLProtocol,
 	}, nil
 }
 
@@ -21,6 +21,7 @@ package config
 
 import (
 	"testing"
+	"time"
 
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/suite"
@@ -142,3 +143,89 @@ func (s *ConfigTestSuite) TestIsCreationAllowed_IngressNotOnAllowListWithBlocked
 		},
 	}))
 }
+
+func (s *ConfigTestSuite) TestParse_DefaultToWebhookDisabled() {
+	cfg, err := Parse()
+
+	s.NoError(err)
+	s.False(cfg.WebhookEnabled)
+}
+
+func (s *ConfigTestSuite) TestParse_DefaultToDryRunDisabled() {
+	cfg, err := Parse()
+
+	s.NoError(err)
+	s.False(cfg.DryRun)
+}
+
+func (s *ConfigTestSuite) TestParse_DefaultToDriftDetectionDisabled() {
+	cfg, err := Parse()
+
+	s.NoError(err)
+	s.Zero(cfg.DriftDetectionInterval)
+	s.False(cfg.DriftDetectionReportOnly)
+}
+
+func (s *ConfigTestSuite) TestParse_DriftDetectionInterval() {
+	viper.Set(driftDetectionIntervalKey, "15m")
+
+	cfg, err := Parse()
+
+	s.NoError(err)
+	s.Equal(15*time.Minute, cfg.DriftDetectionInterval)
+}
+
+func (s *ConfigTestSuite) TestParse_DefaultToGarbageCollectionDisabled() {
+	cfg, err := Parse()
+
+	s.NoError(err)
+	s.Zero(cfg.GarbageCollectionInterval)
+}
+
+func (s *ConfigTestSuite) TestParse_GarbageCollectionInterval() {
+	viper.Set(garbageCollectionIntervalKey, "1h")
+	viper.Set(controllerIDKey, "cluster-a")
+
+	cfg, err := Parse()
+
+	s.NoError(err)
+	s.Equal(time.Hour, cfg.GarbageCollectionInterval)
+	s.Equal("cluster-a", cfg.ControllerID)
+	s.False(cfg.GarbageCollectionDeletionEnabled)
+}
+
+func (s *ConfigTestSuite) TestParse_GarbageCollectionRequiresControllerID() {
+	viper.Set(garbageCollectionIntervalKey, "1h")
+
+	_, err := Parse()
+
+	s.Error(err)
+}
+
+func (s *ConfigTestSuite) TestParse_GarbageCollectionDeletion() {
+	viper.Set(garbageCollectionDeletionKey, "true")
+
+	_, err := Parse()
+	s.Error(err, "deleting orphaned resources should require deletion to be enabled")
+
+	viper.Set(enableDeletionKey, "true")
+
+	cfg, err := Parse()
+	s.NoError(err)
+	s.True(cfg.GarbageCollectionDeletionEnabled)
+}
+
+func (s *ConfigTestSuite) TestParse_DefaultOriginMinSSLProtocolDisablesSSLv3AndTLSv1() {
+	cfg, err := Parse()
+
+	s.NoError(err)
+	s.Equal("TLSv1.1", cfg.CloudFrontDefaultOriginMinSSLProtocol)
+}
+
+func (s *ConfigTestSuite) TestParse_InvalidDefaultOriginMinSSLProtocol() {
+	viper.Set(cfDefaultOriginMinSSLProtocolKey, "TLSv1.3")
+
+	_, err := Parse()
+
+	s.Error(err)
+}
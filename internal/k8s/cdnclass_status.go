@@ -0,0 +1,135 @@
+// Copyright (c) 2023 GPBR Participacoes LTDA.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/Gympass/cdn-origin-controller/api/v1alpha1"
+)
+
+// CDNClassStatusReporter reports how many groups use a CDNClass and whether they are in sync through its conditions
+type CDNClassStatusReporter struct {
+	k8sClient client.Client
+}
+
+// NewCDNClassStatusReporter creates a CDNClassStatusReporter
+func NewCDNClassStatusReporter(k8sClient client.Client) *CDNClassStatusReporter {
+	return &CDNClassStatusReporter{k8sClient: k8sClient}
+}
+
+// Report updates the conditions of the given CDNClass based on the groups currently using it.
+// The CDNClass is only updated if its conditions changed.
+func (r *CDNClassStatusReporter) Report(ctx context.Context, class *v1alpha1.CDNClass) error {
+	groups, err := r.groupsUsing(ctx, class.Name)
+	if err != nil {
+		return err
+	}
+
+	outOfSync, err := r.outOfSync(ctx, groups)
+	if err != nil {
+		return err
+	}
+
+	observed := class.Status.DeepCopy()
+	setCDNClassConditions(class, groups, outOfSync)
+	if equality.Semantic.DeepEqual(observed, &class.Status) {
+		return nil
+	}
+
+	if err := r.k8sClient.Status().Update(ctx, class); err != nil {
+		return fmt.Errorf("updating CDNClass status: %v", err)
+	}
+	return nil
+}
+
+// groupsUsing returns the sorted groups of all Ingresses and Distributions using the given class.
+// Released Ingresses and Distributions are ignored, since the controller no longer manages their groups.
+func (r *CDNClassStatusReporter) groupsUsing(ctx context.Context, className string) ([]string, error) {
+	groups := sets.NewString()
+
+	ingList := &networkingv1.IngressList{}
+	if err := r.k8sClient.List(ctx, ingList); err != nil {
+		return nil, fmt.Errorf("listing Ingresses: %v", err)
+	}
+	for i := range ingList.Items {
+		ing := &ingList.Items[i]
+		if CDNClassAnnotationValue(ing) == className && HasGroupAnnotation(ing) && ing.DeletionTimestamp == nil && !release(ing) {
+			groups.Insert(groupAnnotationValue(ing))
+		}
+	}
+
+	distList := &v1alpha1.DistributionList{}
+	if err := r.k8sClient.List(ctx, distList); err != nil {
+		return nil, fmt.Errorf("listing Distributions: %v", err)
+	}
+	for i := range distList.Items {
+		dist := &distList.Items[i]
+		if dist.Spec.Class == className && dist.DeletionTimestamp == nil && !release(dist) {
+			groups.Insert(dist.Spec.Group)
+		}
+	}
+
+	return groups.List(), nil
+}
+
+// outOfSync returns the groups whose CDNStatus does not exist yet or is not ready
+func (r *CDNClassStatusReporter) outOfSync(ctx context.Context, groups []string) ([]string, error) {
+	var result []string
+	for _, group := range groups {
+		cdnStatus := &v1alpha1.CDNStatus{}
+		err := r.k8sClient.Get(ctx, client.ObjectKey{Name: group}, cdnStatus)
+		if err != nil && !k8serrors.IsNotFound(err) {
+			return nil, fmt.Errorf("fetching CDNStatus %s: %v", group, err)
+		}
+		if err != nil || !cdnStatus.IsConditionTrue(v1alpha1.ConditionReady) {
+			result = append(result, group)
+		}
+	}
+	return result, nil
+}
+
+func setCDNClassConditions(class *v1alpha1.CDNClass, groups, outOfSync []string) {
+	if len(groups) == 0 {
+		class.SetCondition(v1alpha1.CDNClassConditionInUse, metav1.ConditionFalse, "NoGroups", "No groups use this class")
+		class.SetCondition(v1alpha1.CDNClassConditionInSync, metav1.ConditionTrue, "NoGroups", "No groups use this class")
+		return
+	}
+
+	class.SetCondition(v1alpha1.CDNClassConditionInUse, metav1.ConditionTrue, "GroupsFound",
+		fmt.Sprintf("Used by %d group(s): %s", len(groups), strings.Join(groups, ", ")))
+
+	if len(outOfSync) == 0 {
+		class.SetCondition(v1alpha1.CDNClassConditionInSync, metav1.ConditionTrue, "AllGroupsInSync",
+			fmt.Sprintf("All %d group(s) using this class are in sync", len(groups)))
+		return
+	}
+	class.SetCondition(v1alpha1.CDNClassConditionInSync, metav1.ConditionFalse, "GroupsOutOfSync",
+		fmt.Sprintf("%d of %d group(s) using this class are not in sync: %s", len(outOfSync), len(groups), strings.Join(outOfSync, ", ")))
+}
@@ -0,0 +1,133 @@
+// Copyright (c) 2023 GPBR Participacoes LTDA.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package k8s
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/Gympass/cdn-origin-controller/api/v1alpha1"
+)
+
+func TestRunCDNClassStatusTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, &CDNClassStatusTestSuite{})
+}
+
+type CDNClassStatusTestSuite struct {
+	suite.Suite
+}
+
+func (s *CDNClassStatusTestSuite) newClient(objs ...client.Object) client.Client {
+	scheme := runtime.NewScheme()
+	s.NoError(clientgoscheme.AddToScheme(scheme))
+	s.NoError(v1alpha1.AddToScheme(scheme))
+
+	return fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(objs...).
+		WithStatusSubresource(&v1alpha1.CDNClass{}).
+		Build()
+}
+
+func newClassIngress(name, class, group string) *networkingv1.Ingress {
+	return &networkingv1.Ingress{ObjectMeta: metav1.ObjectMeta{
+		Name:        name,
+		Namespace:   "default",
+		Annotations: map[string]string{CDNClassAnnotation: class, CDNGroupAnnotation: group},
+	}}
+}
+
+func newReadyCDNStatus(group string, ready bool) *v1alpha1.CDNStatus {
+	status := &v1alpha1.CDNStatus{ObjectMeta: metav1.ObjectMeta{Name: group}}
+	if ready {
+		status.SetCondition(v1alpha1.ConditionReady, metav1.ConditionTrue, "Ready", "CDN is ready")
+	} else {
+		status.SetCondition(v1alpha1.ConditionReady, metav1.ConditionFalse, "Failed", "some error")
+	}
+	return status
+}
+
+func (s *CDNClassStatusTestSuite) report(k8sClient client.Client) *v1alpha1.CDNClass {
+	class := &v1alpha1.CDNClass{}
+	s.NoError(k8sClient.Get(context.Background(), client.ObjectKey{Name: "class"}, class))
+	s.NoError(NewCDNClassStatusReporter(k8sClient).Report(context.Background(), class))
+
+	got := &v1alpha1.CDNClass{}
+	s.NoError(k8sClient.Get(context.Background(), client.ObjectKey{Name: "class"}, got))
+	return got
+}
+
+func (s *CDNClassStatusTestSuite) TestReport_NoGroups() {
+	k8sClient := s.newClient(
+		&v1alpha1.CDNClass{ObjectMeta: metav1.ObjectMeta{Name: "class"}},
+		newClassIngress("other", "other-class", "group"),
+	)
+
+	got := s.report(k8sClient)
+	s.True(meta.IsStatusConditionFalse(got.Status.Conditions, v1alpha1.CDNClassConditionInUse))
+	s.True(meta.IsStatusConditionTrue(got.Status.Conditions, v1alpha1.CDNClassConditionInSync))
+}
+
+func (s *CDNClassStatusTestSuite) TestReport_AllGroupsInSync() {
+	k8sClient := s.newClient(
+		&v1alpha1.CDNClass{ObjectMeta: metav1.ObjectMeta{Name: "class"}},
+		newClassIngress("ing1", "class", "foo"),
+		newClassIngress("ing2", "class", "foo"),
+		&v1alpha1.Distribution{
+			ObjectMeta: metav1.ObjectMeta{Name: "dist", Namespace: "default"},
+			Spec:       v1alpha1.DistributionSpec{Class: "class", Group: "bar"},
+		},
+		newReadyCDNStatus("foo", true),
+		newReadyCDNStatus("bar", true),
+	)
+
+	got := s.report(k8sClient)
+	inUse := meta.FindStatusCondition(got.Status.Conditions, v1alpha1.CDNClassConditionInUse)
+	s.Equal(metav1.ConditionTrue, inUse.Status)
+	s.Equal("Used by 2 group(s): bar, foo", inUse.Message)
+	s.True(meta.IsStatusConditionTrue(got.Status.Conditions, v1alpha1.CDNClassConditionInSync))
+}
+
+func (s *CDNClassStatusTestSuite) TestReport_GroupsOutOfSync() {
+	k8sClient := s.newClient(
+		&v1alpha1.CDNClass{ObjectMeta: metav1.ObjectMeta{Name: "class"}},
+		newClassIngress("ing1", "class", "foo"),
+		newClassIngress("ing2", "class", "bar"),
+		newClassIngress("ing3", "class", "baz"),
+		newReadyCDNStatus("foo", true),
+		newReadyCDNStatus("bar", false),
+	)
+
+	got := s.report(k8sClient)
+	inSync := meta.FindStatusCondition(got.Status.Conditions, v1alpha1.CDNClassConditionInSync)
+	s.Equal(metav1.ConditionFalse, inSync.Status)
+	s.Equal("GroupsOutOfSync", inSync.Reason)
+	s.Equal("2 of 3 group(s) using this class are not in sync: bar, baz", inSync.Message)
+}
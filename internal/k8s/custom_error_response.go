@@ -0,0 +1,144 @@
+// Copyright (c) 2023 GPBR Participacoes LTDA.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package k8s
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+	networkingv1 "k8s.io/api/networking/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const cfCustomErrorResponsesAnnotation = "cdn-origin-controller.gympass.com/cf.custom-error-responses"
+
+// customErrorCodes are the origin status codes CloudFront supports custom error responses for
+var customErrorCodes = []int64{400, 403, 404, 405, 414, 416, 500, 501, 502, 503, 504}
+
+// customResponseCodes are the status codes CloudFront may return to viewers along with a custom error page
+var customResponseCodes = []int64{200, 400, 403, 404, 405, 414, 416, 500, 501, 502, 503, 504}
+
+// CustomErrorResponse represents how CloudFront responds to viewers when an origin returns an error
+type CustomErrorResponse struct {
+	// ErrorCode is the status code returned by the origin
+	ErrorCode int64 `yaml:"errorCode"`
+	// ResponsePagePath is the path of the page CloudFront returns to viewers instead of the origin's response, if any
+	ResponsePagePath string `yaml:"responsePagePath"`
+	// ResponseCode is the status code CloudFront returns to viewers along with the custom error page, if any
+	ResponseCode int64 `yaml:"responseCode"`
+	// CachingMinTTL is how long, in seconds, CloudFront caches the error. CloudFront's default is used if nil
+	CachingMinTTL *int64 `yaml:"cachingMinTTL"`
+}
+
+// Validate returns an error if the CustomErrorResponse is not supported by CloudFront
+func (r CustomErrorResponse) Validate() error {
+	if !containsStatusCode(customErrorCodes, r.ErrorCode) {
+		return fmt.Errorf("invalid error code %d. Valid values: %v", r.ErrorCode, customErrorCodes)
+	}
+
+	if len(r.ResponsePagePath) > 0 && !strings.HasPrefix(r.ResponsePagePath, "/") {
+		return fmt.Errorf("invalid response page path %q for error code %d: it must start with a slash", r.ResponsePagePath, r.ErrorCode)
+	}
+
+	if r.ResponseCode != 0 && !containsStatusCode(customResponseCodes, r.ResponseCode) {
+		return fmt.Errorf("invalid response code %d for error code %d. Valid values: %v", r.ResponseCode, r.ErrorCode, customResponseCodes)
+	}
+
+	if (len(r.ResponsePagePath) > 0) != (r.ResponseCode != 0) {
+		return fmt.Errorf("error code %d: the response page path and the response code must be specified together", r.ErrorCode)
+	}
+
+	if r.CachingMinTTL != nil && *r.CachingMinTTL < 0 {
+		return fmt.Errorf("invalid caching minimum TTL %d for error code %d: it must not be negative", *r.CachingMinTTL, r.ErrorCode)
+	}
+
+	return nil
+}
+
+func (r CustomErrorResponse) equal(other CustomErrorResponse) bool {
+	sameTTL := r.CachingMinTTL == nil && other.CachingMinTTL == nil ||
+		r.CachingMinTTL != nil && other.CachingMinTTL != nil && *r.CachingMinTTL == *other.CachingMinTTL
+	return sameTTL && r.ErrorCode == other.ErrorCode && r.ResponsePagePath == other.ResponsePagePath &&
+		r.ResponseCode == other.ResponseCode
+}
+
+// validateCustomErrorResponses returns an error if any of the CustomErrorResponses is invalid
+// or if more than one of them is set for the same error code
+func validateCustomErrorResponses(responses []CustomErrorResponse) error {
+	seen := make(map[int64]bool)
+	for _, r := range responses {
+		if err := r.Validate(); err != nil {
+			return err
+		}
+		if seen[r.ErrorCode] {
+			return fmt.Errorf("error code %d specified more than once", r.ErrorCode)
+		}
+		seen[r.ErrorCode] = true
+	}
+	return nil
+}
+
+func customErrorResponses(obj client.Object) ([]CustomErrorResponse, error) {
+	val, ok := obj.GetAnnotations()[cfCustomErrorResponsesAnnotation]
+	if !ok {
+		return nil, nil
+	}
+
+	var responses []CustomErrorResponse
+	if err := yaml.Unmarshal([]byte(val), &responses); err != nil {
+		return nil, fmt.Errorf("parsing annotation %q: %v", cfCustomErrorResponsesAnnotation, err)
+	}
+
+	if err := validateCustomErrorResponses(responses); err != nil {
+		return nil, fmt.Errorf("annotation %q: %v", cfCustomErrorResponsesAnnotation, err)
+	}
+	return responses, nil
+}
+
+// ValidateIngressCustomErrorResponses returns an error if the Ingress configures custom error responses which are
+// invalid or conflicting
+func ValidateIngressCustomErrorResponses(ing *networkingv1.Ingress) error {
+	_, err := customErrorResponses(ing)
+	return err
+}
+
+// mergedCustomErrorResponses returns the CustomErrorResponses of all CDNIngresses sorted by error code.
+// CDNIngresses may specify the same CustomErrorResponse, but not different ones for the same error code.
+func mergedCustomErrorResponses(ingresses []CDNIngress) ([]CustomErrorResponse, error) {
+	byErrorCode := make(map[int64]CustomErrorResponse)
+	for _, ing := range ingresses {
+		for _, r := range ing.UnmergedCustomErrorResponses {
+			existing, ok := byErrorCode[r.ErrorCode]
+			if ok && !existing.equal(r) {
+				return nil, fmt.Errorf("different responses specified for error code %d", r.ErrorCode)
+			}
+			byErrorCode[r.ErrorCode] = r
+		}
+	}
+
+	var result []CustomErrorResponse
+	for _, r := range byErrorCode {
+		result = append(result, r)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ErrorCode < result[j].ErrorCode })
+	return result, nil
+}
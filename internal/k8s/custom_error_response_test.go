@@ -0,0 +1,157 @@
+// Copyright (c) 2023 GPBR Participacoes LTDA.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package k8s
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/suite"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestRunCustomErrorResponseTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, &customErrorResponseTestSuite{})
+}
+
+type customErrorResponseTestSuite struct {
+	suite.Suite
+}
+
+func (s *customErrorResponseTestSuite) TestValidate_Valid() {
+	testCases := []struct {
+		name string
+		in   CustomErrorResponse
+	}{
+		{name: "Only error code", in: CustomErrorResponse{ErrorCode: 404}},
+		{name: "Only TTL", in: CustomErrorResponse{ErrorCode: 500, CachingMinTTL: aws.Int64(0)}},
+		{
+			name: "All settings",
+			in:   CustomErrorResponse{ErrorCode: 404, ResponsePagePath: "/index.html", ResponseCode: 200, CachingMinTTL: aws.Int64(60)},
+		},
+	}
+
+	for _, tc := range testCases {
+		s.NoError(tc.in.Validate(), "test: %s", tc.name)
+	}
+}
+
+func (s *customErrorResponseTestSuite) TestValidate_Invalid() {
+	testCases := []struct {
+		name string
+		in   CustomErrorResponse
+	}{
+		{name: "No error code", in: CustomErrorResponse{}},
+		{name: "Unsupported error code", in: CustomErrorResponse{ErrorCode: 418}},
+		{name: "Relative page path", in: CustomErrorResponse{ErrorCode: 404, ResponsePagePath: "404.html", ResponseCode: 404}},
+		{name: "Unsupported response code", in: CustomErrorResponse{ErrorCode: 404, ResponsePagePath: "/404.html", ResponseCode: 201}},
+		{name: "Page path without response code", in: CustomErrorResponse{ErrorCode: 404, ResponsePagePath: "/404.html"}},
+		{name: "Response code without page path", in: CustomErrorResponse{ErrorCode: 404, ResponseCode: 404}},
+		{name: "Negative TTL", in: CustomErrorResponse{ErrorCode: 404, CachingMinTTL: aws.Int64(-1)}},
+	}
+
+	for _, tc := range testCases {
+		s.Error(tc.in.Validate(), "test: %s", tc.name)
+	}
+}
+
+func (s *customErrorResponseTestSuite) Test_validateCustomErrorResponses_DuplicateErrorCode() {
+	err := validateCustomErrorResponses([]CustomErrorResponse{{ErrorCode: 404}, {ErrorCode: 404, CachingMinTTL: aws.Int64(0)}})
+	s.Error(err)
+}
+
+func (s *customErrorResponseTestSuite) Test_customErrorResponses_FromAnnotation() {
+	ing := &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{
+				cfCustomErrorResponsesAnnotation: `
+- errorCode: 404
+  responsePagePath: /index.html
+  responseCode: 200
+- errorCode: 503
+  cachingMinTTL: 0
+`,
+			},
+		},
+	}
+
+	got, err := customErrorResponses(ing)
+	s.NoError(err)
+	s.Equal([]CustomErrorResponse{
+		{ErrorCode: 404, ResponsePagePath: "/index.html", ResponseCode: 200},
+		{ErrorCode: 503, CachingMinTTL: aws.Int64(0)},
+	}, got)
+}
+
+func (s *customErrorResponseTestSuite) Test_customErrorResponses_NoAnnotation() {
+	got, err := customErrorResponses(&networkingv1.Ingress{})
+	s.NoError(err)
+	s.Nil(got)
+}
+
+func (s *customErrorResponseTestSuite) Test_customErrorResponses_InvalidAnnotation() {
+	testCases := []struct {
+		name  string
+		value string
+	}{
+		{name: "Invalid YAML", value: "errorCode: 404"},
+		{name: "Invalid response", value: "- errorCode: 200"},
+		{name: "Duplicate error code", value: "- errorCode: 404\n- errorCode: 404"},
+	}
+
+	for _, tc := range testCases {
+		ing := &networkingv1.Ingress{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{cfCustomErrorResponsesAnnotation: tc.value}}}
+		_, err := customErrorResponses(ing)
+		s.Error(err, "test: %s", tc.name)
+	}
+}
+
+func (s *customErrorResponseTestSuite) Test_mergedCustomErrorResponses() {
+	ingresses := []CDNIngress{
+		{UnmergedCustomErrorResponses: []CustomErrorResponse{{ErrorCode: 503, CachingMinTTL: aws.Int64(0)}}},
+		{UnmergedCustomErrorResponses: []CustomErrorResponse{
+			{ErrorCode: 404, ResponsePagePath: "/index.html", ResponseCode: 200},
+			{ErrorCode: 503, CachingMinTTL: aws.Int64(0)},
+		}},
+		{},
+	}
+
+	got, err := mergedCustomErrorResponses(ingresses)
+	s.NoError(err)
+	s.Equal([]CustomErrorResponse{
+		{ErrorCode: 404, ResponsePagePath: "/index.html", ResponseCode: 200},
+		{ErrorCode: 503, CachingMinTTL: aws.Int64(0)},
+	}, got)
+}
+
+func (s *customErrorResponseTestSuite) Test_mergedCustomErrorResponses_Conflicting() {
+	ingresses := []CDNIngress{
+		{UnmergedCustomErrorResponses: []CustomErrorResponse{{ErrorCode: 503, CachingMinTTL: aws.Int64(0)}}},
+		{UnmergedCustomErrorResponses: []CustomErrorResponse{{ErrorCode: 503}}},
+	}
+
+	_, err := mergedCustomErrorResponses(ingresses)
+	s.Error(err)
+
+	_, err = NewSharedIngressParams(ingresses)
+	s.ErrorIs(err, errSharedParamsConflictingErrors)
+}
@@ -0,0 +1,344 @@
+// Copyright (c) 2023 GPBR Participacoes LTDA.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package k8s
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/Gympass/cdn-origin-controller/api/v1alpha1"
+)
+
+// NewCDNIngressesFromDistribution creates one CDNIngress for each origin declared in a Distribution
+func NewCDNIngressesFromDistribution(dist *v1alpha1.Distribution, class CDNClass) ([]CDNIngress, error) {
+	if len(dist.Spec.Origins) == 0 {
+		return nil, errors.New("the distribution must have at least one origin")
+	}
+
+	errorResponses := distributionCustomErrorResponses(dist.Spec.CustomErrorResponses)
+	if err := validateCustomErrorResponses(errorResponses); err != nil {
+		return nil, fmt.Errorf("custom error responses: %v", err)
+	}
+
+	geo, err := distributionGeoRestriction(dist.Spec.GeoRestriction)
+	if err != nil {
+		return nil, fmt.Errorf("geo restriction: %v", err)
+	}
+
+	if dist.Spec.DefaultRootObject != nil {
+		if err := validateDefaultRootObject(*dist.Spec.DefaultRootObject); err != nil {
+			return nil, err
+		}
+	}
+
+	var result []CDNIngress
+	for _, o := range dist.Spec.Origins {
+		paths, err := distributionPaths(o)
+		if err != nil {
+			return nil, fmt.Errorf("origin %q: %v", o.Host, err)
+		}
+
+		protocol := OriginProtocol{
+			Policy:           o.OriginProtocolPolicy,
+			HTTPPort:         o.HTTPPort,
+			HTTPSPort:        o.HTTPSPort,
+			KeepaliveTimeout: o.KeepaliveTimeout,
+			MinSSLProtocol:   o.MinSSLProtocol,
+		}
+		if err := protocol.Validate(); err != nil {
+			return nil, fmt.Errorf("origin %q: %v", o.Host, err)
+		}
+		if err := validateOriginPath(o.OriginPath); err != nil {
+			return nil, fmt.Errorf("origin %q: %v", o.Host, err)
+		}
+
+		fo, err := distributionFailover(o.Failover)
+		if err != nil {
+			return nil, fmt.Errorf("origin %q: %v", o.Host, err)
+		}
+
+		originAccess := o.OriginAccess
+		if len(originAccess) == 0 {
+			originAccess = CFUserOriginAccessPublic
+		}
+
+		result = append(result, CDNIngress{
+			NamespacedName: types.NamespacedName{
+				Namespace: dist.Namespace,
+				Name:      dist.Name,
+			},
+			OriginHost:           o.Host,
+			OriginPath:           o.OriginPath,
+			Group:                dist.Spec.Group,
+			UnmergedPaths:        paths,
+			OriginReqPolicy:      o.OriginRequestPolicy,
+			OriginHeaders:        o.Headers,
+			CachePolicy:          o.CachePolicy,
+			ResponsePolicy:       o.ResponsePolicy,
+			OriginRespTimeout:    o.ResponseTimeout,
+			OriginProtocol:       protocol,
+			Failover:             fo,
+			AlternateDomainNames: dist.Spec.AlternateDomainNames,
+			UnmergedWebACLARN:    dist.Spec.WebACLARN,
+			IsBeingRemoved:       dist.DeletionTimestamp != nil,
+			OriginAccess:         originAccess,
+			Class:                class,
+			Tags:                 dist.Spec.Tags,
+			DryRun:               dryRun(dist),
+			Release:              release(dist),
+
+			UnmergedAdoptDistributionID:  dist.Spec.AdoptDistributionID,
+			UnmergedCustomErrorResponses: errorResponses,
+			UnmergedGeoRestriction:       geo,
+			UnmergedDefaultRootObject:    dist.Spec.DefaultRootObject,
+			DefaultBehavior:              o.DefaultBehavior,
+		})
+	}
+
+	return result, nil
+}
+
+func distributionCustomErrorResponses(responses []v1alpha1.CustomErrorResponse) []CustomErrorResponse {
+	var result []CustomErrorResponse
+	for _, r := range responses {
+		result = append(result, CustomErrorResponse{
+			ErrorCode:        r.ErrorCode,
+			ResponsePagePath: r.ResponsePagePath,
+			ResponseCode:     r.ResponseCode,
+			CachingMinTTL:    r.CachingMinTTL,
+		})
+	}
+	return result
+}
+
+func distributionGeoRestriction(g *v1alpha1.GeoRestriction) (*GeoRestriction, error) {
+	if g == nil {
+		return nil, nil
+	}
+
+	var countries []string
+	for _, c := range g.Countries {
+		countries = append(countries, string(c))
+	}
+	return normalizedGeoRestriction(&GeoRestriction{Type: string(g.Type), Countries: countries})
+}
+
+func distributionFailover(f *v1alpha1.DistributionFailover) (*Failover, error) {
+	if f == nil {
+		return nil, nil
+	}
+
+	var codes []int64
+	for _, code := range f.StatusCodes {
+		codes = append(codes, int64(code))
+	}
+	return normalizedFailover(&Failover{
+		Host:         f.Host,
+		OriginPath:   f.OriginPath,
+		OriginAccess: f.OriginAccess,
+		StatusCodes:  codes,
+	})
+}
+
+func distributionPaths(o v1alpha1.DistributionOrigin) ([]Path, error) {
+	var paths []Path
+	for _, b := range o.Behaviors {
+		fa := newFAFromDistribution(b.FunctionAssociations)
+		if err := fa.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid function association at path %q: %v", b.Path, err)
+		}
+		methods, err := Methods{Allowed: httpMethods(b.AllowedMethods), Cached: httpMethods(b.CachedMethods)}.normalized()
+		if err != nil {
+			return nil, fmt.Errorf("invalid methods at path %q: %v", b.Path, err)
+		}
+		paths = append(paths, Path{
+			PathPattern:          b.Path,
+			FunctionAssociations: fa,
+			Methods:              methods,
+			ViewerProtocolPolicy: b.ViewerProtocolPolicy,
+		})
+	}
+	return paths, nil
+}
+
+func newFAFromDistribution(in *v1alpha1.FunctionAssociations) FunctionAssociations {
+	fa := FunctionAssociations{}
+	if in == nil {
+		return fa
+	}
+
+	if in.ViewerRequest != nil {
+		fa.ViewerRequest = &ViewerRequestFunction{
+			ViewerFunction: ViewerFunction{
+				ARN:          in.ViewerRequest.ARN,
+				FunctionType: FunctionType(in.ViewerRequest.FunctionType),
+			},
+			IncludeBody: in.ViewerRequest.IncludeBody,
+		}
+	}
+	if in.ViewerResponse != nil {
+		fa.ViewerResponse = &ViewerFunction{
+			ARN:          in.ViewerResponse.ARN,
+			FunctionType: FunctionType(in.ViewerResponse.FunctionType),
+		}
+	}
+	if in.OriginRequest != nil {
+		fa.OriginRequest = &OriginRequestFunction{
+			OriginFunction: OriginFunction{ARN: in.OriginRequest.ARN},
+			IncludeBody:    in.OriginRequest.IncludeBody,
+		}
+	}
+	if in.OriginResponse != nil {
+		fa.OriginResponse = &OriginFunction{ARN: in.OriginResponse.ARN}
+	}
+
+	return fa
+}
+
+type distributionFetcher struct {
+	k8sClient client.Client
+}
+
+// NewDistributionFetcher creates an IngressFetcher that represents each origin of Distribution resources as a CDNIngress.
+// Distributions which can't be parsed are skipped when fetching, unless any of their origins matches the predicate.
+func NewDistributionFetcher(k8sClient client.Client) IngressFetcher {
+	return distributionFetcher{k8sClient: k8sClient}
+}
+
+func (d distributionFetcher) FetchBy(ctx context.Context, cdnClass CDNClass, predicate func(CDNIngress) bool) ([]CDNIngress, error) {
+	list := &v1alpha1.DistributionList{}
+	if err := d.k8sClient.List(ctx, list); err != nil {
+		return nil, fmt.Errorf("listing Distributions: %v", err)
+	}
+
+	var result []CDNIngress
+	for i := range list.Items {
+		dist := &list.Items[i]
+		ings, err := NewCDNIngressesFromDistribution(dist, cdnClass)
+		if err != nil && anyOriginMatches(dist, predicate) {
+			return nil, fmt.Errorf("distribution %s/%s: %v", dist.Namespace, dist.Name, err)
+		}
+		if err != nil {
+			// an invalid Distribution shouldn't halt reconciliation of other groups, while its own group can't be
+			// reconciled without it. The Distribution itself is rejected when reconciled.
+			log.FromContext(ctx).Error(err, "Ignoring invalid Distribution of another group",
+				"invalidDistribution", dist.Namespace+"/"+dist.Name)
+			continue
+		}
+		for _, ing := range ings {
+			if predicate(ing) {
+				result = append(result, ing)
+			}
+		}
+	}
+	return result, nil
+}
+
+// anyOriginMatches returns whether the predicate matches any origin of a Distribution that can't be parsed, taking
+// only what identifies each origin into account
+func anyOriginMatches(dist *v1alpha1.Distribution, predicate func(CDNIngress) bool) bool {
+	for _, o := range dist.Spec.Origins {
+		ing := CDNIngress{
+			NamespacedName: types.NamespacedName{Namespace: dist.Namespace, Name: dist.Name},
+			OriginHost:     o.Host,
+			OriginPath:     o.OriginPath,
+			Group:          dist.Spec.Group,
+			IsBeingRemoved: dist.DeletionTimestamp != nil,
+		}
+		if predicate(ing) {
+			return true
+		}
+	}
+	return false
+}
+
+func (d distributionFetcher) FetchGroupMembers(ctx context.Context, group string, _ types.NamespacedName) ([]CDNIngress, []string, error) {
+	list := &v1alpha1.DistributionList{}
+	if err := d.k8sClient.List(ctx, list); err != nil {
+		return nil, nil, fmt.Errorf("listing Distributions: %v", err)
+	}
+
+	var result []CDNIngress
+	var warnings []string
+	for i := range list.Items {
+		dist := &list.Items[i]
+		if dist.Spec.Group != group {
+			continue
+		}
+
+		ings, err := NewCDNIngressesFromDistribution(dist, CDNClass{})
+		if err != nil {
+			warnings = append(warnings, invalidMemberWarning("Distribution", dist, err))
+			continue
+		}
+		for _, ing := range ings {
+			if isProvisionedMember(ing) {
+				result = append(result, ing)
+			}
+		}
+	}
+	return result, warnings, nil
+}
+
+type aggregateFetcher []IngressFetcher
+
+// NewAggregateFetcher creates an IngressFetcher that concatenates the results of all given fetchers
+func NewAggregateFetcher(fetchers ...IngressFetcher) IngressFetcher {
+	return aggregateFetcher(fetchers)
+}
+
+func (a aggregateFetcher) FetchBy(ctx context.Context, cdnClass CDNClass, predicate func(CDNIngress) bool) ([]CDNIngress, error) {
+	var result []CDNIngress
+	for _, f := range a {
+		ings, err := f.FetchBy(ctx, cdnClass, predicate)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, ings...)
+	}
+	return result, nil
+}
+
+func (a aggregateFetcher) FetchGroupMembers(ctx context.Context, group string, exclude types.NamespacedName) ([]CDNIngress, []string, error) {
+	var result []CDNIngress
+	var warnings []string
+	for _, f := range a {
+		ings, w, err := f.FetchGroupMembers(ctx, group, exclude)
+		if err != nil {
+			return nil, nil, err
+		}
+		result = append(result, ings...)
+		warnings = append(warnings, w...)
+	}
+	return result, warnings, nil
+}
+
+func httpMethods(methods []v1alpha1.HTTPMethod) []string {
+	var result []string
+	for _, m := range methods {
+		result = append(result, string(m))
+	}
+	return result
+}
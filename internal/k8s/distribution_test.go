@@ -0,0 +1,411 @@
+// Copyright (c) 2023 GPBR Participacoes LTDA.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package k8s
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/Gympass/cdn-origin-controller/api/v1alpha1"
+)
+
+func TestRunDistributionTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, &DistributionTestSuite{})
+}
+
+type DistributionTestSuite struct {
+	suite.Suite
+}
+
+func (s *DistributionTestSuite) TestNewCDNIngressesFromDistribution_OneCDNIngressPerOrigin() {
+	dist := newDistribution("namespace", "name", "group",
+		v1alpha1.DistributionOrigin{
+			Host:                "bucket.s3.amazonaws.com",
+			OriginAccess:        CFUserOriginAccessBucket,
+			ResponseTimeout:     30,
+			Headers:             map[string]string{"foo": "bar"},
+			OriginRequestPolicy: "request-policy",
+			CachePolicy:         "cache-policy",
+			ResponsePolicy:      "response-policy",
+			Behaviors: []v1alpha1.DistributionBehavior{
+				{
+					Path: "/static/*",
+					FunctionAssociations: &v1alpha1.FunctionAssociations{
+						ViewerRequest: &v1alpha1.ViewerRequestFunction{
+							ViewerFunction: v1alpha1.ViewerFunction{ARN: "arn:fn", FunctionType: "cloudfront"},
+						},
+						OriginResponse: &v1alpha1.OriginFunction{ARN: "arn:lambda"},
+					},
+				},
+			},
+		},
+		v1alpha1.DistributionOrigin{
+			Host:      "api.example.com",
+			Behaviors: []v1alpha1.DistributionBehavior{{Path: "/api/*"}},
+		},
+	)
+	dist.Spec.AlternateDomainNames = []string{"www.example.com"}
+	dist.Spec.WebACLARN = "arn:acl"
+	dist.Spec.Tags = map[string]string{"team": "foo"}
+
+	class := CDNClass{HostedZoneID: "zone"}
+	got, err := NewCDNIngressesFromDistribution(dist, class)
+	s.NoError(err)
+
+	nsName := types.NamespacedName{Namespace: "namespace", Name: "name"}
+	expected := []CDNIngress{
+		{
+			NamespacedName:    nsName,
+			OriginHost:        "bucket.s3.amazonaws.com",
+			Group:             "group",
+			OriginReqPolicy:   "request-policy",
+			OriginHeaders:     map[string]string{"foo": "bar"},
+			CachePolicy:       "cache-policy",
+			ResponsePolicy:    "response-policy",
+			OriginRespTimeout: 30,
+			UnmergedPaths: []Path{
+				{
+					PathPattern: "/static/*",
+					FunctionAssociations: FunctionAssociations{
+						ViewerRequest: &ViewerRequestFunction{
+							ViewerFunction: ViewerFunction{ARN: "arn:fn", FunctionType: FunctionTypeCloudfront},
+						},
+						OriginResponse: &OriginFunction{ARN: "arn:lambda"},
+					},
+				},
+			},
+			AlternateDomainNames: []string{"www.example.com"},
+			UnmergedWebACLARN:    "arn:acl",
+			OriginAccess:         CFUserOriginAccessBucket,
+			Class:                class,
+			Tags:                 map[string]string{"team": "foo"},
+		},
+		{
+			NamespacedName:       nsName,
+			OriginHost:           "api.example.com",
+			Group:                "group",
+			UnmergedPaths:        []Path{{PathPattern: "/api/*"}},
+			AlternateDomainNames: []string{"www.example.com"},
+			UnmergedWebACLARN:    "arn:acl",
+			OriginAccess:         CFUserOriginAccessPublic,
+			Class:                class,
+			Tags:                 map[string]string{"team": "foo"},
+		},
+	}
+	s.Equal(expected, got)
+}
+
+func (s *DistributionTestSuite) TestNewCDNIngressesFromDistribution_BeingDeleted() {
+	dist := newDistribution("namespace", "name", "group",
+		v1alpha1.DistributionOrigin{Host: "foo.com", Behaviors: []v1alpha1.DistributionBehavior{{Path: "/*"}}})
+	dist.DeletionTimestamp = &metav1.Time{}
+
+	got, err := NewCDNIngressesFromDistribution(dist, CDNClass{})
+	s.NoError(err)
+	s.Len(got, 1)
+	s.True(got[0].IsBeingRemoved)
+}
+
+func (s *DistributionTestSuite) TestNewCDNIngressesFromDistribution_NoOrigins() {
+	dist := newDistribution("namespace", "name", "group")
+	_, err := NewCDNIngressesFromDistribution(dist, CDNClass{})
+	s.Error(err)
+}
+
+func (s *DistributionTestSuite) TestNewCDNIngressesFromDistribution_InvalidFunctionAssociations() {
+	dist := newDistribution("namespace", "name", "group",
+		v1alpha1.DistributionOrigin{
+			Host: "foo.com",
+			Behaviors: []v1alpha1.DistributionBehavior{
+				{
+					Path: "/*",
+					FunctionAssociations: &v1alpha1.FunctionAssociations{
+						ViewerRequest: &v1alpha1.ViewerRequestFunction{
+							ViewerFunction: v1alpha1.ViewerFunction{ARN: "arn:fn", FunctionType: "cloudfront"},
+							IncludeBody:    true,
+						},
+					},
+				},
+			},
+		})
+
+	_, err := NewCDNIngressesFromDistribution(dist, CDNClass{})
+	s.Error(err)
+}
+
+func (s *DistributionTestSuite) TestNewCDNIngressesFromDistribution_BehaviorSettings() {
+	dist := newDistribution("namespace", "name", "group",
+		v1alpha1.DistributionOrigin{
+			Host: "foo.com",
+			Behaviors: []v1alpha1.DistributionBehavior{
+				{
+					Path:                 "/*",
+					AllowedMethods:       []v1alpha1.HTTPMethod{"HEAD", "GET"},
+					CachedMethods:        []v1alpha1.HTTPMethod{"GET", "HEAD"},
+					ViewerProtocolPolicy: "https-only",
+				},
+			},
+		})
+
+	got, err := NewCDNIngressesFromDistribution(dist, CDNClass{})
+	s.NoError(err)
+	s.Len(got, 1)
+	s.Equal(Methods{Allowed: []string{"GET", "HEAD"}, Cached: []string{"GET", "HEAD"}}, got[0].UnmergedPaths[0].Methods)
+	s.Equal("https-only", got[0].UnmergedPaths[0].ViewerProtocolPolicy)
+}
+
+func (s *DistributionTestSuite) TestNewCDNIngressesFromDistribution_InvalidMethods() {
+	dist := newDistribution("namespace", "name", "group",
+		v1alpha1.DistributionOrigin{
+			Host: "foo.com",
+			Behaviors: []v1alpha1.DistributionBehavior{
+				{
+					Path:           "/*",
+					AllowedMethods: []v1alpha1.HTTPMethod{"GET", "POST"},
+				},
+			},
+		})
+
+	_, err := NewCDNIngressesFromDistribution(dist, CDNClass{})
+	s.Error(err)
+}
+
+func (s *DistributionTestSuite) TestNewCDNIngressesFromDistribution_OriginSettings() {
+	dist := newDistribution("namespace", "name", "group",
+		v1alpha1.DistributionOrigin{
+			Host:                 "foo.com",
+			OriginPath:           "/prefix",
+			OriginProtocolPolicy: "http-only",
+			HTTPPort:             8080,
+			KeepaliveTimeout:     10,
+			MinSSLProtocol:       "TLSv1.2",
+			Behaviors:            []v1alpha1.DistributionBehavior{{Path: "/*"}},
+		})
+
+	got, err := NewCDNIngressesFromDistribution(dist, CDNClass{})
+	s.NoError(err)
+	s.Len(got, 1)
+	s.Equal(OriginProtocol{
+		Policy:           "http-only",
+		HTTPPort:         8080,
+		KeepaliveTimeout: 10,
+		MinSSLProtocol:   "TLSv1.2",
+	}, got[0].OriginProtocol)
+	s.Equal("/prefix", got[0].OriginPath)
+}
+
+func (s *DistributionTestSuite) TestNewCDNIngressesFromDistribution_Failover() {
+	dist := newDistribution("namespace", "name", "group",
+		v1alpha1.DistributionOrigin{
+			Host: "foo.com",
+			Failover: &v1alpha1.DistributionFailover{
+				Host:         "bucket.s3.amazonaws.com",
+				OriginAccess: "Bucket",
+				StatusCodes:  []v1alpha1.FailoverStatusCode{503, 500},
+			},
+			Behaviors: []v1alpha1.DistributionBehavior{{Path: "/*"}},
+		})
+
+	got, err := NewCDNIngressesFromDistribution(dist, CDNClass{})
+	s.NoError(err)
+	s.Len(got, 1)
+	s.Equal(&Failover{Host: "bucket.s3.amazonaws.com", OriginAccess: "Bucket", StatusCodes: []int64{500, 503}}, got[0].Failover)
+}
+
+func (s *DistributionTestSuite) TestNewCDNIngressesFromDistribution_CustomErrorResponses() {
+	dist := newDistribution("namespace", "name", "group",
+		v1alpha1.DistributionOrigin{Host: "foo.com", Behaviors: []v1alpha1.DistributionBehavior{{Path: "/*"}}},
+		v1alpha1.DistributionOrigin{Host: "bar.com", Behaviors: []v1alpha1.DistributionBehavior{{Path: "/bar"}}})
+	ttl := int64(0)
+	dist.Spec.CustomErrorResponses = []v1alpha1.CustomErrorResponse{
+		{ErrorCode: 404, ResponsePagePath: "/index.html", ResponseCode: 200, CachingMinTTL: &ttl},
+	}
+
+	got, err := NewCDNIngressesFromDistribution(dist, CDNClass{})
+	s.NoError(err)
+	s.Len(got, 2)
+	for _, ing := range got {
+		s.Equal([]CustomErrorResponse{
+			{ErrorCode: 404, ResponsePagePath: "/index.html", ResponseCode: 200, CachingMinTTL: &ttl},
+		}, ing.UnmergedCustomErrorResponses)
+	}
+
+	dist.Spec.CustomErrorResponses = append(dist.Spec.CustomErrorResponses, v1alpha1.CustomErrorResponse{ErrorCode: 404})
+	_, err = NewCDNIngressesFromDistribution(dist, CDNClass{})
+	s.Error(err, "duplicate error code")
+}
+
+func (s *DistributionTestSuite) TestNewCDNIngressesFromDistribution_GeoRestriction() {
+	dist := newDistribution("namespace", "name", "group",
+		v1alpha1.DistributionOrigin{Host: "foo.com", Behaviors: []v1alpha1.DistributionBehavior{{Path: "/*"}}})
+	dist.Spec.GeoRestriction = &v1alpha1.GeoRestriction{Type: "allow", Countries: []v1alpha1.CountryCode{"MX", "BR"}}
+
+	got, err := NewCDNIngressesFromDistribution(dist, CDNClass{})
+	s.NoError(err)
+	s.Len(got, 1)
+	s.Equal(&GeoRestriction{Type: GeoRestrictionAllow, Countries: []string{"BR", "MX"}}, got[0].UnmergedGeoRestriction)
+
+	dist.Spec.GeoRestriction.Countries = append(dist.Spec.GeoRestriction.Countries, "XX")
+	_, err = NewCDNIngressesFromDistribution(dist, CDNClass{})
+	s.Error(err, "unknown country code")
+}
+
+func (s *DistributionTestSuite) TestNewCDNIngressesFromDistribution_DefaultRootObject() {
+	dist := newDistribution("namespace", "name", "group",
+		v1alpha1.DistributionOrigin{Host: "foo.com", Behaviors: []v1alpha1.DistributionBehavior{{Path: "/*"}}})
+	rootObject := "index.html"
+	dist.Spec.DefaultRootObject = &rootObject
+
+	got, err := NewCDNIngressesFromDistribution(dist, CDNClass{})
+	s.NoError(err)
+	s.Len(got, 1)
+	s.Equal(&rootObject, got[0].UnmergedDefaultRootObject)
+
+	rootObject = "/index.html"
+	_, err = NewCDNIngressesFromDistribution(dist, CDNClass{})
+	s.Error(err, "leading slash")
+}
+
+func (s *DistributionTestSuite) TestNewCDNIngressesFromDistribution_DefaultBehavior() {
+	dist := newDistribution("namespace", "name", "group",
+		v1alpha1.DistributionOrigin{Host: "foo.com", DefaultBehavior: true, Behaviors: []v1alpha1.DistributionBehavior{{Path: "/*"}}},
+		v1alpha1.DistributionOrigin{Host: "bar.com", Behaviors: []v1alpha1.DistributionBehavior{{Path: "/bar"}}})
+
+	got, err := NewCDNIngressesFromDistribution(dist, CDNClass{})
+	s.NoError(err)
+	s.Len(got, 2)
+	s.True(got[0].DefaultBehavior)
+	s.False(got[1].DefaultBehavior)
+}
+
+func (s *DistributionTestSuite) TestNewCDNIngressesFromDistribution_InvalidOriginProtocol() {
+	dist := newDistribution("namespace", "name", "group",
+		v1alpha1.DistributionOrigin{
+			Host:      "foo.com",
+			HTTPSPort: 1000,
+			Behaviors: []v1alpha1.DistributionBehavior{{Path: "/*"}},
+		})
+
+	_, err := NewCDNIngressesFromDistribution(dist, CDNClass{})
+	s.Error(err)
+}
+
+func (s *DistributionTestSuite) TestDistributionFetcher_FetchBy() {
+	scheme := runtime.NewScheme()
+	s.NoError(v1alpha1.AddToScheme(scheme))
+
+	k8sClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithLists(&v1alpha1.DistributionList{
+			Items: []v1alpha1.Distribution{
+				*newDistribution("namespace", "matching", "group",
+					v1alpha1.DistributionOrigin{Host: "foo.com", Behaviors: []v1alpha1.DistributionBehavior{{Path: "/*"}}}),
+				*newDistribution("namespace", "not-matching", "other-group",
+					v1alpha1.DistributionOrigin{Host: "bar.com", Behaviors: []v1alpha1.DistributionBehavior{{Path: "/*"}}}),
+			},
+		}).
+		Build()
+
+	predicate := func(ing CDNIngress) bool {
+		return ing.Group == "group"
+	}
+
+	got, err := NewDistributionFetcher(k8sClient).FetchBy(context.Background(), CDNClass{}, predicate)
+	s.NoError(err)
+	s.Len(got, 1)
+	s.Equal("foo.com", got[0].OriginHost)
+	s.Equal("matching", got[0].Name)
+}
+
+func (s *DistributionTestSuite) TestDistributionFetcher_FetchBySkipsInvalidDistributionsOfOtherGroups() {
+	scheme := runtime.NewScheme()
+	s.NoError(v1alpha1.AddToScheme(scheme))
+
+	invalidOrigin := v1alpha1.DistributionOrigin{Host: "bar.com", HTTPSPort: 1000, Behaviors: []v1alpha1.DistributionBehavior{{Path: "/*"}}}
+	k8sClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithLists(&v1alpha1.DistributionList{
+			Items: []v1alpha1.Distribution{
+				*newDistribution("namespace", "matching", "group",
+					v1alpha1.DistributionOrigin{Host: "foo.com", Behaviors: []v1alpha1.DistributionBehavior{{Path: "/*"}}}),
+				*newDistribution("namespace", "invalid", "other-group", invalidOrigin),
+			},
+		}).
+		Build()
+	fetcher := NewDistributionFetcher(k8sClient)
+
+	got, err := fetcher.FetchBy(context.Background(), CDNClass{}, func(ing CDNIngress) bool { return ing.Group == "group" })
+	s.NoError(err)
+	s.Len(got, 1)
+	s.Equal("matching", got[0].Name)
+
+	_, err = fetcher.FetchBy(context.Background(), CDNClass{}, func(ing CDNIngress) bool { return ing.Group == "other-group" })
+	s.Error(err, "a group can't be reconciled without its invalid Distribution, or its origins would be removed")
+}
+
+func (s *DistributionTestSuite) TestAggregateFetcher_FetchBy() {
+	first := &fakeFetcher{ings: []CDNIngress{{OriginHost: "foo.com"}}}
+	second := &fakeFetcher{ings: []CDNIngress{{OriginHost: "bar.com"}}}
+
+	got, err := NewAggregateFetcher(first, second).FetchBy(context.Background(), CDNClass{}, nil)
+	s.NoError(err)
+	s.Equal([]CDNIngress{{OriginHost: "foo.com"}, {OriginHost: "bar.com"}}, got)
+}
+
+func (s *DistributionTestSuite) TestAggregateFetcher_FetchByFailsIfAnyFetcherFails() {
+	first := &fakeFetcher{ings: []CDNIngress{{OriginHost: "foo.com"}}}
+	second := &fakeFetcher{err: errors.New("mock err")}
+
+	_, err := NewAggregateFetcher(first, second).FetchBy(context.Background(), CDNClass{}, nil)
+	s.Error(err)
+}
+
+type fakeFetcher struct {
+	ings     []CDNIngress
+	warnings []string
+	err      error
+}
+
+func (f *fakeFetcher) FetchBy(context.Context, CDNClass, func(CDNIngress) bool) ([]CDNIngress, error) {
+	return f.ings, f.err
+}
+
+func (f *fakeFetcher) FetchGroupMembers(context.Context, string, types.NamespacedName) ([]CDNIngress, []string, error) {
+	return f.ings, f.warnings, f.err
+}
+
+func newDistribution(namespace, name, group string, origins ...v1alpha1.DistributionOrigin) *v1alpha1.Distribution {
+	return &v1alpha1.Distribution{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+		Spec: v1alpha1.DistributionSpec{
+			Group:   group,
+			Class:   "class",
+			Origins: origins,
+		},
+	}
+}
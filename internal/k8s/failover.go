@@ -0,0 +1,137 @@
+// Copyright (c) 2023 GPBR Participacoes LTDA.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package k8s
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+	networkingv1 "k8s.io/api/networking/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const cfFailoverAnnotation = "cdn-origin-controller.gympass.com/cf.failover"
+
+// failoverStatusCodes are the status codes from the primary origin CloudFront may fail over on
+var failoverStatusCodes = []int64{400, 403, 404, 416, 500, 502, 503, 504}
+
+// defaultFailoverStatusCodes are used when a Failover doesn't specify its own status codes
+var defaultFailoverStatusCodes = []int64{500, 502, 503, 504}
+
+// Failover represents a secondary origin CloudFront fails over to when the primary origin
+// can't be reached or responds with one of the StatusCodes
+type Failover struct {
+	// Host is the secondary origin's hostname
+	Host string `yaml:"host"`
+	// OriginPath is the directory CloudFront requests content from within the secondary origin's host, if any
+	OriginPath string `yaml:"originPath"`
+	// OriginAccess is how CloudFront accesses the secondary origin: Public or Bucket
+	OriginAccess string `yaml:"originAccess"`
+	// StatusCodes are the status codes from the primary origin which trigger a failover
+	StatusCodes []int64 `yaml:"statusCodes"`
+}
+
+// normalized returns the Failover with defaults set for unspecified fields,
+// or an error if it's not supported by CloudFront
+func (f Failover) normalized() (Failover, error) {
+	if len(f.Host) == 0 {
+		return Failover{}, errors.New("the failover origin must have a host")
+	}
+
+	if err := validateOriginPath(f.OriginPath); err != nil {
+		return Failover{}, err
+	}
+
+	if len(f.OriginAccess) == 0 {
+		f.OriginAccess = CFUserOriginAccessPublic
+	}
+	if f.OriginAccess != CFUserOriginAccessPublic && f.OriginAccess != CFUserOriginAccessBucket {
+		return Failover{}, fmt.Errorf("invalid failover origin access %q. Valid values: %q, %q",
+			f.OriginAccess, CFUserOriginAccessPublic, CFUserOriginAccessBucket)
+	}
+
+	if len(f.StatusCodes) == 0 {
+		f.StatusCodes = defaultFailoverStatusCodes
+		return f, nil
+	}
+
+	seen := make(map[int64]bool)
+	var codes []int64
+	for _, code := range f.StatusCodes {
+		if !containsStatusCode(failoverStatusCodes, code) {
+			return Failover{}, fmt.Errorf("invalid failover status code %d. Valid values: %v", code, failoverStatusCodes)
+		}
+		if !seen[code] {
+			seen[code] = true
+			codes = append(codes, code)
+		}
+	}
+	sort.Slice(codes, func(i, j int) bool { return codes[i] < codes[j] })
+	f.StatusCodes = codes
+	return f, nil
+}
+
+func containsStatusCode(codes []int64, code int64) bool {
+	for _, c := range codes {
+		if c == code {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizedFailover normalizes an optional Failover
+func normalizedFailover(f *Failover) (*Failover, error) {
+	if f == nil {
+		return nil, nil
+	}
+
+	normalized, err := f.normalized()
+	if err != nil {
+		return nil, err
+	}
+	return &normalized, nil
+}
+
+func failover(obj client.Object) (*Failover, error) {
+	val, ok := obj.GetAnnotations()[cfFailoverAnnotation]
+	if !ok {
+		return nil, nil
+	}
+
+	f := &Failover{}
+	if err := yaml.Unmarshal([]byte(val), f); err != nil {
+		return nil, fmt.Errorf("parsing annotation %q: %v", cfFailoverAnnotation, err)
+	}
+
+	normalized, err := normalizedFailover(f)
+	if err != nil {
+		return nil, fmt.Errorf("annotation %q: %v", cfFailoverAnnotation, err)
+	}
+	return normalized, nil
+}
+
+// ValidateIngressFailover returns an error if the Ingress configures a failover origin which is invalid
+func ValidateIngressFailover(ing *networkingv1.Ingress) error {
+	_, err := failover(ing)
+	return err
+}
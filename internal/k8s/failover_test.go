@@ -0,0 +1,121 @@
+// Copyright (c) 2023 GPBR Participacoes LTDA.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package k8s
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestRunFailoverTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, &failoverTestSuite{})
+}
+
+type failoverTestSuite struct {
+	suite.Suite
+}
+
+func (s *failoverTestSuite) TestNormalized_Valid() {
+	testCases := []struct {
+		name string
+		in   Failover
+		want Failover
+	}{
+		{
+			name: "Defaults",
+			in:   Failover{Host: "foo.com"},
+			want: Failover{Host: "foo.com", OriginAccess: "Public", StatusCodes: []int64{500, 502, 503, 504}},
+		},
+		{
+			name: "All settings",
+			in:   Failover{Host: "bucket.s3.amazonaws.com", OriginPath: "/maintenance", OriginAccess: "Bucket", StatusCodes: []int64{404}},
+			want: Failover{Host: "bucket.s3.amazonaws.com", OriginPath: "/maintenance", OriginAccess: "Bucket", StatusCodes: []int64{404}},
+		},
+		{
+			name: "Unordered and duplicate status codes",
+			in:   Failover{Host: "foo.com", StatusCodes: []int64{503, 403, 503}},
+			want: Failover{Host: "foo.com", OriginAccess: "Public", StatusCodes: []int64{403, 503}},
+		},
+	}
+
+	for _, tc := range testCases {
+		got, err := tc.in.normalized()
+		s.NoError(err, "test: %s", tc.name)
+		s.Equal(tc.want, got, "test: %s", tc.name)
+	}
+}
+
+func (s *failoverTestSuite) TestNormalized_Invalid() {
+	testCases := []struct {
+		name string
+		in   Failover
+	}{
+		{name: "No host", in: Failover{}},
+		{name: "Invalid origin path", in: Failover{Host: "foo.com", OriginPath: "maintenance/"}},
+		{name: "Invalid origin access", in: Failover{Host: "foo.com", OriginAccess: "Private"}},
+		{name: "Unsupported status code", in: Failover{Host: "foo.com", StatusCodes: []int64{501}}},
+	}
+
+	for _, tc := range testCases {
+		_, err := tc.in.normalized()
+		s.Error(err, "test: %s", tc.name)
+	}
+}
+
+func (s *failoverTestSuite) Test_failover_FromAnnotation() {
+	ing := &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{
+				cfFailoverAnnotation: "host: bucket.s3.amazonaws.com\noriginAccess: Bucket\nstatusCodes: [500, 503]",
+			},
+		},
+	}
+
+	got, err := failover(ing)
+	s.NoError(err)
+	s.Equal(&Failover{Host: "bucket.s3.amazonaws.com", OriginAccess: "Bucket", StatusCodes: []int64{500, 503}}, got)
+}
+
+func (s *failoverTestSuite) Test_failover_NoAnnotation() {
+	got, err := failover(&networkingv1.Ingress{})
+	s.NoError(err)
+	s.Nil(got)
+}
+
+func (s *failoverTestSuite) Test_failover_InvalidAnnotation() {
+	testCases := []struct {
+		name  string
+		value string
+	}{
+		{name: "Invalid YAML", value: "host: [foo"},
+		{name: "No host", value: "statusCodes: [500]"},
+		{name: "Unsupported status code", value: "host: foo.com\nstatusCodes: [200]"},
+	}
+
+	for _, tc := range testCases {
+		ing := &networkingv1.Ingress{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{cfFailoverAnnotation: tc.value}}}
+		_, err := failover(ing)
+		s.Error(err, "test: %s", tc.name)
+	}
+}
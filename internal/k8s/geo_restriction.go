@@ -0,0 +1,172 @@
+// Copyright (c) 2023 GPBR Participacoes LTDA.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package k8s
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const cfGeoRestrictionAnnotation = "cdn-origin-controller.gympass.com/cf.geo-restriction"
+
+const (
+	// GeoRestrictionAllow means only viewers from the listed countries can access the distribution
+	GeoRestrictionAllow = "allow"
+	// GeoRestrictionDeny means viewers from the listed countries can't access the distribution
+	GeoRestrictionDeny = "deny"
+	// GeoRestrictionNone means viewers from any country can access the distribution
+	GeoRestrictionNone = "none"
+)
+
+// countryCodes are the ISO 3166-1 alpha-2 codes of all countries
+var countryCodes = sets.NewString(
+	"AD", "AE", "AF", "AG", "AI", "AL", "AM", "AO", "AQ", "AR", "AS", "AT", "AU", "AW", "AX", "AZ",
+	"BA", "BB", "BD", "BE", "BF", "BG", "BH", "BI", "BJ", "BL", "BM", "BN", "BO", "BQ", "BR", "BS", "BT", "BV", "BW", "BY", "BZ",
+	"CA", "CC", "CD", "CF", "CG", "CH", "CI", "CK", "CL", "CM", "CN", "CO", "CR", "CU", "CV", "CW", "CX", "CY", "CZ",
+	"DE", "DJ", "DK", "DM", "DO", "DZ",
+	"EC", "EE", "EG", "EH", "ER", "ES", "ET",
+	"FI", "FJ", "FK", "FM", "FO", "FR",
+	"GA", "GB", "GD", "GE", "GF", "GG", "GH", "GI", "GL", "GM", "GN", "GP", "GQ", "GR", "GS", "GT", "GU", "GW", "GY",
+	"HK", "HM", "HN", "HR", "HT", "HU",
+	"ID", "IE", "IL", "IM", "IN", "IO", "IQ", "IR", "IS", "IT",
+	"JE", "JM", "JO", "JP",
+	"KE", "KG", "KH", "KI", "KM", "KN", "KP", "KR", "KW", "KY", "KZ",
+	"LA", "LB", "LC", "LI", "LK", "LR", "LS", "LT", "LU", "LV", "LY",
+	"MA", "MC", "MD", "ME", "MF", "MG", "MH", "MK", "ML", "MM", "MN", "MO", "MP", "MQ", "MR", "MS", "MT", "MU", "MV", "MW", "MX", "MY", "MZ",
+	"NA", "NC", "NE", "NF", "NG", "NI", "NL", "NO", "NP", "NR", "NU", "NZ",
+	"OM",
+	"PA", "PE", "PF", "PG", "PH", "PK", "PL", "PM", "PN", "PR", "PS", "PT", "PW", "PY",
+	"QA",
+	"RE", "RO", "RS", "RU", "RW",
+	"SA", "SB", "SC", "SD", "SE", "SG", "SH", "SI", "SJ", "SK", "SL", "SM", "SN", "SO", "SR", "SS", "ST", "SV", "SX", "SY", "SZ",
+	"TC", "TD", "TF", "TG", "TH", "TJ", "TK", "TL", "TM", "TN", "TO", "TR", "TT", "TV", "TW", "TZ",
+	"UA", "UG", "UM", "US", "UY", "UZ",
+	"VA", "VC", "VE", "VG", "VI", "VN", "VU",
+	"WF", "WS",
+	"YE", "YT",
+	"ZA", "ZM", "ZW",
+)
+
+// GeoRestriction represents which countries viewers may access a distribution from
+type GeoRestriction struct {
+	// Type is how Countries are restricted: allow, deny or none
+	Type string `yaml:"type"`
+	// Countries are the ISO 3166-1 alpha-2 codes of the countries being allowed or denied
+	Countries []string `yaml:"countries"`
+}
+
+// normalized returns the GeoRestriction with sorted, deduplicated and upper case country codes,
+// or an error if it's invalid
+func (g GeoRestriction) normalized() (GeoRestriction, error) {
+	switch g.Type {
+	case GeoRestrictionAllow, GeoRestrictionDeny:
+		if len(g.Countries) == 0 {
+			return GeoRestriction{}, fmt.Errorf("geo restriction of type %q must have at least one country", g.Type)
+		}
+	case GeoRestrictionNone:
+		if len(g.Countries) > 0 {
+			return GeoRestriction{}, fmt.Errorf("geo restriction of type %q must not have countries", g.Type)
+		}
+		return GeoRestriction{Type: g.Type}, nil
+	default:
+		return GeoRestriction{}, fmt.Errorf("invalid geo restriction type %q. Valid values: %q, %q, %q",
+			g.Type, GeoRestrictionAllow, GeoRestrictionDeny, GeoRestrictionNone)
+	}
+
+	countries := sets.NewString()
+	for _, c := range g.Countries {
+		code := strings.ToUpper(c)
+		if !countryCodes.Has(code) {
+			return GeoRestriction{}, fmt.Errorf("invalid country code %q: it must be an ISO 3166-1 alpha-2 code", c)
+		}
+		countries.Insert(code)
+	}
+	return GeoRestriction{Type: g.Type, Countries: countries.List()}, nil
+}
+
+func (g GeoRestriction) String() string {
+	if len(g.Countries) == 0 {
+		return g.Type
+	}
+	return fmt.Sprintf("%s %s", g.Type, strings.Join(g.Countries, ","))
+}
+
+// normalizedGeoRestriction normalizes an optional GeoRestriction
+func normalizedGeoRestriction(g *GeoRestriction) (*GeoRestriction, error) {
+	if g == nil {
+		return nil, nil
+	}
+
+	normalized, err := g.normalized()
+	if err != nil {
+		return nil, err
+	}
+	return &normalized, nil
+}
+
+func geoRestriction(obj client.Object) (*GeoRestriction, error) {
+	val, ok := obj.GetAnnotations()[cfGeoRestrictionAnnotation]
+	if !ok {
+		return nil, nil
+	}
+
+	g := &GeoRestriction{}
+	if err := yaml.Unmarshal([]byte(val), g); err != nil {
+		return nil, fmt.Errorf("parsing annotation %q: %v", cfGeoRestrictionAnnotation, err)
+	}
+
+	normalized, err := normalizedGeoRestriction(g)
+	if err != nil {
+		return nil, fmt.Errorf("annotation %q: %v", cfGeoRestrictionAnnotation, err)
+	}
+	return normalized, nil
+}
+
+// ValidateIngressGeoRestriction returns an error if the Ingress configures a geo restriction which is invalid, such as
+// one listing unknown country codes
+func ValidateIngressGeoRestriction(ing *networkingv1.Ingress) error {
+	_, err := geoRestriction(ing)
+	return err
+}
+
+// mergedGeoRestriction returns the GeoRestriction specified by the CDNIngresses, if any.
+// CDNIngresses may specify the same GeoRestriction, but not different ones.
+func mergedGeoRestriction(ingresses []CDNIngress) (*GeoRestriction, error) {
+	var result *GeoRestriction
+	specified := sets.NewString()
+	for _, ing := range ingresses {
+		if ing.UnmergedGeoRestriction == nil {
+			continue
+		}
+		result = ing.UnmergedGeoRestriction
+		specified.Insert(result.String())
+	}
+
+	if len(specified) > 1 {
+		return nil, errors.New("more than one geo restriction specified: " + strings.Join(specified.List(), "; "))
+	}
+	return result, nil
+}
@@ -0,0 +1,158 @@
+// Copyright (c) 2023 GPBR Participacoes LTDA.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package k8s
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestRunGeoRestrictionTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, &geoRestrictionTestSuite{})
+}
+
+type geoRestrictionTestSuite struct {
+	suite.Suite
+}
+
+func (s *geoRestrictionTestSuite) Test_normalized_Valid() {
+	testCases := []struct {
+		name string
+		in   GeoRestriction
+		want GeoRestriction
+	}{
+		{
+			name: "Allow list is sorted, deduplicated and upper case",
+			in:   GeoRestriction{Type: GeoRestrictionAllow, Countries: []string{"mx", "BR", "br"}},
+			want: GeoRestriction{Type: GeoRestrictionAllow, Countries: []string{"BR", "MX"}},
+		},
+		{
+			name: "Deny list",
+			in:   GeoRestriction{Type: GeoRestrictionDeny, Countries: []string{"US"}},
+			want: GeoRestriction{Type: GeoRestrictionDeny, Countries: []string{"US"}},
+		},
+		{
+			name: "No restriction",
+			in:   GeoRestriction{Type: GeoRestrictionNone},
+			want: GeoRestriction{Type: GeoRestrictionNone},
+		},
+	}
+
+	for _, tc := range testCases {
+		got, err := tc.in.normalized()
+		s.NoError(err, "test: %s", tc.name)
+		s.Equal(tc.want, got, "test: %s", tc.name)
+	}
+}
+
+func (s *geoRestrictionTestSuite) Test_normalized_Invalid() {
+	testCases := []struct {
+		name string
+		in   GeoRestriction
+	}{
+		{name: "No type", in: GeoRestriction{Countries: []string{"BR"}}},
+		{name: "Unknown type", in: GeoRestriction{Type: "whitelist", Countries: []string{"BR"}}},
+		{name: "Allow list without countries", in: GeoRestriction{Type: GeoRestrictionAllow}},
+		{name: "Deny list without countries", in: GeoRestriction{Type: GeoRestrictionDeny}},
+		{name: "No restriction with countries", in: GeoRestriction{Type: GeoRestrictionNone, Countries: []string{"BR"}}},
+		{name: "Unknown country", in: GeoRestriction{Type: GeoRestrictionAllow, Countries: []string{"BR", "XX"}}},
+		{name: "Alpha-3 country", in: GeoRestriction{Type: GeoRestrictionAllow, Countries: []string{"BRA"}}},
+	}
+
+	for _, tc := range testCases {
+		_, err := tc.in.normalized()
+		s.Error(err, "test: %s", tc.name)
+	}
+}
+
+func (s *geoRestrictionTestSuite) Test_geoRestriction_FromAnnotation() {
+	ing := &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{
+				cfGeoRestrictionAnnotation: `
+type: deny
+countries: [us, CA]
+`,
+			},
+		},
+	}
+
+	got, err := geoRestriction(ing)
+	s.NoError(err)
+	s.Equal(&GeoRestriction{Type: GeoRestrictionDeny, Countries: []string{"CA", "US"}}, got)
+}
+
+func (s *geoRestrictionTestSuite) Test_geoRestriction_NoAnnotation() {
+	got, err := geoRestriction(&networkingv1.Ingress{})
+	s.NoError(err)
+	s.Nil(got)
+}
+
+func (s *geoRestrictionTestSuite) Test_geoRestriction_InvalidAnnotation() {
+	testCases := []struct {
+		name  string
+		value string
+	}{
+		{name: "Invalid YAML", value: "- type: allow"},
+		{name: "Invalid restriction", value: "type: allow\ncountries: [ZZ]"},
+	}
+
+	for _, tc := range testCases {
+		ing := &networkingv1.Ingress{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{cfGeoRestrictionAnnotation: tc.value}}}
+		_, err := geoRestriction(ing)
+		s.Error(err, "test: %s", tc.name)
+	}
+}
+
+func (s *geoRestrictionTestSuite) Test_mergedGeoRestriction() {
+	restriction := &GeoRestriction{Type: GeoRestrictionAllow, Countries: []string{"BR", "MX"}}
+	ingresses := []CDNIngress{
+		{UnmergedGeoRestriction: restriction},
+		{},
+		{UnmergedGeoRestriction: &GeoRestriction{Type: GeoRestrictionAllow, Countries: []string{"BR", "MX"}}},
+	}
+
+	got, err := mergedGeoRestriction(ingresses)
+	s.NoError(err)
+	s.Equal(restriction, got)
+}
+
+func (s *geoRestrictionTestSuite) Test_mergedGeoRestriction_NoneSpecified() {
+	got, err := mergedGeoRestriction([]CDNIngress{{}, {}})
+	s.NoError(err)
+	s.Nil(got)
+}
+
+func (s *geoRestrictionTestSuite) Test_mergedGeoRestriction_Conflicting() {
+	ingresses := []CDNIngress{
+		{UnmergedGeoRestriction: &GeoRestriction{Type: GeoRestrictionAllow, Countries: []string{"BR"}}},
+		{UnmergedGeoRestriction: &GeoRestriction{Type: GeoRestrictionDeny, Countries: []string{"BR"}}},
+	}
+
+	_, err := mergedGeoRestriction(ingresses)
+	s.Error(err)
+
+	_, err = NewSharedIngressParams(ingresses)
+	s.ErrorIs(err, errSharedParamsConflictingGeo)
+}
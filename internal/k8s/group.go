@@ -0,0 +1,113 @@
+// Copyright (c) 2023 GPBR Participacoes LTDA.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package k8s
+
+import (
+	"context"
+	"fmt"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/Gympass/cdn-origin-controller/api/v1alpha1"
+)
+
+// GroupMembers returns all Ingresses and Distributions, across all namespaces, which are part of the given group
+func GroupMembers(ctx context.Context, k8sClient client.Client, group string) ([]client.Object, error) {
+	var result []client.Object
+
+	ingList := &networkingv1.IngressList{}
+	if err := k8sClient.List(ctx, ingList); err != nil {
+		return nil, fmt.Errorf("listing Ingresses: %v", err)
+	}
+	for i := range ingList.Items {
+		if groupAnnotationValue(&ingList.Items[i]) == group {
+			result = append(result, &ingList.Items[i])
+		}
+	}
+
+	distList := &v1alpha1.DistributionList{}
+	if err := k8sClient.List(ctx, distList); err != nil {
+		return nil, fmt.Errorf("listing Distributions: %v", err)
+	}
+	for i := range distList.Items {
+		if distList.Items[i].Spec.Group == group {
+			result = append(result, &distList.Items[i])
+		}
+	}
+
+	return result, nil
+}
+
+// GroupClassName returns the CDN class of the Ingresses and Distributions of the given group which aren't being
+// deleted, or an empty string if there are none
+func GroupClassName(ctx context.Context, k8sClient client.Client, group string) (string, error) {
+	members, err := GroupMembers(ctx, k8sClient, group)
+	if err != nil {
+		return "", err
+	}
+
+	for _, m := range members {
+		if m.GetDeletionTimestamp() != nil {
+			continue
+		}
+		if dist, ok := m.(*v1alpha1.Distribution); ok {
+			return dist.Spec.Class, nil
+		}
+		if className := CDNClassAnnotationValue(m); CDNClassNotEmpty(className) {
+			return className, nil
+		}
+	}
+	return "", nil
+}
+
+// ActiveGroups returns the groups of all Ingresses and Distributions, across all namespaces, and of all CDNStatuses
+func ActiveGroups(ctx context.Context, k8sClient client.Client) (sets.String, error) {
+	groups := sets.NewString()
+
+	ingList := &networkingv1.IngressList{}
+	if err := k8sClient.List(ctx, ingList); err != nil {
+		return nil, fmt.Errorf("listing Ingresses: %v", err)
+	}
+	for i := range ingList.Items {
+		if HasGroupAnnotation(&ingList.Items[i]) {
+			groups.Insert(groupAnnotationValue(&ingList.Items[i]))
+		}
+	}
+
+	distList := &v1alpha1.DistributionList{}
+	if err := k8sClient.List(ctx, distList); err != nil {
+		return nil, fmt.Errorf("listing Distributions: %v", err)
+	}
+	for _, dist := range distList.Items {
+		groups.Insert(dist.Spec.Group)
+	}
+
+	statusList := &v1alpha1.CDNStatusList{}
+	if err := k8sClient.List(ctx, statusList); err != nil {
+		return nil, fmt.Errorf("listing CDNStatuses: %v", err)
+	}
+	for _, status := range statusList.Items {
+		groups.Insert(status.Name)
+	}
+
+	return groups, nil
+}
@@ -33,6 +33,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
+	"github.com/Gympass/cdn-origin-controller/api/v1alpha1"
 	"github.com/Gympass/cdn-origin-controller/internal/strhelper"
 )
 
@@ -53,6 +54,18 @@ const (
 	cfWebACLARNAnnotation            = "cdn-origin-controller.gympass.com/cf.web-acl-arn"
 	cfTagsAnnotation                 = "cdn-origin-controller.gympass.com/cf.tags"
 	cfOrigHeadersAnnotation          = "cdn-origin-controller.gympass.com/cf.origin-headers"
+	cfDryRunAnnotation               = "cdn-origin-controller.gympass.com/cf.dry-run"
+	cfInvalidateOnRolloutAnnotation  = "cdn-origin-controller.gympass.com/cf.invalidate-on-rollout"
+	cfAdoptDistributionIDAnnotation  = "cdn-origin-controller.gympass.com/cf.adopt-distribution-id"
+	cfReleaseAnnotation              = "cdn-origin-controller.gympass.com/cf.release"
+	cfOrigPathAnnotation             = "cdn-origin-controller.gympass.com/cf.origin-path"
+	cfDefaultRootObjectAnnotation    = "cdn-origin-controller.gympass.com/cf.default-root-object"
+	cfDefaultBehaviorAnnotation      = "cdn-origin-controller.gympass.com/cf.default-behavior"
+)
+
+const (
+	maxOriginPathLength        = 255
+	maxDefaultRootObjectLength = 255
 )
 
 // Path represents a path item within an Ingress
@@ -60,12 +73,41 @@ type Path struct {
 	PathPattern          string
 	PathType             string
 	FunctionAssociations FunctionAssociations
+	// Methods are the HTTP methods allowed and cached on this Path, if not the default ones
+	Methods Methods
+	// ViewerProtocolPolicy is the protocol viewers may use to access this Path, if not the default one
+	ViewerProtocolPolicy string
+}
+
+// merge returns a Path with the configuration of both p and other, which are expected to have the same pattern and type.
+// Returns an error if both configure the same setting differently.
+func (p Path) merge(other Path) (Path, error) {
+	fa, err := p.FunctionAssociations.Merge(other.FunctionAssociations)
+	if err != nil {
+		return Path{}, fmt.Errorf("conflicting function associations on %q: %v", p.PathPattern, err)
+	}
+
+	methods, err := p.Methods.merge(other.Methods)
+	if err != nil {
+		return Path{}, fmt.Errorf("conflicting methods on %q: %v", p.PathPattern, err)
+	}
+
+	policy, err := mergeViewerProtocolPolicies(p.ViewerProtocolPolicy, other.ViewerProtocolPolicy)
+	if err != nil {
+		return Path{}, fmt.Errorf("conflicting viewer protocol policies on %q: %v", p.PathPattern, err)
+	}
+
+	p.FunctionAssociations = fa
+	p.Methods = methods
+	p.ViewerProtocolPolicy = policy
+	return p, nil
 }
 
 // CDNIngress represents an Ingress within the bounded context of cdn-origin-controller
 type CDNIngress struct {
 	types.NamespacedName
 	OriginHost           string
+	OriginPath           string
 	Group                string
 	UnmergedPaths        []Path
 	OriginReqPolicy      string
@@ -73,12 +115,32 @@ type CDNIngress struct {
 	CachePolicy          string
 	ResponsePolicy       string
 	OriginRespTimeout    int64
+	OriginProtocol       OriginProtocol
+	Failover             *Failover
 	AlternateDomainNames []string
 	UnmergedWebACLARN    string
 	IsBeingRemoved       bool
 	OriginAccess         string
 	Class                CDNClass
 	Tags                 map[string]string
+	DryRun               bool
+	// Release is true if the CDNIngress asks for its group's distribution to no longer be managed by the controller
+	Release bool
+	// UnmergedAdoptDistributionID is the ID of an existing distribution this CDNIngress asks to be adopted into its group
+	UnmergedAdoptDistributionID string
+	// UnmergedCustomErrorResponses are the custom error responses this CDNIngress asks for its group's distribution
+	UnmergedCustomErrorResponses []CustomErrorResponse
+	// UnmergedGeoRestriction is the geo restriction this CDNIngress asks for its group's distribution, if any
+	UnmergedGeoRestriction *GeoRestriction
+	// UnmergedDefaultRootObject is the default root object this CDNIngress asks for its group's distribution, if any.
+	// An empty value means the distribution should have no default root object
+	UnmergedDefaultRootObject *string
+	// DefaultBehavior is true if the CDNIngress asks for its origin to be the target of the default behavior of its
+	// group's distribution, instead of the default origin
+	DefaultBehavior bool
+	// InvalidOptionalFields are the optional fields of its group's distribution the CDNIngress declares with values
+	// that can't be parsed, which must be kept as they are instead of being considered no longer declared
+	InvalidOptionalFields []v1alpha1.OptionalField
 }
 
 // GetNamespace returns the CDNIngress namespace
@@ -92,14 +154,74 @@ func (c CDNIngress) GetName() string {
 }
 
 var (
-	errSharedParamsConflictingACL   = errors.New("conflicting WAF WebACL ARNs")
-	errSharedParamsConflictingPaths = errors.New("conflicting path configuration")
+	errSharedParamsConflictingACL      = errors.New("conflicting WAF WebACL ARNs")
+	errSharedParamsConflictingPaths    = errors.New("conflicting path configuration")
+	errSharedParamsConflictingAdoption = errors.New("conflicting distributions to adopt")
+	errSharedParamsConflictingErrors   = errors.New("conflicting custom error responses")
+	errSharedParamsConflictingGeo      = errors.New("conflicting geo restrictions")
+	errSharedParamsConflictingRootObj  = errors.New("conflicting default root objects")
+	errSharedParamsConflictingDefault  = errors.New("conflicting default behaviors")
 )
 
 // SharedIngressParams represents parameters which might be specified in multiple Ingresses
 type SharedIngressParams struct {
 	WebACLARN string
-	paths     map[string][]Path // map[originHost][]Path
+	// DryRun is true if any of the Ingresses asks for changes to only be planned
+	DryRun bool
+	// AdoptDistributionID is the ID of an existing distribution that should be adopted by the group, if any
+	AdoptDistributionID string
+	// CustomErrorResponses are the custom error responses of the group's distribution, sorted by error code
+	CustomErrorResponses []CustomErrorResponse
+	// GeoRestriction is the geo restriction of the group's distribution, if any Ingress declares one
+	GeoRestriction *GeoRestriction
+	// DefaultRootObject is the default root object of the group's distribution, if any Ingress declares one
+	DefaultRootObject *string
+	// InvalidOptionalFields are the optional fields of the group's distribution any CDNIngress declares with values
+	// that can't be parsed, sorted by name
+	InvalidOptionalFields []v1alpha1.OptionalField
+	paths                 map[originKey][]Path
+	// defaultOrigin is the origin the default behavior targets, if any CDNIngress asks for it
+	defaultOrigin *originKey
+}
+
+// originKey identifies an origin, since the same host might be used with different origin parameters
+type originKey struct {
+	host        string
+	path        string
+	access      string
+	respTimeout int64
+	protocol    OriginProtocol
+	headers     string
+	failover    string
+}
+
+func newOriginKey(ing CDNIngress) originKey {
+	return originKey{
+		host:        ing.OriginHost,
+		path:        ing.OriginPath,
+		access:      ing.OriginAccess,
+		respTimeout: ing.OriginRespTimeout,
+		protocol:    ing.OriginProtocol,
+		headers:     headersKey(ing.OriginHeaders),
+		failover:    failoverKey(ing.Failover),
+	}
+}
+
+// failoverKey represents an optional Failover as a comparable value
+func failoverKey(f *Failover) string {
+	if f == nil {
+		return ""
+	}
+	return fmt.Sprintf("%+v", *f)
+}
+
+// headersKey represents headers as a comparable value, independent of map ordering
+func headersKey(headers map[string]string) string {
+	var result []string
+	for _, name := range sets.StringKeySet(headers).List() {
+		result = append(result, name+"="+headers[name])
+	}
+	return strings.Join(result, ",")
 }
 
 // NewSharedIngressParams creates a new SharedIngressParams from a slice of CDNIngress
@@ -114,75 +236,155 @@ func NewSharedIngressParams(ingresses []CDNIngress) (SharedIngressParams, error)
 		return SharedIngressParams{}, fmt.Errorf("%w: %v", errSharedParamsConflictingPaths, err)
 	}
 
+	adoptID, err := mergedAdoptDistributionID(ingresses)
+	if err != nil {
+		return SharedIngressParams{}, fmt.Errorf("%w: %v", errSharedParamsConflictingAdoption, err)
+	}
+
+	errorResponses, err := mergedCustomErrorResponses(ingresses)
+	if err != nil {
+		return SharedIngressParams{}, fmt.Errorf("%w: %v", errSharedParamsConflictingErrors, err)
+	}
+
+	geo, err := mergedGeoRestriction(ingresses)
+	if err != nil {
+		return SharedIngressParams{}, fmt.Errorf("%w: %v", errSharedParamsConflictingGeo, err)
+	}
+
+	rootObject, err := mergedDefaultRootObject(ingresses)
+	if err != nil {
+		return SharedIngressParams{}, fmt.Errorf("%w: %v", errSharedParamsConflictingRootObj, err)
+	}
+
+	defaultOrigin, err := mergedDefaultOrigin(ingresses)
+	if err != nil {
+		return SharedIngressParams{}, fmt.Errorf("%w: %v", errSharedParamsConflictingDefault, err)
+	}
+
 	return SharedIngressParams{
-		WebACLARN: acl,
-		paths:     fa,
+		WebACLARN:           acl,
+		DryRun:              mergedDryRun(ingresses),
+		AdoptDistributionID: adoptID,
+		paths:               fa,
+
+		CustomErrorResponses: errorResponses,
+		GeoRestriction:       geo,
+		DefaultRootObject:    rootObject,
+		defaultOrigin:        defaultOrigin,
+
+		InvalidOptionalFields: mergedInvalidOptionalFields(ingresses),
 	}, nil
 }
 
-func (sp SharedIngressParams) PathsFromOrigin(originHost string) []Path {
-	return sp.paths[originHost]
+// IsDefaultOrigin returns whether the origin the given CDNIngress points to is the target of the default behavior
+func (sp SharedIngressParams) IsDefaultOrigin(ing CDNIngress) bool {
+	return sp.defaultOrigin != nil && *sp.defaultOrigin == newOriginKey(ing)
 }
 
-func mergedPaths(ingresses []CDNIngress) (map[string][]Path, error) {
-	// Let's put it all in a map of Paths to easily check if we already saw this
-	// Path before and whether to merge it, but we must avoid checking equality of
-	// Paths by also checking Path.FunctionAssociations, because the same Path might
-	// be specified in more than one CDNIngress (and should be merged if valid).
-	//
-	// This is why here we always assign a new Path when indexing the map,
-	// instead of just using the input Path, with an empty FunctionAssociations.
-	// This way we ensure we only check equality via PathPattern and PathType.
-	//
-	// We're also going to create a map[originHostname] because this
-	// struct will later also need to filter paths by origin, so it comes in handy
-	// to filter easily.
-	//
-	// The resulting map of all of this is a map of Path indexed by ingress name.
+// PathsFromOrigin returns the merged Paths of the origin the given CDNIngress points to, which are the Paths of
+// all CDNIngresses with the same origin host and parameters
+func (sp SharedIngressParams) PathsFromOrigin(ing CDNIngress) []Path {
+	return sp.paths[newOriginKey(ing)]
+}
 
-	mergedPaths := make(map[string]map[Path]FunctionAssociations)
+// pathKey identifies a Path regardless of its configuration, so the same Path specified in more than one
+// CDNIngress can be merged
+type pathKey struct {
+	pattern  string
+	pathType string
+}
+
+// hostKey identifies the origins of the same host and origin path, whose Paths are merged together
+type hostKey struct {
+	host string
+	path string
+}
+
+func mergedPaths(ingresses []CDNIngress) (map[originKey][]Path, error) {
+	// Paths are merged across all origins of the same host and origin path, so conflicting configuration
+	// is caught even if origins differ in other parameters, and identified by pattern and type, since the
+	// same Path might be specified in more than one CDNIngress and should be merged if valid.
+	// Merged Paths are then grouped by origin, since they're later filtered by origin.
+
+	merged := make(map[hostKey][]Path)
+	indexes := make(map[hostKey]map[pathKey]int) // map[host]map[pathKey]index in merged[host]
 	for _, ing := range ingresses {
-		_, ok := mergedPaths[ing.OriginHost]
-		if !ok {
-			mergedPaths[ing.OriginHost] = make(map[Path]FunctionAssociations)
+		host := hostKey{host: ing.OriginHost, path: ing.OriginPath}
+		if _, ok := indexes[host]; !ok {
+			indexes[host] = make(map[pathKey]int)
 		}
 
 		for _, p := range ing.UnmergedPaths {
-			pKey := Path{
-				PathPattern: p.PathPattern,
-				PathType:    p.PathType,
-			}
-			existingFA, ok := mergedPaths[ing.OriginHost][pKey]
+			key := pathKey{pattern: p.PathPattern, pathType: p.PathType}
+			i, ok := indexes[host][key]
 			if !ok {
-				mergedPaths[ing.OriginHost][pKey] = p.FunctionAssociations
+				indexes[host][key] = len(merged[host])
+				merged[host] = append(merged[host], p)
 				continue
 			}
 
-			mergedFA, err := existingFA.Merge(p.FunctionAssociations)
+			m, err := merged[host][i].merge(p)
 			if err != nil {
-				return nil, fmt.Errorf("conflicting function associations on %q: %v", p.PathPattern, err)
+				return nil, err
 			}
+			merged[host][i] = m
+		}
+	}
+
+	result := make(map[originKey][]Path)
+	added := make(map[originKey]map[pathKey]bool)
+	for _, ing := range ingresses {
+		host := hostKey{host: ing.OriginHost, path: ing.OriginPath}
+		origin := newOriginKey(ing)
+		if _, ok := added[origin]; !ok {
+			added[origin] = make(map[pathKey]bool)
+		}
 
-			mergedPaths[ing.OriginHost][pKey] = mergedFA
+		for _, p := range ing.UnmergedPaths {
+			key := pathKey{pattern: p.PathPattern, pathType: p.PathType}
+			if added[origin][key] {
+				continue
+			}
+			added[origin][key] = true
+			result[origin] = append(result[origin], merged[host][indexes[host][key]])
 		}
 	}
 
-	return mapOfOriginHostToPath(mergedPaths), nil
+	return result, nil
 }
 
-func mapOfOriginHostToPath(ingsPathsAndFAs map[string]map[Path]FunctionAssociations) map[string][]Path {
-	s := make(map[string][]Path)
-	for originHost, pathsAndFAs := range ingsPathsAndFAs {
-		for p, fa := range pathsAndFAs {
-			path := Path{
-				PathPattern:          p.PathPattern,
-				PathType:             p.PathType,
-				FunctionAssociations: fa,
-			}
-			s[originHost] = append(s[originHost], path)
+func mergedInvalidOptionalFields(ingresses []CDNIngress) []v1alpha1.OptionalField {
+	fields := sets.NewString()
+	for _, ing := range ingresses {
+		for _, f := range ing.InvalidOptionalFields {
+			fields.Insert(string(f))
 		}
 	}
-	return s
+
+	var result []v1alpha1.OptionalField
+	for _, f := range fields.List() {
+		result = append(result, v1alpha1.OptionalField(f))
+	}
+	return result
+}
+
+func mergedDryRun(ingresses []CDNIngress) bool {
+	for _, ing := range ingresses {
+		if ing.DryRun {
+			return true
+		}
+	}
+	return false
+}
+
+// IsGroupReleased returns whether any of the given CDNIngresses asks for its group to be released
+func IsGroupReleased(ingresses []CDNIngress) bool {
+	for _, ing := range ingresses {
+		if ing.Release {
+			return true
+		}
+	}
+	return false
 }
 
 func mergedWebACL(ingresses []CDNIngress) (string, error) {
@@ -201,6 +403,59 @@ func mergedWebACL(ingresses []CDNIngress) (string, error) {
 	return validARN, nil
 }
 
+func mergedAdoptDistributionID(ingresses []CDNIngress) (string, error) {
+	ids := sets.NewString()
+	for _, ing := range ingresses {
+		if len(ing.UnmergedAdoptDistributionID) > 0 {
+			ids.Insert(ing.UnmergedAdoptDistributionID)
+		}
+	}
+
+	if len(ids) > 1 {
+		return "", fmt.Errorf("more than one distribution ID specified: %v", ids.List())
+	}
+
+	id, _ := ids.PopAny()
+	return id, nil
+}
+
+func mergedDefaultRootObject(ingresses []CDNIngress) (*string, error) {
+	var result *string
+	objects := sets.NewString()
+	for _, ing := range ingresses {
+		if ing.UnmergedDefaultRootObject != nil {
+			result = ing.UnmergedDefaultRootObject
+			objects.Insert(*result)
+		}
+	}
+
+	if len(objects) > 1 {
+		return nil, fmt.Errorf("more than one default root object specified: %q", objects.List())
+	}
+	return result, nil
+}
+
+// mergedDefaultOrigin returns the origin of the CDNIngresses asking to be the target of the default behavior, if any.
+// CDNIngresses of the same origin may ask for it, but not CDNIngresses of different origins.
+func mergedDefaultOrigin(ingresses []CDNIngress) (*originKey, error) {
+	var result *originKey
+	hosts := sets.NewString()
+	for _, ing := range ingresses {
+		if !ing.DefaultBehavior {
+			continue
+		}
+
+		key := newOriginKey(ing)
+		if result != nil && *result != key {
+			return nil, fmt.Errorf("more than one origin specified as the default behavior's target: %v",
+				hosts.Insert(ing.OriginHost+ing.OriginPath).List())
+		}
+		result = &key
+		hosts.Insert(ing.OriginHost + ing.OriginPath)
+	}
+	return result, nil
+}
+
 // NewCDNIngressFromV1 creates a new CDNIngress from a v1 Ingress
 func NewCDNIngressFromV1(ctx context.Context, ing *networkingv1.Ingress, class CDNClass) (CDNIngress, error) {
 	tags, err := tagsAnnotationValue(ing)
@@ -218,6 +473,47 @@ func NewCDNIngressFromV1(ctx context.Context, ing *networkingv1.Ingress, class C
 		return CDNIngress{}, err
 	}
 
+	protocol, err := originProtocol(ing)
+	if err != nil {
+		logInvalidAnnotation(ctx, ing, "origin protocol", err)
+		protocol = OriginProtocol{}
+	}
+
+	originPath := ing.GetAnnotations()[cfOrigPathAnnotation]
+	if err := validateOriginPath(originPath); err != nil {
+		logInvalidAnnotation(ctx, ing, "origin path", err)
+		originPath = ""
+	}
+
+	fo, err := failover(ing)
+	if err != nil {
+		logInvalidAnnotation(ctx, ing, "failover", err)
+		fo = nil
+	}
+
+	var invalidFields []v1alpha1.OptionalField
+
+	errorResponses, err := customErrorResponses(ing)
+	if err != nil {
+		logInvalidAnnotation(ctx, ing, "custom error responses", err)
+		errorResponses = nil
+		invalidFields = append(invalidFields, v1alpha1.OptionalFieldCustomErrorResponses)
+	}
+
+	geo, err := geoRestriction(ing)
+	if err != nil {
+		logInvalidAnnotation(ctx, ing, "geo restriction", err)
+		geo = nil
+		invalidFields = append(invalidFields, v1alpha1.OptionalFieldGeoRestriction)
+	}
+
+	rootObject, err := defaultRootObject(ing)
+	if err != nil {
+		logInvalidAnnotation(ctx, ing, "default root object", err)
+		rootObject = nil
+		invalidFields = append(invalidFields, v1alpha1.OptionalFieldDefaultRootObject)
+	}
+
 	result := CDNIngress{
 		NamespacedName: types.NamespacedName{
 			Namespace: ing.Namespace,
@@ -230,12 +526,24 @@ func NewCDNIngressFromV1(ctx context.Context, ing *networkingv1.Ingress, class C
 		CachePolicy:          cachePolicy(ing),
 		ResponsePolicy:       responsePolicy(ing),
 		OriginRespTimeout:    originRespTimeout(ing),
+		OriginPath:           originPath,
+		OriginProtocol:       protocol,
+		Failover:             fo,
 		AlternateDomainNames: alternateDomainNames(ing),
 		UnmergedWebACLARN:    webACLARN(ing),
 		IsBeingRemoved:       IsBeingRemovedFromDesiredState(ing),
 		Class:                class,
 		Tags:                 tags,
 		OriginAccess:         CFUserOriginAccessPublic,
+		DryRun:               dryRun(ing),
+		Release:              release(ing),
+
+		UnmergedAdoptDistributionID:  adoptDistributionID(ing),
+		UnmergedCustomErrorResponses: errorResponses,
+		UnmergedGeoRestriction:       geo,
+		UnmergedDefaultRootObject:    rootObject,
+		DefaultBehavior:              defaultBehavior(ing),
+		InvalidOptionalFields:        invalidFields,
 	}
 
 	if len(ing.Status.LoadBalancer.Ingress) > 0 {
@@ -245,6 +553,15 @@ func NewCDNIngressFromV1(ctx context.Context, ing *networkingv1.Ingress, class C
 	return result, nil
 }
 
+// logInvalidAnnotation complains about an invalid annotation, which is ignored when calculating the desired state, or
+// whose field keeps its current value on the distribution if it's an optional one. Reconciliation of all Ingresses
+// isn't halted because one of them is bad: the bad Ingress itself is rejected when validated by the admission webhook
+// or reconciled.
+func logInvalidAnnotation(ctx context.Context, ing *networkingv1.Ingress, setting string, err error) {
+	log.FromContext(ctx).Error(err, "Found invalid "+setting+" when calculating desired state",
+		"invalidIngress", ing.Namespace+"/"+ing.Name)
+}
+
 func pathsV1(ctx context.Context, ing *networkingv1.Ingress) ([]Path, error) {
 	fa, err := functionAssociations(ing)
 	if err != nil {
@@ -257,10 +574,63 @@ func pathsV1(ctx context.Context, ing *networkingv1.Ingress) ([]Path, error) {
 			cfViewerFnAnnotation, cfFunctionAssociationsAnnotation, cfFunctionAssociationsAnnotation)
 	}
 
+	methods, err := methodsByPath(ing)
+	if err != nil {
+		return nil, fmt.Errorf("parsing methods from annotation: %v", err)
+	}
+
+	policies, err := viewerProtocolPoliciesByPath(ing)
+	if err != nil {
+		return nil, fmt.Errorf("parsing viewer protocol policies from annotation: %v", err)
+	}
+
+	var paths []Path
 	if len(viewerFn) > 0 {
-		return pathsForViewerFunction(ing, viewerFn), nil
+		paths = pathsForViewerFunction(ing, viewerFn)
+	} else {
+		paths = pathsForFunctionAssociations(ctx, ing, fa)
 	}
-	return pathsForFunctionAssociations(ctx, ing, fa), nil
+	paths = withMethods(ctx, ing, paths, methods)
+	return withViewerProtocolPolicies(ctx, ing, paths, policies), nil
+}
+
+func withMethods(ctx context.Context, ing *networkingv1.Ingress, paths []Path, methods map[string]Methods) []Path {
+	for i, p := range paths {
+		m, ok := methods[p.PathPattern]
+		if !ok {
+			continue
+		}
+
+		normalized, err := m.normalized()
+		if err != nil {
+			// complain about invalid methods for now, but don't halt reconciliation of all Ingresses because one of them is bad
+			// the bad ingress itself will throw an error when reconciled due to invalid annotation
+			log.FromContext(ctx).Error(err, "Found invalid methods when calculating desired state",
+				"methods", m, "invalidIngress", ing.Namespace+"/"+ing.Name)
+			continue
+		}
+		paths[i].Methods = normalized
+	}
+	return paths
+}
+
+func withViewerProtocolPolicies(ctx context.Context, ing *networkingv1.Ingress, paths []Path, policies map[string]string) []Path {
+	for i, p := range paths {
+		policy, ok := policies[p.PathPattern]
+		if !ok {
+			continue
+		}
+
+		if err := validateViewerProtocolPolicy(policy); err != nil {
+			// complain about invalid policies for now, but don't halt reconciliation of all Ingresses because one of them is bad
+			// the bad ingress itself will throw an error when reconciled due to invalid annotation
+			log.FromContext(ctx).Error(err, "Found invalid viewer protocol policy when calculating desired state",
+				"viewerProtocolPolicy", policy, "invalidIngress", ing.Namespace+"/"+ing.Name)
+			continue
+		}
+		paths[i].ViewerProtocolPolicy = policy
+	}
+	return paths
 }
 
 func pathsForViewerFunction(ing *networkingv1.Ingress, fnARN string) []Path {
@@ -350,6 +720,58 @@ func parseOriginHeaders(rawHeaders string) (map[string]string, error) {
 	return result, nil
 }
 
+// validateOriginPath returns an error if the origin path is not empty nor a path CloudFront accepts
+func validateOriginPath(path string) error {
+	if len(path) == 0 {
+		return nil
+	}
+	if !strings.HasPrefix(path, "/") || strings.HasSuffix(path, "/") {
+		return fmt.Errorf("invalid origin path %q: it must start with a slash and must not end with one", path)
+	}
+	if len(path) > maxOriginPathLength {
+		return fmt.Errorf("invalid origin path %q: it must not be longer than %d characters", path, maxOriginPathLength)
+	}
+	return nil
+}
+
+// ValidateIngressOriginPath returns an error if the Ingress configures an origin path CloudFront doesn't accept
+func ValidateIngressOriginPath(ing *networkingv1.Ingress) error {
+	return validateOriginPath(ing.GetAnnotations()[cfOrigPathAnnotation])
+}
+
+// validateDefaultRootObject returns an error if the default root object is not empty nor an object name CloudFront accepts
+func validateDefaultRootObject(object string) error {
+	if strings.HasPrefix(object, "/") {
+		return fmt.Errorf("invalid default root object %q: it must not start with a slash", object)
+	}
+	if strings.ContainsAny(object, " \t\n") {
+		return fmt.Errorf("invalid default root object %q: it must not contain whitespace", object)
+	}
+	if len(object) > maxDefaultRootObjectLength {
+		return fmt.Errorf("invalid default root object %q: it must not be longer than %d characters", object, maxDefaultRootObjectLength)
+	}
+	return nil
+}
+
+// ValidateIngressDefaultRootObject returns an error if the Ingress configures a default root object CloudFront doesn't accept
+func ValidateIngressDefaultRootObject(ing *networkingv1.Ingress) error {
+	_, err := defaultRootObject(ing)
+	return err
+}
+
+func defaultRootObject(obj client.Object) (*string, error) {
+	val, ok := obj.GetAnnotations()[cfDefaultRootObjectAnnotation]
+	if !ok {
+		return nil, nil
+	}
+
+	val = strings.TrimSpace(val)
+	if err := validateDefaultRootObject(val); err != nil {
+		return nil, fmt.Errorf("annotation %q: %v", cfDefaultRootObjectAnnotation, err)
+	}
+	return &val, nil
+}
+
 func viewerFnARN(obj client.Object) string {
 	return obj.GetAnnotations()[cfViewerFnAnnotation]
 }
@@ -406,6 +828,25 @@ func alternateDomainNames(obj client.Object) (domainNames []string) {
 	return
 }
 
+func defaultBehavior(obj client.Object) bool {
+	val, _ := strconv.ParseBool(obj.GetAnnotations()[cfDefaultBehaviorAnnotation])
+	return val
+}
+
+func dryRun(obj client.Object) bool {
+	val, _ := strconv.ParseBool(obj.GetAnnotations()[cfDryRunAnnotation])
+	return val
+}
+
 func webACLARN(obj client.Object) string {
 	return obj.GetAnnotations()[cfWebACLARNAnnotation]
 }
+
+func release(obj client.Object) bool {
+	val, _ := strconv.ParseBool(obj.GetAnnotations()[cfReleaseAnnotation])
+	return val
+}
+
+func adoptDistributionID(obj client.Object) string {
+	return strings.TrimSpace(obj.GetAnnotations()[cfAdoptDistributionIDAnnotation])
+}
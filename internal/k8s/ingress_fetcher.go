@@ -21,6 +21,8 @@ package k8s
 
 import (
 	"context"
+
+	"k8s.io/apimachinery/pkg/types"
 )
 
 // IngressFetcher interacts with Kubernetes to fetch networking.k8s.io Ingress resources
@@ -28,4 +30,8 @@ type IngressFetcher interface {
 	// FetchBy fetches all Ingresses and returns a slice of the ones matching the given predicate.
 	// User-supplied origins present in annotations of these Ingresses are also included in the output.
 	FetchBy(ctx context.Context, cdnClass CDNClass, predicate func(CDNIngress) bool) ([]CDNIngress, error)
+	// FetchGroupMembers fetches the provisioned members of a group which aren't being removed, except for the one
+	// identified by exclude, which is skipped before being parsed.
+	// Members which can't be parsed are skipped as well, and a warning describing each of them is returned.
+	FetchGroupMembers(ctx context.Context, group string, exclude types.NamespacedName) ([]CDNIngress, []string, error)
 }
@@ -24,6 +24,7 @@ import (
 	"fmt"
 
 	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -59,3 +60,45 @@ func (i ingFetcherV1) FetchBy(ctx context.Context, cdnClass CDNClass, predicate
 	}
 	return result, nil
 }
+
+func (i ingFetcherV1) FetchGroupMembers(ctx context.Context, group string, exclude types.NamespacedName) ([]CDNIngress, []string, error) {
+	list := &networkingv1.IngressList{}
+	if err := i.k8sClient.List(ctx, list); err != nil {
+		return nil, nil, fmt.Errorf("listing Ingresses: %v", err)
+	}
+
+	var result []CDNIngress
+	var warnings []string
+	for idx := range list.Items {
+		k8sIng := &list.Items[idx]
+		if client.ObjectKeyFromObject(k8sIng) == exclude || groupAnnotationValue(k8sIng) != group {
+			continue
+		}
+
+		ing, err := NewCDNIngressFromV1(ctx, k8sIng, CDNClass{})
+		if err != nil {
+			warnings = append(warnings, invalidMemberWarning("Ingress", k8sIng, err))
+			continue
+		}
+		if !isProvisionedMember(ing) {
+			continue
+		}
+
+		userOriginsCDNIngresses, err := cdnIngressesForUserOrigins(k8sIng)
+		if err != nil {
+			warnings = append(warnings, invalidMemberWarning("Ingress", k8sIng, err))
+			continue
+		}
+		result = append(result, ing)
+		result = append(result, userOriginsCDNIngresses...)
+	}
+	return result, warnings, nil
+}
+
+func isProvisionedMember(ing CDNIngress) bool {
+	return len(ing.OriginHost) > 0 && !ing.IsBeingRemoved
+}
+
+func invalidMemberWarning(kind string, obj client.Object, err error) string {
+	return fmt.Sprintf("ignoring invalid %s %s/%s of the same group: %v", kind, obj.GetNamespace(), obj.GetName(), err)
+}
@@ -265,6 +265,45 @@ func (s *IngressFetcherV1TestSuite) TestFetchBy_FailureWithUserOrigins() {
 	}
 }
 
+func (s *IngressFetcherV1TestSuite) TestFetchGroupMembers() {
+	client := fake.NewClientBuilder().
+		WithLists(&networkingv1.IngressList{
+			Items: []networkingv1.Ingress{
+				*newIngressV1WithLB("namespace", "member", map[string]string{CDNGroupAnnotation: "group"}),
+				*newIngressV1WithLB("namespace", "excluded", map[string]string{
+					CDNGroupAnnotation: "group",
+					cfTagsAnnotation:   "not: [valid",
+				}),
+				*newIngressV1WithLB("namespace", "invalid", map[string]string{
+					CDNGroupAnnotation: "group",
+					cfTagsAnnotation:   "not: [valid",
+				}),
+				*newIngressV1WithLB("namespace", "other-group", map[string]string{
+					CDNGroupAnnotation: "other-group",
+					cfTagsAnnotation:   "not: [valid",
+				}),
+			},
+		}).
+		Build()
+
+	exclude := types.NamespacedName{Namespace: "namespace", Name: "excluded"}
+	got, warnings, err := NewIngressFetcherV1(client).FetchGroupMembers(context.Background(), "group", exclude)
+	s.NoError(err)
+	s.Len(got, 1)
+	s.Equal("member", got[0].Name)
+	s.Len(warnings, 1)
+	s.Contains(warnings[0], "namespace/invalid")
+}
+
+func (s *IngressFetcherV1TestSuite) TestFetchGroupMembers_FailureToListIngresses() {
+	client := &test.MockK8sClient{}
+	client.On("List", mock.Anything, mock.Anything, mock.Anything).Return(errors.New("mock err"))
+
+	got, _, err := NewIngressFetcherV1(client).FetchGroupMembers(context.Background(), "group", types.NamespacedName{})
+	s.Error(err)
+	s.Nil(got)
+}
+
 func newIngressV1WithLB(namespace, name string, annotations map[string]string) *networkingv1.Ingress {
 	return &networkingv1.Ingress{
 		ObjectMeta: metav1.ObjectMeta{
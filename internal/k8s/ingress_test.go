@@ -21,11 +21,15 @@ package k8s
 
 import (
 	"context"
+	"strings"
 	"testing"
 
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/stretchr/testify/suite"
 	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/Gympass/cdn-origin-controller/api/v1alpha1"
 )
 
 func TestRunCDNIngressTestSuite(t *testing.T) {
@@ -156,6 +160,82 @@ func (s *CDNIngressSuite) TestNewCDNIngressFromV1_UsingFunctionAssociationsOnlyI
 	}
 }
 
+func (s *CDNIngressSuite) TestNewCDNIngressFromV1_WithMethodsAnnotation() {
+	methodsYAML := `
+/foo:
+  allowedMethods: [head, get, options]
+  cachedMethods: [GET, HEAD, OPTIONS]
+/bar:
+  allowedMethods: [GET, POST]
+`
+	pathType := networkingv1.PathTypeImplementationSpecific
+	ing := &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "foo",
+			Namespace:   "bar",
+			Annotations: map[string]string{cfMethodsAnnotation: methodsYAML},
+		},
+		Spec: networkingv1.IngressSpec{
+			Rules: []networkingv1.IngressRule{
+				{
+					IngressRuleValue: networkingv1.IngressRuleValue{HTTP: &networkingv1.HTTPIngressRuleValue{
+						Paths: []networkingv1.HTTPIngressPath{
+							{Path: "/foo", PathType: &pathType},
+							{Path: "/bar", PathType: &pathType},
+							{Path: "/baz", PathType: &pathType},
+						},
+					}},
+				},
+			},
+		},
+	}
+
+	got, err := NewCDNIngressFromV1(context.Background(), ing, CDNClass{})
+	s.NoError(err)
+	s.Len(got.UnmergedPaths, 3)
+	s.Equal(Methods{
+		Allowed: []string{"GET", "HEAD", "OPTIONS"},
+		Cached:  []string{"GET", "HEAD", "OPTIONS"},
+	}, got.UnmergedPaths[0].Methods)
+	s.True(got.UnmergedPaths[1].Methods.IsEmpty(), "invalid methods should be ignored")
+	s.True(got.UnmergedPaths[2].Methods.IsEmpty())
+}
+
+func (s *CDNIngressSuite) TestNewCDNIngressFromV1_WithViewerProtocolPolicyAnnotation() {
+	policiesYAML := `
+/foo: https-only
+/bar: invalid
+`
+	pathType := networkingv1.PathTypeImplementationSpecific
+	ing := &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "foo",
+			Namespace:   "bar",
+			Annotations: map[string]string{cfViewerProtocolPolicyAnnotation: policiesYAML},
+		},
+		Spec: networkingv1.IngressSpec{
+			Rules: []networkingv1.IngressRule{
+				{
+					IngressRuleValue: networkingv1.IngressRuleValue{HTTP: &networkingv1.HTTPIngressRuleValue{
+						Paths: []networkingv1.HTTPIngressPath{
+							{Path: "/foo", PathType: &pathType},
+							{Path: "/bar", PathType: &pathType},
+							{Path: "/baz", PathType: &pathType},
+						},
+					}},
+				},
+			},
+		},
+	}
+
+	got, err := NewCDNIngressFromV1(context.Background(), ing, CDNClass{})
+	s.NoError(err)
+	s.Len(got.UnmergedPaths, 3)
+	s.Equal("https-only", got.UnmergedPaths[0].ViewerProtocolPolicy)
+	s.Empty(got.UnmergedPaths[1].ViewerProtocolPolicy, "invalid policies should be ignored")
+	s.Empty(got.UnmergedPaths[2].ViewerProtocolPolicy)
+}
+
 func (s *CDNIngressSuite) TestNewCDNIngressFromV1_UsingFunctionAssociationsAndViewerFunctionARNIsInvalid() {
 	faYAML := `
 /foo/*:
@@ -225,6 +305,179 @@ func (s *CDNIngressSuite) TestNewCDNIngressFromV1_WithOriginHeadersAnnotationIsV
 	s.Equal(map[string]string{"key": "value"}, got.OriginHeaders)
 }
 
+func (s *CDNIngressSuite) TestNewCDNIngressFromV1_WithDryRunAnnotation() {
+	ing := &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "foo",
+			Namespace: "bar",
+			Annotations: map[string]string{
+				cfDryRunAnnotation: "true",
+			},
+		},
+	}
+
+	got, err := NewCDNIngressFromV1(context.Background(), ing, CDNClass{})
+	s.NoError(err)
+	s.True(got.DryRun)
+}
+
+func (s *CDNIngressSuite) TestNewCDNIngressFromV1_WithOriginPathAnnotation() {
+	ing := &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "foo",
+			Namespace:   "bar",
+			Annotations: map[string]string{cfOrigPathAnnotation: "/prefix"},
+		},
+	}
+
+	got, err := NewCDNIngressFromV1(context.Background(), ing, CDNClass{})
+	s.NoError(err)
+	s.Equal("/prefix", got.OriginPath)
+
+	ing.Annotations[cfOrigPathAnnotation] = "/prefix/"
+	got, err = NewCDNIngressFromV1(context.Background(), ing, CDNClass{})
+	s.NoError(err)
+	s.Empty(got.OriginPath, "invalid origin paths should be ignored")
+	s.Error(ValidateIngressOriginPath(ing))
+}
+
+func (s *CDNIngressSuite) TestNewCDNIngressFromV1_WithOriginProtocolAnnotations() {
+	ing := &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "foo",
+			Namespace: "bar",
+			Annotations: map[string]string{
+				cfOrigProtocolPolicyAnnotation: "https-only",
+				cfOrigHTTPSPortAnnotation:      "8443",
+			},
+		},
+	}
+
+	got, err := NewCDNIngressFromV1(context.Background(), ing, CDNClass{})
+	s.NoError(err)
+	s.Equal(OriginProtocol{Policy: "https-only", HTTPSPort: 8443}, got.OriginProtocol)
+
+	ing.Annotations[cfOrigHTTPSPortAnnotation] = "22"
+	got, err = NewCDNIngressFromV1(context.Background(), ing, CDNClass{})
+	s.NoError(err)
+	s.Equal(OriginProtocol{}, got.OriginProtocol, "invalid origin protocols should be ignored")
+}
+
+func (s *CDNIngressSuite) TestNewCDNIngressFromV1_WithFailoverAnnotation() {
+	ing := &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "foo",
+			Namespace:   "bar",
+			Annotations: map[string]string{cfFailoverAnnotation: "host: foo.com"},
+		},
+	}
+
+	got, err := NewCDNIngressFromV1(context.Background(), ing, CDNClass{})
+	s.NoError(err)
+	s.Equal(&Failover{Host: "foo.com", OriginAccess: CFUserOriginAccessPublic, StatusCodes: defaultFailoverStatusCodes}, got.Failover)
+
+	ing.Annotations[cfFailoverAnnotation] = "host: foo.com\nstatusCodes: [501]"
+	got, err = NewCDNIngressFromV1(context.Background(), ing, CDNClass{})
+	s.NoError(err)
+	s.Nil(got.Failover, "invalid failovers should be ignored")
+}
+
+func (s *CDNIngressSuite) TestNewCDNIngressFromV1_WithCustomErrorResponsesAnnotation() {
+	ing := &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "foo",
+			Namespace:   "bar",
+			Annotations: map[string]string{cfCustomErrorResponsesAnnotation: "- errorCode: 404"},
+		},
+	}
+
+	got, err := NewCDNIngressFromV1(context.Background(), ing, CDNClass{})
+	s.NoError(err)
+	s.Equal([]CustomErrorResponse{{ErrorCode: 404}}, got.UnmergedCustomErrorResponses)
+	s.Empty(got.InvalidOptionalFields)
+
+	ing.Annotations[cfCustomErrorResponsesAnnotation] = "- errorCode: 200"
+	got, err = NewCDNIngressFromV1(context.Background(), ing, CDNClass{})
+	s.NoError(err)
+	s.Nil(got.UnmergedCustomErrorResponses, "invalid custom error responses should be ignored")
+	s.Equal([]v1alpha1.OptionalField{v1alpha1.OptionalFieldCustomErrorResponses}, got.InvalidOptionalFields,
+		"invalid custom error responses should be kept instead of considered no longer declared")
+}
+
+func (s *CDNIngressSuite) TestNewCDNIngressFromV1_WithGeoRestrictionAnnotation() {
+	ing := &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "foo",
+			Namespace:   "bar",
+			Annotations: map[string]string{cfGeoRestrictionAnnotation: "type: allow\ncountries: [br]"},
+		},
+	}
+
+	got, err := NewCDNIngressFromV1(context.Background(), ing, CDNClass{})
+	s.NoError(err)
+	s.Equal(&GeoRestriction{Type: GeoRestrictionAllow, Countries: []string{"BR"}}, got.UnmergedGeoRestriction)
+	s.Empty(got.InvalidOptionalFields)
+
+	ing.Annotations[cfGeoRestrictionAnnotation] = "type: allow\ncountries: [XX]"
+	got, err = NewCDNIngressFromV1(context.Background(), ing, CDNClass{})
+	s.NoError(err)
+	s.Nil(got.UnmergedGeoRestriction, "invalid geo restrictions should be ignored")
+	s.Equal([]v1alpha1.OptionalField{v1alpha1.OptionalFieldGeoRestriction}, got.InvalidOptionalFields,
+		"invalid geo restrictions should be kept instead of considered no longer declared")
+}
+
+func (s *CDNIngressSuite) Test_validateOriginPath() {
+	s.NoError(validateOriginPath(""))
+	s.NoError(validateOriginPath("/foo"))
+	s.NoError(validateOriginPath("/foo/bar"))
+	s.Error(validateOriginPath("/"))
+	s.Error(validateOriginPath("foo"))
+	s.Error(validateOriginPath("/foo/"))
+	s.Error(validateOriginPath("/" + strings.Repeat("a", 255)))
+}
+
+func (s *CDNIngressSuite) TestNewCDNIngressFromV1_WithDefaultRootObjectAnnotation() {
+	ing := &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "foo",
+			Namespace:   "bar",
+			Annotations: map[string]string{cfDefaultRootObjectAnnotation: " index.html "},
+		},
+	}
+
+	got, err := NewCDNIngressFromV1(context.Background(), ing, CDNClass{})
+	s.NoError(err)
+	s.Equal(aws.String("index.html"), got.UnmergedDefaultRootObject)
+	s.Empty(got.InvalidOptionalFields)
+
+	ing.Annotations[cfDefaultRootObjectAnnotation] = ""
+	got, err = NewCDNIngressFromV1(context.Background(), ing, CDNClass{})
+	s.NoError(err)
+	s.Equal(aws.String(""), got.UnmergedDefaultRootObject, "an empty annotation removes the default root object")
+
+	ing.Annotations[cfDefaultRootObjectAnnotation] = "/index.html"
+	got, err = NewCDNIngressFromV1(context.Background(), ing, CDNClass{})
+	s.NoError(err)
+	s.Nil(got.UnmergedDefaultRootObject, "invalid default root objects should be ignored")
+	s.Equal([]v1alpha1.OptionalField{v1alpha1.OptionalFieldDefaultRootObject}, got.InvalidOptionalFields,
+		"invalid default root objects should be kept instead of considered no longer declared")
+	s.Error(ValidateIngressDefaultRootObject(ing))
+
+	delete(ing.Annotations, cfDefaultRootObjectAnnotation)
+	got, err = NewCDNIngressFromV1(context.Background(), ing, CDNClass{})
+	s.NoError(err)
+	s.Nil(got.UnmergedDefaultRootObject)
+}
+
+func (s *CDNIngressSuite) Test_validateDefaultRootObject() {
+	s.NoError(validateDefaultRootObject(""))
+	s.NoError(validateDefaultRootObject("index.html"))
+	s.NoError(validateDefaultRootObject("docs/index.html"))
+	s.Error(validateDefaultRootObject("/index.html"))
+	s.Error(validateDefaultRootObject("my index.html"))
+	s.Error(validateDefaultRootObject(strings.Repeat("a", 256)))
+}
+
 func (s *CDNIngressSuite) TestNewCDNIngressFromV1_WithMalformedOriginHeadersAnnotationIsInvalid() {
 	testCases := []struct {
 		name       string
@@ -297,8 +550,8 @@ func (s *CDNIngressSuite) Test_sharedIngressParams_SingleOriginIsValid() {
 
 	expected := SharedIngressParams{
 		WebACLARN: "arn:aws:wafv2:us-east-1:000000000000:global/webacl/foo/00000-5c43-4ea0-8424-2ed34dd3434",
-		paths: map[string][]Path{
-			"foo.bar": {
+		paths: map[originKey][]Path{
+			{host: "foo.bar"}: {
 				{
 					PathPattern: "/",
 					PathType:    "Prefix",
@@ -351,8 +604,8 @@ func (s *CDNIngressSuite) Test_sharedIngressParams_MultipleOriginsWithDifferentP
 	shared, err := NewSharedIngressParams(params)
 
 	expected := SharedIngressParams{
-		paths: map[string][]Path{
-			"foo.bar": {
+		paths: map[originKey][]Path{
+			{host: "foo.bar"}: {
 				{
 					PathPattern: "/",
 					PathType:    "Prefix",
@@ -361,7 +614,7 @@ func (s *CDNIngressSuite) Test_sharedIngressParams_MultipleOriginsWithDifferentP
 					},
 				},
 			},
-			"foo.bar2": {
+			{host: "foo.bar2"}: {
 				{
 					PathPattern: "/foo",
 					PathType:    "Prefix",
@@ -414,8 +667,8 @@ func (s *CDNIngressSuite) Test_sharedIngressParams_MultipleOriginsWithSamePathBu
 	shared, err := NewSharedIngressParams(params)
 
 	expected := SharedIngressParams{
-		paths: map[string][]Path{
-			"foo.bar": {
+		paths: map[originKey][]Path{
+			{host: "foo.bar"}: {
 				{
 					PathPattern: "/",
 					PathType:    "Prefix",
@@ -541,6 +794,115 @@ func (s *CDNIngressSuite) Test_sharedIngressParams_FunctionAssociationsForSameEv
 	s.ErrorIs(err, errSharedParamsConflictingPaths)
 }
 
+func (s *CDNIngressSuite) Test_sharedIngressParams_MethodsForSamePath() {
+	readOnly := Methods{Allowed: []string{"GET", "HEAD"}}
+	params := []CDNIngress{
+		{
+			Group:         "foo",
+			OriginHost:    "origin",
+			UnmergedPaths: []Path{{PathPattern: "/", PathType: "Prefix", Methods: readOnly}},
+		},
+		{
+			Group:         "foo",
+			OriginHost:    "origin",
+			UnmergedPaths: []Path{{PathPattern: "/", PathType: "Prefix"}},
+		},
+	}
+
+	shared, err := NewSharedIngressParams(params)
+	s.NoError(err)
+	s.Equal([]Path{{PathPattern: "/", PathType: "Prefix", Methods: readOnly}}, shared.PathsFromOrigin(CDNIngress{OriginHost: "origin"}))
+
+	params[1].UnmergedPaths[0].Methods = Methods{Allowed: []string{"GET", "HEAD", "OPTIONS"}}
+	shared, err = NewSharedIngressParams(params)
+	s.Empty(shared)
+	s.ErrorIs(err, errSharedParamsConflictingPaths)
+}
+
+func (s *CDNIngressSuite) Test_sharedIngressParams_ViewerProtocolPolicyForSamePath() {
+	params := []CDNIngress{
+		{
+			Group:         "foo",
+			OriginHost:    "origin",
+			UnmergedPaths: []Path{{PathPattern: "/", PathType: "Prefix"}},
+		},
+		{
+			Group:         "foo",
+			OriginHost:    "origin",
+			UnmergedPaths: []Path{{PathPattern: "/", PathType: "Prefix", ViewerProtocolPolicy: "allow-all"}},
+		},
+	}
+
+	shared, err := NewSharedIngressParams(params)
+	s.NoError(err)
+	s.Equal([]Path{{PathPattern: "/", PathType: "Prefix", ViewerProtocolPolicy: "allow-all"}}, shared.PathsFromOrigin(CDNIngress{OriginHost: "origin"}))
+
+	params[0].UnmergedPaths[0].ViewerProtocolPolicy = "https-only"
+	shared, err = NewSharedIngressParams(params)
+	s.Empty(shared)
+	s.ErrorIs(err, errSharedParamsConflictingPaths)
+}
+
+func (s *CDNIngressSuite) Test_sharedIngressParams_SameHostWithDifferentOriginPaths() {
+	params := []CDNIngress{
+		{
+			Group:         "foo",
+			OriginHost:    "origin",
+			OriginPath:    "/a",
+			UnmergedPaths: []Path{{PathPattern: "/a/*", PathType: "Prefix"}},
+		},
+		{
+			Group:         "foo",
+			OriginHost:    "origin",
+			OriginPath:    "/b",
+			UnmergedPaths: []Path{{PathPattern: "/b/*", PathType: "Prefix"}},
+		},
+	}
+
+	shared, err := NewSharedIngressParams(params)
+	s.NoError(err)
+	s.Equal([]Path{{PathPattern: "/a/*", PathType: "Prefix"}}, shared.PathsFromOrigin(params[0]))
+	s.Equal([]Path{{PathPattern: "/b/*", PathType: "Prefix"}}, shared.PathsFromOrigin(params[1]))
+	s.Empty(shared.PathsFromOrigin(CDNIngress{OriginHost: "origin"}))
+}
+
+func (s *CDNIngressSuite) Test_sharedIngressParams_SameHostWithDifferentOriginParameters() {
+	params := []CDNIngress{
+		{
+			Group:             "foo",
+			OriginHost:        "origin",
+			OriginRespTimeout: 10,
+			UnmergedPaths:     []Path{{PathPattern: "/fast", PathType: "Prefix"}, {PathPattern: "/", PathType: "Prefix"}},
+		},
+		{
+			Group:             "foo",
+			OriginHost:        "origin",
+			OriginRespTimeout: 60,
+			OriginHeaders:     map[string]string{"foo": "bar"},
+			UnmergedPaths: []Path{
+				{PathPattern: "/slow", PathType: "Prefix"},
+				{PathPattern: "/", PathType: "Prefix", ViewerProtocolPolicy: "https-only"},
+			},
+		},
+	}
+
+	shared, err := NewSharedIngressParams(params)
+	s.NoError(err)
+	s.Equal([]Path{
+		{PathPattern: "/fast", PathType: "Prefix"},
+		{PathPattern: "/", PathType: "Prefix", ViewerProtocolPolicy: "https-only"},
+	}, shared.PathsFromOrigin(params[0]))
+	s.Equal([]Path{
+		{PathPattern: "/slow", PathType: "Prefix"},
+		{PathPattern: "/", PathType: "Prefix", ViewerProtocolPolicy: "https-only"},
+	}, shared.PathsFromOrigin(params[1]))
+	s.Empty(shared.PathsFromOrigin(CDNIngress{OriginHost: "origin"}))
+
+	params[0].UnmergedPaths[1].ViewerProtocolPolicy = "allow-all"
+	_, err = NewSharedIngressParams(params)
+	s.ErrorIs(err, errSharedParamsConflictingPaths, "paths of the same host are merged regardless of origin parameters")
+}
+
 func (s *CDNIngressSuite) Test_sharedIngressParams_ConflictingWebACLs() {
 	params := []CDNIngress{
 		{
@@ -559,10 +921,144 @@ func (s *CDNIngressSuite) Test_sharedIngressParams_ConflictingWebACLs() {
 	s.ErrorIs(err, errSharedParamsConflictingACL)
 }
 
+func (s *CDNIngressSuite) Test_sharedIngressParams_DryRunIfAnyIngressIsInDryRun() {
+	params := []CDNIngress{
+		{Group: "foo"},
+		{Group: "foo", DryRun: true},
+	}
+
+	shared, err := NewSharedIngressParams(params)
+
+	s.NoError(err)
+	s.True(shared.DryRun)
+}
+
+func (s *CDNIngressSuite) Test_sharedIngressParams_AdoptDistributionID() {
+	params := []CDNIngress{
+		{Group: "foo"},
+		{Group: "foo", UnmergedAdoptDistributionID: "E2QWRUHAPOMQZL"},
+		{Group: "foo", UnmergedAdoptDistributionID: "E2QWRUHAPOMQZL"},
+	}
+
+	shared, err := NewSharedIngressParams(params)
+
+	s.NoError(err)
+	s.Equal("E2QWRUHAPOMQZL", shared.AdoptDistributionID)
+}
+
+func (s *CDNIngressSuite) Test_sharedIngressParams_ConflictingAdoptDistributionIDs() {
+	params := []CDNIngress{
+		{Group: "foo", UnmergedAdoptDistributionID: "E2QWRUHAPOMQZL"},
+		{Group: "foo", UnmergedAdoptDistributionID: "EDFDVBD6EXAMPLE"},
+	}
+
+	shared, err := NewSharedIngressParams(params)
+
+	s.Equal(SharedIngressParams{}, shared)
+	s.ErrorIs(err, errSharedParamsConflictingAdoption)
+}
+
+func (s *CDNIngressSuite) Test_sharedIngressParams_DefaultRootObject() {
+	params := []CDNIngress{
+		{Group: "foo"},
+		{Group: "foo", UnmergedDefaultRootObject: aws.String("index.html")},
+		{Group: "foo", UnmergedDefaultRootObject: aws.String("index.html")},
+	}
+
+	shared, err := NewSharedIngressParams(params)
+
+	s.NoError(err)
+	s.Equal(aws.String("index.html"), shared.DefaultRootObject)
+
+	shared, err = NewSharedIngressParams([]CDNIngress{{Group: "foo"}})
+
+	s.NoError(err)
+	s.Nil(shared.DefaultRootObject)
+}
+
+func (s *CDNIngressSuite) Test_sharedIngressParams_ConflictingDefaultRootObjects() {
+	params := []CDNIngress{
+		{Group: "foo", UnmergedDefaultRootObject: aws.String("index.html")},
+		{Group: "foo", UnmergedDefaultRootObject: aws.String("")},
+	}
+
+	shared, err := NewSharedIngressParams(params)
+
+	s.Equal(SharedIngressParams{}, shared)
+	s.ErrorIs(err, errSharedParamsConflictingRootObj)
+}
+
+func (s *CDNIngressSuite) Test_sharedIngressParams_IsDefaultOrigin() {
+	spa := CDNIngress{Group: "foo", OriginHost: "spa", OriginAccess: "Bucket", DefaultBehavior: true}
+	sameOrigin := CDNIngress{Group: "foo", OriginHost: "spa", OriginAccess: "Bucket"}
+	api := CDNIngress{Group: "foo", OriginHost: "api", OriginAccess: "Public"}
+
+	shared, err := NewSharedIngressParams([]CDNIngress{spa, sameOrigin, api})
+
+	s.NoError(err)
+	s.True(shared.IsDefaultOrigin(spa))
+	s.True(shared.IsDefaultOrigin(sameOrigin), "CDNIngresses of the same origin should share the default behavior")
+	s.False(shared.IsDefaultOrigin(api))
+
+	shared, err = NewSharedIngressParams([]CDNIngress{sameOrigin, api})
+
+	s.NoError(err)
+	s.False(shared.IsDefaultOrigin(sameOrigin))
+}
+
+func (s *CDNIngressSuite) Test_sharedIngressParams_ConflictingDefaultBehaviors() {
+	params := []CDNIngress{
+		{Group: "foo", OriginHost: "spa", DefaultBehavior: true},
+		{Group: "foo", OriginHost: "spa", OriginPath: "/v2", DefaultBehavior: true},
+	}
+
+	shared, err := NewSharedIngressParams(params)
+
+	s.Equal(SharedIngressParams{}, shared)
+	s.ErrorIs(err, errSharedParamsConflictingDefault)
+}
+
+func (s *CDNIngressSuite) Test_sharedIngressParams_InvalidOptionalFields() {
+	params := []CDNIngress{
+		{Group: "foo", OriginHost: "a", UnmergedCustomErrorResponses: []CustomErrorResponse{{ErrorCode: 404}}},
+		{Group: "foo", OriginHost: "b", InvalidOptionalFields: []v1alpha1.OptionalField{v1alpha1.OptionalFieldCustomErrorResponses}},
+		{Group: "foo", OriginHost: "c", InvalidOptionalFields: []v1alpha1.OptionalField{v1alpha1.OptionalFieldCustomErrorResponses}},
+	}
+
+	shared, err := NewSharedIngressParams(params)
+
+	s.NoError(err)
+	s.Equal([]v1alpha1.OptionalField{v1alpha1.OptionalFieldCustomErrorResponses}, shared.InvalidOptionalFields)
+}
+
+func (s *CDNIngressSuite) TestNewCDNIngressFromV1_WithDefaultBehaviorAnnotation() {
+	ing := &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "foo",
+			Namespace:   "bar",
+			Annotations: map[string]string{cfDefaultBehaviorAnnotation: "true"},
+		},
+	}
+
+	got, err := NewCDNIngressFromV1(context.Background(), ing, CDNClass{})
+	s.NoError(err)
+	s.True(got.DefaultBehavior)
+
+	ing.Annotations[cfDefaultBehaviorAnnotation] = "false"
+	got, err = NewCDNIngressFromV1(context.Background(), ing, CDNClass{})
+	s.NoError(err)
+	s.False(got.DefaultBehavior)
+}
+
+func (s *CDNIngressSuite) Test_IsGroupReleased() {
+	s.False(IsGroupReleased([]CDNIngress{{Group: "foo"}, {Group: "foo"}}))
+	s.True(IsGroupReleased([]CDNIngress{{Group: "foo"}, {Group: "foo", Release: true}}))
+}
+
 func (s *CDNIngressSuite) TestSharedIngressParams_PathsFromOrigin() {
 	shared := SharedIngressParams{
-		paths: map[string][]Path{
-			"foo.bar": {
+		paths: map[originKey][]Path{
+			{host: "foo.bar"}: {
 				{
 					PathPattern: "/",
 					PathType:    "Prefix",
@@ -574,7 +1070,7 @@ func (s *CDNIngressSuite) TestSharedIngressParams_PathsFromOrigin() {
 					},
 				},
 			},
-			"foo.bar2": {
+			{host: "foo.bar2"}: {
 				{
 					PathPattern: "/foo",
 					PathType:    "Prefix",
@@ -599,7 +1095,7 @@ func (s *CDNIngressSuite) TestSharedIngressParams_PathsFromOrigin() {
 		},
 	}
 
-	s.Empty(shared.PathsFromOrigin("I don't exist"))
+	s.Empty(shared.PathsFromOrigin(CDNIngress{OriginHost: "I don't exist"}))
 
 	s.ElementsMatch([]Path{
 		{
@@ -612,7 +1108,7 @@ func (s *CDNIngressSuite) TestSharedIngressParams_PathsFromOrigin() {
 				},
 			},
 		},
-	}, shared.PathsFromOrigin("foo.bar"))
+	}, shared.PathsFromOrigin(CDNIngress{OriginHost: "foo.bar"}))
 
 	s.ElementsMatch([]Path{
 		{
@@ -634,5 +1130,5 @@ func (s *CDNIngressSuite) TestSharedIngressParams_PathsFromOrigin() {
 					ARN: "some-other-arn3",
 				},
 			},
-		}}, shared.PathsFromOrigin("foo.bar2"))
+		}}, shared.PathsFromOrigin(CDNIngress{OriginHost: "foo.bar2"}))
 }
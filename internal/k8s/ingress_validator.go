@@ -0,0 +1,144 @@
+// Copyright (c) 2023 GPBR Participacoes LTDA.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+//+kubebuilder:webhook:path=/validate-networking-k8s-io-v1-ingress,mutating=false,failurePolicy=ignore,sideEffects=None,groups=networking.k8s.io,resources=ingresses,verbs=create;update,versions=v1,name=vingress.cdn.gympass.com,admissionReviewVersions=v1
+
+// IngressValidator validates CDN-related configuration of Ingresses when they are admitted by the API server
+type IngressValidator struct {
+	Fetcher IngressFetcher
+}
+
+var _ admission.CustomValidator = &IngressValidator{}
+
+// ValidateCreate validates an Ingress being created
+func (v *IngressValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	ing, ok := obj.(*networkingv1.Ingress)
+	if !ok {
+		return nil, fmt.Errorf("expected an Ingress, got %T", obj)
+	}
+	return v.validate(ctx, ing)
+}
+
+// ValidateUpdate validates an Ingress being updated.
+// Updates that don't touch annotations or the spec, such as the controller managing its finalizer or recording
+// rollout revisions, are always allowed.
+func (v *IngressValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	oldIng, ok := oldObj.(*networkingv1.Ingress)
+	if !ok {
+		return nil, fmt.Errorf("expected an Ingress, got %T", oldObj)
+	}
+	newIng, ok := newObj.(*networkingv1.Ingress)
+	if !ok {
+		return nil, fmt.Errorf("expected an Ingress, got %T", newObj)
+	}
+
+	sameAnnotations := reflect.DeepEqual(withoutRolloutRevisions(oldIng.Annotations), withoutRolloutRevisions(newIng.Annotations))
+	if sameAnnotations && reflect.DeepEqual(oldIng.Spec, newIng.Spec) {
+		return nil, nil
+	}
+	return v.validate(ctx, newIng)
+}
+
+// ValidateDelete always allows Ingresses to be deleted
+func (v *IngressValidator) ValidateDelete(context.Context, runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (v *IngressValidator) validate(ctx context.Context, ing *networkingv1.Ingress) (admission.Warnings, error) {
+	if !CDNClassNotEmpty(CDNClassAnnotationValue(ing)) || !HasGroupAnnotation(ing) || ing.DeletionTimestamp != nil {
+		return nil, nil
+	}
+
+	var warnings admission.Warnings
+	for _, df := range UsedDeprecatedFields(ing) {
+		warnings = append(warnings, fmt.Sprintf("annotation %q is deprecated", df))
+	}
+
+	if err := ValidateIngressFunctionAssociations(ing); err != nil {
+		return warnings, err
+	}
+
+	if err := ValidateIngressMethods(ing); err != nil {
+		return warnings, err
+	}
+
+	if err := ValidateIngressViewerProtocolPolicies(ing); err != nil {
+		return warnings, err
+	}
+
+	if err := ValidateIngressOriginProtocol(ing); err != nil {
+		return warnings, err
+	}
+
+	if err := ValidateIngressOriginPath(ing); err != nil {
+		return warnings, err
+	}
+
+	if err := ValidateIngressFailover(ing); err != nil {
+		return warnings, err
+	}
+
+	if err := ValidateIngressCustomErrorResponses(ing); err != nil {
+		return warnings, err
+	}
+
+	if err := ValidateIngressGeoRestriction(ing); err != nil {
+		return warnings, err
+	}
+
+	if err := ValidateIngressDefaultRootObject(ing); err != nil {
+		return warnings, err
+	}
+
+	cdnIng, err := NewCDNIngressFromV1(ctx, ing, CDNClass{})
+	if err != nil {
+		return warnings, err
+	}
+
+	userOrigins, err := cdnIngressesForUserOrigins(ing)
+	if err != nil {
+		return warnings, err
+	}
+
+	group, memberWarnings, err := v.Fetcher.FetchGroupMembers(ctx, cdnIng.Group, cdnIng.NamespacedName)
+	warnings = append(warnings, memberWarnings...)
+	if err != nil {
+		return warnings, fmt.Errorf("fetching Ingresses of group %q: %v", cdnIng.Group, err)
+	}
+	group = append(group, cdnIng)
+	group = append(group, userOrigins...)
+
+	if _, err := NewSharedIngressParams(group); err != nil {
+		return warnings, fmt.Errorf("validating against other members of group %q: %w", cdnIng.Group, err)
+	}
+
+	return warnings, nil
+}
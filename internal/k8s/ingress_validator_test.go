@@ -0,0 +1,258 @@
+// Copyright (c) 2023 GPBR Participacoes LTDA.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package k8s
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestRunIngressValidatorTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, &IngressValidatorTestSuite{})
+}
+
+type IngressValidatorTestSuite struct {
+	suite.Suite
+}
+
+func (s *IngressValidatorTestSuite) TestValidateCreate_IgnoresIngressesNotManagedByTheController() {
+	ing := newIngressV1WithLB("namespace", "name", map[string]string{
+		cfTagsAnnotation: "not: [valid",
+	})
+
+	v := &IngressValidator{Fetcher: &fakeFetcher{}}
+	_, err := v.ValidateCreate(context.Background(), ing)
+	s.NoError(err)
+}
+
+func (s *IngressValidatorTestSuite) TestValidateCreate_ValidIngress() {
+	ing := newManagedIngress(map[string]string{
+		cfTagsAnnotation:        "foo: bar",
+		cfOrigHeadersAnnotation: "foo=bar",
+	})
+
+	v := &IngressValidator{Fetcher: &fakeFetcher{}}
+	warnings, err := v.ValidateCreate(context.Background(), ing)
+	s.NoError(err)
+	s.Empty(warnings)
+}
+
+func (s *IngressValidatorTestSuite) TestValidateCreate_InvalidAnnotations() {
+	testCases := []struct {
+		name        string
+		annotations map[string]string
+	}{
+		{
+			name:        "Invalid tags",
+			annotations: map[string]string{cfTagsAnnotation: "not: [valid"},
+		},
+		{
+			name:        "Invalid origin headers",
+			annotations: map[string]string{cfOrigHeadersAnnotation: "foo"},
+		},
+		{
+			name: "Invalid function associations",
+			annotations: map[string]string{cfFunctionAssociationsAnnotation: `
+/foo:
+  viewerRequest:
+    arn: arn:fn
+    functionType: invalid`},
+		},
+		{
+			name:        "Function associations referencing unknown path",
+			annotations: map[string]string{cfFunctionAssociationsAnnotation: "/bar:\n  originResponse:\n    arn: arn:fn"},
+		},
+		{
+			name:        "Invalid methods",
+			annotations: map[string]string{cfMethodsAnnotation: "/foo:\n  allowedMethods: [GET, POST]"},
+		},
+		{
+			name:        "Invalid viewer protocol policy",
+			annotations: map[string]string{cfViewerProtocolPolicyAnnotation: "/foo: http-only"},
+		},
+		{
+			name:        "Viewer protocol policy referencing unknown path",
+			annotations: map[string]string{cfViewerProtocolPolicyAnnotation: "/bar: https-only"},
+		},
+		{
+			name:        "Invalid origin port",
+			annotations: map[string]string{cfOrigHTTPPortAnnotation: "22"},
+		},
+		{
+			name:        "Invalid origin path",
+			annotations: map[string]string{cfOrigPathAnnotation: "/prefix/"},
+		},
+		{
+			name:        "Invalid failover",
+			annotations: map[string]string{cfFailoverAnnotation: "host: foo.com\nstatusCodes: [501]"},
+		},
+		{
+			name:        "Invalid custom error responses",
+			annotations: map[string]string{cfCustomErrorResponsesAnnotation: "- errorCode: 200"},
+		},
+		{
+			name:        "Invalid geo restriction",
+			annotations: map[string]string{cfGeoRestrictionAnnotation: "type: allow\ncountries: [XX]"},
+		},
+		{
+			name:        "Invalid default root object",
+			annotations: map[string]string{cfDefaultRootObjectAnnotation: "/index.html"},
+		},
+		{
+			name:        "Invalid user origins",
+			annotations: map[string]string{cfUserOriginsAnnotation: "- host: foo.com"},
+		},
+	}
+
+	for _, tc := range testCases {
+		v := &IngressValidator{Fetcher: &fakeFetcher{}}
+		_, err := v.ValidateCreate(context.Background(), newManagedIngress(tc.annotations))
+		s.Errorf(err, "test case: %s", tc.name)
+	}
+}
+
+func (s *IngressValidatorTestSuite) TestValidateCreate_WarnsAboutDeprecatedAnnotations() {
+	ing := newManagedIngress(map[string]string{cfViewerFnAnnotation: "arn:fn"})
+
+	v := &IngressValidator{Fetcher: &fakeFetcher{}}
+	warnings, err := v.ValidateCreate(context.Background(), ing)
+	s.NoError(err)
+	s.Len(warnings, 1)
+}
+
+func (s *IngressValidatorTestSuite) TestValidateCreate_ConflictingWebACLWithinGroup() {
+	other := CDNIngress{
+		NamespacedName:    types.NamespacedName{Namespace: "namespace", Name: "other"},
+		Group:             "group",
+		OriginHost:        "host",
+		UnmergedWebACLARN: "arn:acl:other",
+	}
+	ing := newManagedIngress(map[string]string{cfWebACLARNAnnotation: "arn:acl:mine"})
+
+	v := &IngressValidator{Fetcher: &fakeFetcher{ings: []CDNIngress{other}}}
+	_, err := v.ValidateCreate(context.Background(), ing)
+	s.ErrorIs(err, errSharedParamsConflictingACL)
+}
+
+func (s *IngressValidatorTestSuite) TestValidateCreate_ConflictingFunctionAssociationsWithinGroup() {
+	other := CDNIngress{
+		NamespacedName: types.NamespacedName{Namespace: "namespace", Name: "other"},
+		Group:          "group",
+		OriginHost:     "host",
+		UnmergedPaths: []Path{
+			{
+				PathPattern:          "/foo",
+				PathType:             string(networkingv1.PathTypePrefix),
+				FunctionAssociations: FunctionAssociations{OriginResponse: &OriginFunction{ARN: "arn:other"}},
+			},
+		},
+	}
+	ing := newManagedIngress(map[string]string{
+		cfFunctionAssociationsAnnotation: "/foo:\n  originResponse:\n    arn: arn:mine",
+	})
+
+	v := &IngressValidator{Fetcher: &fakeFetcher{ings: []CDNIngress{other}}}
+	_, err := v.ValidateCreate(context.Background(), ing)
+	s.ErrorIs(err, errSharedParamsConflictingPaths)
+}
+
+func (s *IngressValidatorTestSuite) TestValidateCreate_WarnsAboutInvalidMembersOfTheGroup() {
+	fetcher := &fakeFetcher{warnings: []string{"ignoring invalid Ingress namespace/other of the same group"}}
+
+	v := &IngressValidator{Fetcher: fetcher}
+	warnings, err := v.ValidateCreate(context.Background(), newManagedIngress(nil))
+	s.NoError(err)
+	s.Equal(fetcher.warnings, []string(warnings))
+}
+
+func (s *IngressValidatorTestSuite) TestValidateCreate_FailsIfGroupCantBeFetched() {
+	v := &IngressValidator{Fetcher: &fakeFetcher{err: errors.New("mock err")}}
+	_, err := v.ValidateCreate(context.Background(), newManagedIngress(nil))
+	s.Error(err)
+}
+
+func (s *IngressValidatorTestSuite) TestValidateUpdate_AllowsUpdatesNotTouchingAnnotationsOrSpec() {
+	oldIng := newManagedIngress(map[string]string{cfTagsAnnotation: "not: [valid"})
+	newIng := oldIng.DeepCopy()
+	newIng.Finalizers = []string{CDNFinalizer}
+
+	v := &IngressValidator{Fetcher: &fakeFetcher{}}
+	_, err := v.ValidateUpdate(context.Background(), oldIng, newIng)
+	s.NoError(err)
+}
+
+func (s *IngressValidatorTestSuite) TestValidateUpdate_AllowsRecordingRolloutRevisions() {
+	oldIng := newManagedIngress(map[string]string{cfTagsAnnotation: "not: [valid"})
+	newIng := oldIng.DeepCopy()
+	SetRolloutRevision(newIng, "deploy", "2")
+
+	v := &IngressValidator{Fetcher: &fakeFetcher{}}
+	_, err := v.ValidateUpdate(context.Background(), oldIng, newIng)
+	s.NoError(err)
+}
+
+func (s *IngressValidatorTestSuite) TestValidateUpdate_ValidatesChangedAnnotations() {
+	oldIng := newManagedIngress(nil)
+	newIng := newManagedIngress(map[string]string{cfTagsAnnotation: "not: [valid"})
+
+	v := &IngressValidator{Fetcher: &fakeFetcher{}}
+	_, err := v.ValidateUpdate(context.Background(), oldIng, newIng)
+	s.Error(err)
+}
+
+func (s *IngressValidatorTestSuite) TestValidateUpdate_AllowsIngressesBeingDeleted() {
+	oldIng := newManagedIngress(nil)
+	newIng := newManagedIngress(map[string]string{cfTagsAnnotation: "not: [valid"})
+	newIng.DeletionTimestamp = &metav1.Time{}
+
+	v := &IngressValidator{Fetcher: &fakeFetcher{}}
+	_, err := v.ValidateUpdate(context.Background(), oldIng, newIng)
+	s.NoError(err)
+}
+
+func newManagedIngress(annotations map[string]string) *networkingv1.Ingress {
+	allAnnotations := map[string]string{
+		CDNClassAnnotation: "class",
+		CDNGroupAnnotation: "group",
+	}
+	for k, v := range annotations {
+		allAnnotations[k] = v
+	}
+
+	ing := newIngressV1WithLB("namespace", "name", allAnnotations)
+	pathType := networkingv1.PathTypePrefix
+	ing.Spec.Rules = []networkingv1.IngressRule{
+		{
+			IngressRuleValue: networkingv1.IngressRuleValue{
+				HTTP: &networkingv1.HTTPIngressRuleValue{
+					Paths: []networkingv1.HTTPIngressPath{{Path: "/foo", PathType: &pathType}},
+				},
+			},
+		},
+	}
+	return ing
+}
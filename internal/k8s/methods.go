@@ -0,0 +1,155 @@
+// Copyright (c) 2023 GPBR Participacoes LTDA.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package k8s
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/Gympass/cdn-origin-controller/internal/strhelper"
+)
+
+const cfMethodsAnnotation = "cdn-origin-controller.gympass.com/cf.methods"
+
+var (
+	// allowedMethodsCombinations are the sets of HTTP methods CloudFront accepts as allowed methods of a behavior
+	allowedMethodsCombinations = [][]string{
+		{"GET", "HEAD"},
+		{"GET", "HEAD", "OPTIONS"},
+		{"GET", "HEAD", "OPTIONS", "PUT", "POST", "PATCH", "DELETE"},
+	}
+	// cachedMethodsCombinations are the sets of HTTP methods CloudFront accepts as cached methods of a behavior
+	cachedMethodsCombinations = [][]string{
+		{"GET", "HEAD"},
+		{"GET", "HEAD", "OPTIONS"},
+	}
+)
+
+// Methods represents the HTTP methods a behavior allows and caches.
+// Empty methods mean the controller's default: all methods allowed, GET and HEAD cached.
+type Methods struct {
+	Allowed []string `yaml:"allowedMethods"`
+	Cached  []string `yaml:"cachedMethods"`
+}
+
+// IsEmpty returns whether no methods were specified
+func (m Methods) IsEmpty() bool {
+	return len(m.Allowed) == 0 && len(m.Cached) == 0
+}
+
+// Validate returns an error if the methods are not a combination CloudFront accepts
+func (m Methods) Validate() error {
+	_, err := m.normalized()
+	return err
+}
+
+// normalized returns the methods as one of the combinations accepted by CloudFront, with methods in uppercase and
+// in a stable order. Returns an error if the methods are not a valid combination.
+func (m Methods) normalized() (Methods, error) {
+	allowed, err := matchingCombination(m.Allowed, allowedMethodsCombinations)
+	if err != nil {
+		return Methods{}, fmt.Errorf("invalid allowed methods: %v", err)
+	}
+
+	cached, err := matchingCombination(m.Cached, cachedMethodsCombinations)
+	if err != nil {
+		return Methods{}, fmt.Errorf("invalid cached methods: %v", err)
+	}
+
+	if len(allowed) > 0 && !sets.NewString(allowed...).HasAll(cached...) {
+		return Methods{}, fmt.Errorf("cached methods %v must also be allowed methods %v", cached, allowed)
+	}
+
+	return Methods{Allowed: allowed, Cached: cached}, nil
+}
+
+// merge returns the methods configured by either m or other. Returns an error if both configure different methods.
+func (m Methods) merge(other Methods) (Methods, error) {
+	if m.IsEmpty() {
+		return other, nil
+	}
+	if other.IsEmpty() {
+		return m, nil
+	}
+
+	if !sets.NewString(m.Allowed...).Equal(sets.NewString(other.Allowed...)) ||
+		!sets.NewString(m.Cached...).Equal(sets.NewString(other.Cached...)) {
+		return Methods{}, fmt.Errorf("methods informed twice with different values: %+v and %+v", m, other)
+	}
+	return m, nil
+}
+
+func matchingCombination(methods []string, combinations [][]string) ([]string, error) {
+	if len(methods) == 0 {
+		return nil, nil
+	}
+
+	var upper []string
+	for _, method := range methods {
+		upper = append(upper, strings.ToUpper(strings.TrimSpace(method)))
+	}
+
+	for _, c := range combinations {
+		if sets.NewString(upper...).Equal(sets.NewString(c...)) {
+			return c, nil
+		}
+	}
+	return nil, fmt.Errorf("%v is not one of the supported combinations %v", methods, combinations)
+}
+
+func methodsByPath(obj client.Object) (map[string]Methods, error) {
+	methodsYAML, ok := obj.GetAnnotations()[cfMethodsAnnotation]
+	if !ok {
+		return nil, nil
+	}
+
+	methods := make(map[string]Methods)
+	if err := yaml.Unmarshal([]byte(methodsYAML), &methods); err != nil {
+		return nil, fmt.Errorf("unmarshalling YAML: %v", err)
+	}
+	return methods, nil
+}
+
+// ValidateIngressMethods returns an error if the Ingress configures methods which are invalid or for paths the
+// Ingress doesn't have
+func ValidateIngressMethods(ing *networkingv1.Ingress) error {
+	allMethods, err := methodsByPath(ing)
+	if err != nil {
+		return fmt.Errorf("parsing methods: %v", err)
+	}
+
+	ingPaths := ingressPaths(ing)
+	for path, m := range allMethods {
+		if err := m.Validate(); err != nil {
+			return fmt.Errorf("invalid methods at path %q: %v", path, err)
+		}
+
+		if !strhelper.Contains(ingPaths, path) {
+			return fmt.Errorf("methods reference a path %q that is not part of the Ingress' paths %v", path, ingPaths)
+		}
+	}
+
+	return nil
+}
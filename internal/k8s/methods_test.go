@@ -0,0 +1,186 @@
+// Copyright (c) 2023 GPBR Participacoes LTDA.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package k8s
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestRunMethodsTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, &methodsTestSuite{})
+}
+
+type methodsTestSuite struct {
+	suite.Suite
+}
+
+func (s *methodsTestSuite) Test_normalized_Valid() {
+	testCases := []struct {
+		name string
+		in   Methods
+		want Methods
+	}{
+		{
+			name: "Empty methods",
+			in:   Methods{},
+			want: Methods{},
+		},
+		{
+			name: "Read-only methods in lowercase and any order",
+			in:   Methods{Allowed: []string{"head", "get"}, Cached: []string{"HEAD", "get"}},
+			want: Methods{Allowed: []string{"GET", "HEAD"}, Cached: []string{"GET", "HEAD"}},
+		},
+		{
+			name: "All methods allowed, OPTIONS cached",
+			in: Methods{
+				Allowed: []string{"DELETE", "GET", "HEAD", "OPTIONS", "PATCH", "POST", "PUT"},
+				Cached:  []string{"GET", "HEAD", "OPTIONS"},
+			},
+			want: Methods{
+				Allowed: []string{"GET", "HEAD", "OPTIONS", "PUT", "POST", "PATCH", "DELETE"},
+				Cached:  []string{"GET", "HEAD", "OPTIONS"},
+			},
+		},
+		{
+			name: "Only cached methods",
+			in:   Methods{Cached: []string{"GET", "HEAD", "OPTIONS"}},
+			want: Methods{Cached: []string{"GET", "HEAD", "OPTIONS"}},
+		},
+	}
+
+	for _, tc := range testCases {
+		got, err := tc.in.normalized()
+		s.NoError(err, "test: %s", tc.name)
+		s.Equal(tc.want, got, "test: %s", tc.name)
+	}
+}
+
+func (s *methodsTestSuite) Test_normalized_Invalid() {
+	testCases := []struct {
+		name string
+		in   Methods
+	}{
+		{
+			name: "Unsupported allowed combination",
+			in:   Methods{Allowed: []string{"GET", "POST"}},
+		},
+		{
+			name: "Unsupported cached combination",
+			in:   Methods{Cached: []string{"GET"}},
+		},
+		{
+			name: "Unknown method",
+			in:   Methods{Allowed: []string{"GET", "HEAD", "FOO"}},
+		},
+		{
+			name: "Cached method which is not allowed",
+			in:   Methods{Allowed: []string{"GET", "HEAD"}, Cached: []string{"GET", "HEAD", "OPTIONS"}},
+		},
+	}
+
+	for _, tc := range testCases {
+		_, err := tc.in.normalized()
+		s.Error(err, "test: %s", tc.name)
+		s.Error(tc.in.Validate(), "test: %s", tc.name)
+	}
+}
+
+func (s *methodsTestSuite) Test_merge() {
+	readOnly := Methods{Allowed: []string{"GET", "HEAD"}, Cached: []string{"GET", "HEAD"}}
+	withOptions := Methods{Allowed: []string{"GET", "HEAD", "OPTIONS"}}
+
+	got, err := Methods{}.merge(readOnly)
+	s.NoError(err)
+	s.Equal(readOnly, got)
+
+	got, err = readOnly.merge(Methods{})
+	s.NoError(err)
+	s.Equal(readOnly, got)
+
+	got, err = readOnly.merge(readOnly)
+	s.NoError(err)
+	s.Equal(readOnly, got)
+
+	_, err = readOnly.merge(withOptions)
+	s.Error(err)
+}
+
+func (s *methodsTestSuite) TestValidateIngressMethods() {
+	testCases := []struct {
+		name        string
+		annotation  string
+		expectError bool
+	}{
+		{
+			name:        "No annotation",
+			expectError: false,
+		},
+		{
+			name:        "Valid methods for existing path",
+			annotation:  "/foo:\n  allowedMethods: [GET, HEAD]\n  cachedMethods: [GET, HEAD]",
+			expectError: false,
+		},
+		{
+			name:        "Invalid methods",
+			annotation:  "/foo:\n  allowedMethods: [GET, POST]",
+			expectError: true,
+		},
+		{
+			name:        "Path not in the Ingress",
+			annotation:  "/bar:\n  allowedMethods: [GET, HEAD]",
+			expectError: true,
+		},
+		{
+			name:        "Invalid YAML",
+			annotation:  "*",
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		ing := &networkingv1.Ingress{
+			ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{}},
+			Spec: networkingv1.IngressSpec{
+				Rules: []networkingv1.IngressRule{
+					{
+						IngressRuleValue: networkingv1.IngressRuleValue{HTTP: &networkingv1.HTTPIngressRuleValue{
+							Paths: []networkingv1.HTTPIngressPath{{Path: "/foo"}},
+						}},
+					},
+				},
+			},
+		}
+		if len(tc.annotation) > 0 {
+			ing.Annotations[cfMethodsAnnotation] = tc.annotation
+		}
+
+		err := ValidateIngressMethods(ing)
+		if tc.expectError {
+			s.Error(err, "test: %s", tc.name)
+		} else {
+			s.NoError(err, "test: %s", tc.name)
+		}
+	}
+}
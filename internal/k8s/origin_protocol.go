@@ -0,0 +1,132 @@
+// Copyright (c) 2023 GPBR Participacoes LTDA.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package k8s
+
+import (
+	"fmt"
+	"strconv"
+
+	awscloudfront "github.com/aws/aws-sdk-go/service/cloudfront"
+	networkingv1 "k8s.io/api/networking/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/Gympass/cdn-origin-controller/internal/strhelper"
+)
+
+const (
+	cfOrigProtocolPolicyAnnotation   = "cdn-origin-controller.gympass.com/cf.origin-protocol-policy"
+	cfOrigHTTPPortAnnotation         = "cdn-origin-controller.gympass.com/cf.origin-http-port"
+	cfOrigHTTPSPortAnnotation        = "cdn-origin-controller.gympass.com/cf.origin-https-port"
+	cfOrigKeepaliveTimeoutAnnotation = "cdn-origin-controller.gympass.com/cf.origin-keepalive-timeout"
+	cfOrigMinSSLProtocolAnnotation   = "cdn-origin-controller.gympass.com/cf.origin-min-ssl-protocol"
+)
+
+const (
+	maxOriginKeepaliveTimeout = 60
+	minOriginCustomPort       = 1024
+	maxOriginPort             = 65535
+)
+
+// OriginProtocol represents how CloudFront connects to a public origin.
+// Zero values mean the controller's defaults should be used.
+type OriginProtocol struct {
+	// Policy is the protocol CloudFront uses to connect to the origin: http-only, https-only or match-viewer
+	Policy string `yaml:"originProtocolPolicy"`
+	// HTTPPort is the port CloudFront uses for HTTP connections to the origin
+	HTTPPort int64 `yaml:"httpPort"`
+	// HTTPSPort is the port CloudFront uses for HTTPS connections to the origin
+	HTTPSPort int64 `yaml:"httpsPort"`
+	// KeepaliveTimeout is how long, in seconds, CloudFront keeps idle connections to the origin open
+	KeepaliveTimeout int64 `yaml:"keepaliveTimeout"`
+	// MinSSLProtocol is the oldest SSL/TLS protocol CloudFront may use for HTTPS connections to the origin
+	MinSSLProtocol string `yaml:"minSSLProtocol"`
+}
+
+// Validate returns an error if any of the settings is not supported by CloudFront
+func (p OriginProtocol) Validate() error {
+	if len(p.Policy) > 0 && !strhelper.Contains(awscloudfront.OriginProtocolPolicy_Values(), p.Policy) {
+		return fmt.Errorf("invalid origin protocol policy %q. Valid values: %v",
+			p.Policy, awscloudfront.OriginProtocolPolicy_Values())
+	}
+
+	if p.HTTPPort != 0 && p.HTTPPort != 80 && !isCustomOriginPort(p.HTTPPort) {
+		return fmt.Errorf("invalid origin HTTP port %d: must be 80 or between %d and %d",
+			p.HTTPPort, minOriginCustomPort, maxOriginPort)
+	}
+
+	if p.HTTPSPort != 0 && p.HTTPSPort != 443 && !isCustomOriginPort(p.HTTPSPort) {
+		return fmt.Errorf("invalid origin HTTPS port %d: must be 443 or between %d and %d",
+			p.HTTPSPort, minOriginCustomPort, maxOriginPort)
+	}
+
+	if p.KeepaliveTimeout < 0 || p.KeepaliveTimeout > maxOriginKeepaliveTimeout {
+		return fmt.Errorf("invalid origin keepalive timeout %d: must be between 1 and %d",
+			p.KeepaliveTimeout, maxOriginKeepaliveTimeout)
+	}
+
+	if len(p.MinSSLProtocol) > 0 && !strhelper.Contains(awscloudfront.SslProtocol_Values(), p.MinSSLProtocol) {
+		return fmt.Errorf("invalid minimum origin SSL protocol %q. Valid values: %v",
+			p.MinSSLProtocol, awscloudfront.SslProtocol_Values())
+	}
+
+	return nil
+}
+
+func isCustomOriginPort(port int64) bool {
+	return port >= minOriginCustomPort && port <= maxOriginPort
+}
+
+func originProtocol(obj client.Object) (OriginProtocol, error) {
+	annotations := obj.GetAnnotations()
+	p := OriginProtocol{
+		Policy:         annotations[cfOrigProtocolPolicyAnnotation],
+		MinSSLProtocol: annotations[cfOrigMinSSLProtocolAnnotation],
+	}
+
+	intAnnotations := map[string]*int64{
+		cfOrigHTTPPortAnnotation:         &p.HTTPPort,
+		cfOrigHTTPSPortAnnotation:        &p.HTTPSPort,
+		cfOrigKeepaliveTimeoutAnnotation: &p.KeepaliveTimeout,
+	}
+	for key, field := range intAnnotations {
+		val, ok := annotations[key]
+		if !ok {
+			continue
+		}
+
+		parsed, err := strconv.ParseInt(val, 10, 64)
+		if err != nil {
+			return OriginProtocol{}, fmt.Errorf("parsing annotation %q: %v", key, err)
+		}
+		*field = parsed
+	}
+
+	if err := p.Validate(); err != nil {
+		return OriginProtocol{}, err
+	}
+	return p, nil
+}
+
+// ValidateIngressOriginProtocol returns an error if the Ingress configures how CloudFront connects to its origin with
+// values CloudFront doesn't support
+func ValidateIngressOriginProtocol(ing *networkingv1.Ingress) error {
+	_, err := originProtocol(ing)
+	return err
+}
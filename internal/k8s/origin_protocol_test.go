@@ -0,0 +1,122 @@
+// Copyright (c) 2023 GPBR Participacoes LTDA.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package k8s
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestRunOriginProtocolTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, &originProtocolTestSuite{})
+}
+
+type originProtocolTestSuite struct {
+	suite.Suite
+}
+
+func (s *originProtocolTestSuite) TestValidate_Valid() {
+	testCases := []struct {
+		name string
+		in   OriginProtocol
+	}{
+		{name: "Empty", in: OriginProtocol{}},
+		{name: "Default ports", in: OriginProtocol{HTTPPort: 80, HTTPSPort: 443}},
+		{name: "Custom ports", in: OriginProtocol{HTTPPort: 1024, HTTPSPort: 65535}},
+		{
+			name: "All settings",
+			in:   OriginProtocol{Policy: "https-only", KeepaliveTimeout: 60, MinSSLProtocol: "TLSv1.2"},
+		},
+	}
+
+	for _, tc := range testCases {
+		s.NoError(tc.in.Validate(), "test: %s", tc.name)
+	}
+}
+
+func (s *originProtocolTestSuite) TestValidate_Invalid() {
+	testCases := []struct {
+		name string
+		in   OriginProtocol
+	}{
+		{name: "Unknown policy", in: OriginProtocol{Policy: "redirect-to-https"}},
+		{name: "Reserved HTTP port", in: OriginProtocol{HTTPPort: 443}},
+		{name: "Reserved HTTPS port", in: OriginProtocol{HTTPSPort: 80}},
+		{name: "Port out of range", in: OriginProtocol{HTTPPort: 65536}},
+		{name: "Negative keepalive timeout", in: OriginProtocol{KeepaliveTimeout: -1}},
+		{name: "Keepalive timeout too long", in: OriginProtocol{KeepaliveTimeout: 61}},
+		{name: "Unknown SSL protocol", in: OriginProtocol{MinSSLProtocol: "TLSv1.3"}},
+	}
+
+	for _, tc := range testCases {
+		s.Error(tc.in.Validate(), "test: %s", tc.name)
+	}
+}
+
+func (s *originProtocolTestSuite) Test_originProtocol_FromAnnotations() {
+	ing := &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{
+				cfOrigProtocolPolicyAnnotation:   "http-only",
+				cfOrigHTTPPortAnnotation:         "8080",
+				cfOrigHTTPSPortAnnotation:        "8443",
+				cfOrigKeepaliveTimeoutAnnotation: "10",
+				cfOrigMinSSLProtocolAnnotation:   "TLSv1.2",
+			},
+		},
+	}
+
+	got, err := originProtocol(ing)
+	s.NoError(err)
+	s.Equal(OriginProtocol{
+		Policy:           "http-only",
+		HTTPPort:         8080,
+		HTTPSPort:        8443,
+		KeepaliveTimeout: 10,
+		MinSSLProtocol:   "TLSv1.2",
+	}, got)
+}
+
+func (s *originProtocolTestSuite) Test_originProtocol_NoAnnotations() {
+	got, err := originProtocol(&networkingv1.Ingress{})
+	s.NoError(err)
+	s.Equal(OriginProtocol{}, got)
+}
+
+func (s *originProtocolTestSuite) Test_originProtocol_InvalidAnnotations() {
+	testCases := []struct {
+		name        string
+		annotations map[string]string
+	}{
+		{name: "Non-numeric port", annotations: map[string]string{cfOrigHTTPPortAnnotation: "http"}},
+		{name: "Invalid port", annotations: map[string]string{cfOrigHTTPSPortAnnotation: "80"}},
+		{name: "Invalid policy", annotations: map[string]string{cfOrigProtocolPolicyAnnotation: "tcp"}},
+	}
+
+	for _, tc := range testCases {
+		ing := &networkingv1.Ingress{ObjectMeta: metav1.ObjectMeta{Annotations: tc.annotations}}
+		_, err := originProtocol(ing)
+		s.Error(err, "test: %s", tc.name)
+	}
+}
@@ -0,0 +1,151 @@
+// Copyright (c) 2023 GPBR Participacoes LTDA.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package k8s
+
+import (
+	"encoding/json"
+	"reflect"
+	"strconv"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// deploymentRevisionAnnotation is the annotation the Deployment controller uses to store the revision of a Deployment
+const deploymentRevisionAnnotation = "deployment.kubernetes.io/revision"
+
+// rolloutRevisionsAnnotation is the annotation the controller uses to store, on an Ingress, the last revision
+// of each Deployment backing it that was seen rolling out
+const rolloutRevisionsAnnotation = "cdn-origin-controller.gympass.com/rollout-revisions"
+
+// InvalidatesOnRollout returns whether the given Ingress opted in to having its paths invalidated when a Deployment backing it rolls out
+func InvalidatesOnRollout(obj client.Object) bool {
+	val, _ := strconv.ParseBool(obj.GetAnnotations()[cfInvalidateOnRolloutAnnotation])
+	return val
+}
+
+// DeploymentRevision returns the revision of the given Deployment, or an empty string if it was not set yet
+func DeploymentRevision(d *appsv1.Deployment) string {
+	return d.GetAnnotations()[deploymentRevisionAnnotation]
+}
+
+// RolloutRevisions returns the last revision seen rolling out for each Deployment backing the given Ingress,
+// by Deployment name. Deployments which were never seen are not present.
+func RolloutRevisions(ing client.Object) map[string]string {
+	revisions := map[string]string{}
+	val, ok := ing.GetAnnotations()[rolloutRevisionsAnnotation]
+	if !ok {
+		return revisions
+	}
+	if err := json.Unmarshal([]byte(val), &revisions); err != nil {
+		return map[string]string{}
+	}
+	return revisions
+}
+
+// SetRolloutRevision records the given revision as the last one seen rolling out for a Deployment backing the given Ingress
+func SetRolloutRevision(ing client.Object, deployName, revision string) {
+	revisions := RolloutRevisions(ing)
+	revisions[deployName] = revision
+	raw, _ := json.Marshal(revisions)
+
+	annotations := ing.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[rolloutRevisionsAnnotation] = string(raw)
+	ing.SetAnnotations(annotations)
+}
+
+// IsRolloutRevisionsUpdate returns whether the only change between two versions of an Ingress is to the
+// revisions recorded by the controller with SetRolloutRevision
+func IsRolloutRevisionsUpdate(oldIng, newIng *networkingv1.Ingress) bool {
+	if oldIng.Annotations[rolloutRevisionsAnnotation] == newIng.Annotations[rolloutRevisionsAnnotation] {
+		return false
+	}
+
+	oldCopy, newCopy := oldIng.DeepCopy(), newIng.DeepCopy()
+	for _, ing := range []*networkingv1.Ingress{oldCopy, newCopy} {
+		ing.Annotations = withoutRolloutRevisions(ing.Annotations)
+		ing.ResourceVersion = ""
+		ing.ManagedFields = nil
+	}
+	return reflect.DeepEqual(oldCopy, newCopy)
+}
+
+func withoutRolloutRevisions(annotations map[string]string) map[string]string {
+	result := make(map[string]string, len(annotations))
+	for k, v := range annotations {
+		if k != rolloutRevisionsAnnotation {
+			result[k] = v
+		}
+	}
+	return result
+}
+
+// IsRolloutComplete returns whether the latest revision of the given Deployment is available and no pods of
+// previous revisions are left, following the same criteria as "kubectl rollout status"
+func IsRolloutComplete(d *appsv1.Deployment) bool {
+	if len(DeploymentRevision(d)) == 0 || d.Status.ObservedGeneration < d.Generation {
+		return false
+	}
+
+	var replicas int32 = 1
+	if d.Spec.Replicas != nil {
+		replicas = *d.Spec.Replicas
+	}
+
+	return replicas > 0 &&
+		d.Status.UpdatedReplicas >= replicas &&
+		d.Status.Replicas == d.Status.UpdatedReplicas &&
+		d.Status.AvailableReplicas >= d.Status.UpdatedReplicas
+}
+
+// BackendServiceNames returns the names of all Services referenced by the backends of the given Ingress
+func BackendServiceNames(ing *networkingv1.Ingress) []string {
+	names := sets.NewString()
+	if b := ing.Spec.DefaultBackend; b != nil && b.Service != nil {
+		names.Insert(b.Service.Name)
+	}
+
+	for _, rule := range ing.Spec.Rules {
+		if rule.HTTP == nil {
+			continue
+		}
+		for _, p := range rule.HTTP.Paths {
+			if p.Backend.Service != nil {
+				names.Insert(p.Backend.Service.Name)
+			}
+		}
+	}
+	return names.List()
+}
+
+// SelectsDeployment returns whether the given Service routes traffic to pods of the given Deployment
+func SelectsDeployment(svc *corev1.Service, d *appsv1.Deployment) bool {
+	if svc.Namespace != d.Namespace || len(svc.Spec.Selector) == 0 {
+		return false
+	}
+	return labels.SelectorFromSet(svc.Spec.Selector).Matches(labels.Set(d.Spec.Template.Labels))
+}
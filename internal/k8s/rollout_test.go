@@ -0,0 +1,164 @@
+// Copyright (c) 2023 GPBR Participacoes LTDA.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package k8s
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestRunRolloutTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, &RolloutTestSuite{})
+}
+
+type RolloutTestSuite struct {
+	suite.Suite
+}
+
+func newRolledOutDeployment() *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "deploy",
+			Namespace:   "default",
+			Generation:  2,
+			Annotations: map[string]string{deploymentRevisionAnnotation: "2"},
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: int32Ptr(2),
+			Template: corev1.PodTemplateSpec{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "foo", "tier": "web"}}},
+		},
+		Status: appsv1.DeploymentStatus{
+			ObservedGeneration: 2,
+			Replicas:           2,
+			UpdatedReplicas:    2,
+			AvailableReplicas:  2,
+		},
+	}
+}
+
+func (s *RolloutTestSuite) TestInvalidatesOnRollout() {
+	ing := &networkingv1.Ingress{}
+	s.False(InvalidatesOnRollout(ing))
+
+	ing.Annotations = map[string]string{cfInvalidateOnRolloutAnnotation: "true"}
+	s.True(InvalidatesOnRollout(ing))
+
+	ing.Annotations = map[string]string{cfInvalidateOnRolloutAnnotation: "not-a-bool"}
+	s.False(InvalidatesOnRollout(ing))
+}
+
+func (s *RolloutTestSuite) TestRolloutRevisions() {
+	ing := &networkingv1.Ingress{}
+	s.Empty(RolloutRevisions(ing))
+
+	SetRolloutRevision(ing, "foo", "1")
+	SetRolloutRevision(ing, "bar", "3")
+	SetRolloutRevision(ing, "foo", "2")
+	s.Equal(map[string]string{"foo": "2", "bar": "3"}, RolloutRevisions(ing))
+
+	ing.Annotations[rolloutRevisionsAnnotation] = "not json"
+	s.Empty(RolloutRevisions(ing))
+}
+
+func (s *RolloutTestSuite) TestIsRolloutRevisionsUpdate() {
+	oldIng := &networkingv1.Ingress{ObjectMeta: metav1.ObjectMeta{
+		ResourceVersion: "1",
+		Annotations:     map[string]string{CDNGroupAnnotation: "group"},
+	}}
+
+	newIng := oldIng.DeepCopy()
+	newIng.ResourceVersion = "2"
+	SetRolloutRevision(newIng, "deploy", "1")
+	s.True(IsRolloutRevisionsUpdate(oldIng, newIng))
+
+	newIng.Annotations[CDNGroupAnnotation] = "other"
+	s.False(IsRolloutRevisionsUpdate(oldIng, newIng))
+
+	newIng = oldIng.DeepCopy()
+	newIng.Finalizers = []string{CDNFinalizer}
+	s.False(IsRolloutRevisionsUpdate(oldIng, newIng))
+}
+
+func (s *RolloutTestSuite) TestIsRolloutComplete() {
+	testCases := []struct {
+		name   string
+		mutate func(d *appsv1.Deployment)
+		want   bool
+	}{
+		{name: "All replicas are updated and available", mutate: func(d *appsv1.Deployment) {}, want: true},
+		{name: "No revision yet", mutate: func(d *appsv1.Deployment) { d.Annotations = nil }, want: false},
+		{name: "Latest generation not observed", mutate: func(d *appsv1.Deployment) { d.Status.ObservedGeneration = 1 }, want: false},
+		{name: "Scaled to zero", mutate: func(d *appsv1.Deployment) { d.Spec.Replicas = int32Ptr(0) }, want: false},
+		{name: "Replicas are still being updated", mutate: func(d *appsv1.Deployment) { d.Status.UpdatedReplicas = 1 }, want: false},
+		{name: "Old replicas are pending termination", mutate: func(d *appsv1.Deployment) { d.Status.Replicas = 3 }, want: false},
+		{name: "Updated replicas are not available", mutate: func(d *appsv1.Deployment) { d.Status.AvailableReplicas = 1 }, want: false},
+	}
+
+	for _, tc := range testCases {
+		d := newRolledOutDeployment()
+		tc.mutate(d)
+		s.Equal(tc.want, IsRolloutComplete(d), "test: %s", tc.name)
+	}
+}
+
+func (s *RolloutTestSuite) TestBackendServiceNames() {
+	ing := &networkingv1.Ingress{
+		Spec: networkingv1.IngressSpec{
+			DefaultBackend: &networkingv1.IngressBackend{Service: &networkingv1.IngressServiceBackend{Name: "default"}},
+			Rules: []networkingv1.IngressRule{
+				{},
+				{IngressRuleValue: networkingv1.IngressRuleValue{HTTP: &networkingv1.HTTPIngressRuleValue{
+					Paths: []networkingv1.HTTPIngressPath{
+						{Path: "/foo", Backend: networkingv1.IngressBackend{Service: &networkingv1.IngressServiceBackend{Name: "foo"}}},
+						{Path: "/bar", Backend: networkingv1.IngressBackend{Service: &networkingv1.IngressServiceBackend{Name: "foo"}}},
+						{Path: "/baz", Backend: networkingv1.IngressBackend{Resource: &corev1.TypedLocalObjectReference{Name: "bucket"}}},
+					},
+				}}},
+			},
+		},
+	}
+
+	s.Equal([]string{"default", "foo"}, BackendServiceNames(ing))
+}
+
+func (s *RolloutTestSuite) TestSelectsDeployment() {
+	d := newRolledOutDeployment()
+	svc := func(namespace string, selector map[string]string) *corev1.Service {
+		return &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace},
+			Spec:       corev1.ServiceSpec{Selector: selector},
+		}
+	}
+
+	s.True(SelectsDeployment(svc("default", map[string]string{"app": "foo"}), d))
+	s.False(SelectsDeployment(svc("default", map[string]string{"app": "bar"}), d))
+	s.False(SelectsDeployment(svc("other", map[string]string{"app": "foo"}), d))
+	s.False(SelectsDeployment(svc("default", nil), d))
+}
+
+func int32Ptr(i int32) *int32 {
+	return &i
+}
@@ -52,9 +52,12 @@ func cdnIngressesForUserOrigins(obj client.Object) ([]CDNIngress, error) {
 
 	var result []CDNIngress
 	for _, o := range origins {
+		// failovers were validated when parsing the user origins
+		fo, _ := normalizedFailover(o.Failover)
 		ing := CDNIngress{
 			NamespacedName:    types.NamespacedName{Namespace: obj.GetNamespace(), Name: obj.GetName()},
 			OriginHost:        o.Host,
+			OriginPath:        o.OriginPath,
 			OriginHeaders:     o.Headers,
 			Group:             groupAnnotationValue(obj),
 			UnmergedPaths:     o.paths(),
@@ -62,8 +65,15 @@ func cdnIngressesForUserOrigins(obj client.Object) ([]CDNIngress, error) {
 			CachePolicy:       o.CachePolicy,
 			ResponsePolicy:    o.ResponsePolicy,
 			OriginRespTimeout: o.ResponseTimeout,
+			OriginProtocol:    o.OriginProtocol,
+			Failover:          fo,
 			UnmergedWebACLARN: o.WebACLARN,
 			OriginAccess:      o.OriginAccess,
+			DryRun:            dryRun(obj),
+			Release:           release(obj),
+
+			UnmergedAdoptDistributionID: adoptDistributionID(obj),
+			DefaultBehavior:             o.DefaultBehavior,
 		}
 		result = append(result, ing)
 	}
@@ -73,6 +83,7 @@ func cdnIngressesForUserOrigins(obj client.Object) ([]CDNIngress, error) {
 
 type userOrigin struct {
 	Host              string                 `yaml:"host"`
+	OriginPath        string                 `yaml:"originPath"`
 	Headers           map[string]string      `yaml:"headers"`
 	ResponseTimeout   int64                  `yaml:"responseTimeout"`
 	Paths             []string               `yaml:"paths"` // deprecated in favor of Behaviors
@@ -83,11 +94,16 @@ type userOrigin struct {
 	ResponsePolicy    string                 `yaml:"responsePolicy"`
 	WebACLARN         string                 `yaml:"webACLARN"`
 	OriginAccess      string                 `yaml:"originAccess" default:"Public"`
+	OriginProtocol    `yaml:",inline"`
+	Failover          *Failover `yaml:"failover"`
+	DefaultBehavior   bool      `yaml:"defaultBehavior"`
 }
 
 type customOriginBehavior struct {
 	Path                 string               `yaml:"path"`
 	FunctionAssociations FunctionAssociations `yaml:"functionAssociations"`
+	Methods              `yaml:",inline"`
+	ViewerProtocolPolicy string `yaml:"viewerProtocolPolicy"`
 }
 
 func (o userOrigin) paths() []Path {
@@ -101,9 +117,13 @@ func (o userOrigin) paths() []Path {
 	}
 
 	for _, b := range o.Behaviors {
+		// methods were validated when parsing the user origins
+		methods, _ := b.Methods.normalized()
 		paths = append(paths, Path{
 			PathPattern:          b.Path,
 			FunctionAssociations: b.FunctionAssociations,
+			Methods:              methods,
+			ViewerProtocolPolicy: b.ViewerProtocolPolicy,
 		})
 	}
 
@@ -128,6 +148,18 @@ func (o userOrigin) validate() error {
 			CFUserOriginAccessPublic, CFUserOriginAccessBucket)
 	}
 
+	if err := o.OriginProtocol.Validate(); err != nil {
+		return err
+	}
+
+	if err := validateOriginPath(o.OriginPath); err != nil {
+		return err
+	}
+
+	if _, err := normalizedFailover(o.Failover); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -137,6 +169,14 @@ func (o userOrigin) validateBehaviors() error {
 			return fmt.Errorf("validating behavior function associations: %v", err)
 		}
 
+		if err := b.Methods.Validate(); err != nil {
+			return fmt.Errorf("validating behavior methods: %v", err)
+		}
+
+		if err := validateViewerProtocolPolicy(b.ViewerProtocolPolicy); err != nil {
+			return fmt.Errorf("validating behavior viewer protocol policy: %v", err)
+		}
+
 		if strhelper.Contains(o.Paths, b.Path) {
 			return fmt.Errorf("same path %q informed in paths (deprecated) and behaviors. Specify it in behaviors only", b.Path)
 		}
@@ -250,6 +250,152 @@ func (s *userOriginSuite) Test_cdnIngressesForUserOrigins_WithBehaviorsIsValid()
 	}, got[0].UnmergedPaths[0].FunctionAssociations)
 }
 
+func (s *userOriginSuite) Test_cdnIngressesForUserOrigins_WithBehaviorMethodsIsValid() {
+	userOriginsYAML := `
+- host: foo.com
+  behaviors:
+  - path: /foo
+    allowedMethods: [GET, HEAD]
+    cachedMethods: [head, get]
+  - path: /bar
+`
+	ing := &networkingv1.Ingress{}
+	ing.Annotations = map[string]string{
+		cfUserOriginsAnnotation: userOriginsYAML,
+		CDNGroupAnnotation:      "group",
+	}
+
+	got, err := cdnIngressesForUserOrigins(ing)
+	s.NoError(err)
+
+	s.Len(got, 1)
+	s.Len(got[0].UnmergedPaths, 2)
+	s.Equal(Methods{Allowed: []string{"GET", "HEAD"}, Cached: []string{"GET", "HEAD"}}, got[0].UnmergedPaths[0].Methods)
+	s.True(got[0].UnmergedPaths[1].Methods.IsEmpty())
+}
+
+func (s *userOriginSuite) Test_cdnIngressesForUserOrigins_WithBehaviorViewerProtocolPolicyIsValid() {
+	userOriginsYAML := `
+- host: foo.com
+  behaviors:
+  - path: /foo
+    viewerProtocolPolicy: allow-all
+  - path: /bar
+`
+	ing := &networkingv1.Ingress{}
+	ing.Annotations = map[string]string{
+		cfUserOriginsAnnotation: userOriginsYAML,
+		CDNGroupAnnotation:      "group",
+	}
+
+	got, err := cdnIngressesForUserOrigins(ing)
+	s.NoError(err)
+
+	s.Len(got, 1)
+	s.Len(got[0].UnmergedPaths, 2)
+	s.Equal("allow-all", got[0].UnmergedPaths[0].ViewerProtocolPolicy)
+	s.Empty(got[0].UnmergedPaths[1].ViewerProtocolPolicy)
+}
+
+func (s *userOriginSuite) Test_cdnIngressesForUserOrigins_WithOriginProtocolIsValid() {
+	userOriginsYAML := `
+- host: foo.com
+  originProtocolPolicy: https-only
+  httpsPort: 8443
+  keepaliveTimeout: 20
+  minSSLProtocol: TLSv1.2
+  behaviors:
+  - path: /foo
+`
+	ing := &networkingv1.Ingress{}
+	ing.Annotations = map[string]string{
+		cfUserOriginsAnnotation: userOriginsYAML,
+		CDNGroupAnnotation:      "group",
+	}
+
+	got, err := cdnIngressesForUserOrigins(ing)
+	s.NoError(err)
+
+	s.Len(got, 1)
+	s.Equal(OriginProtocol{
+		Policy:           "https-only",
+		HTTPSPort:        8443,
+		KeepaliveTimeout: 20,
+		MinSSLProtocol:   "TLSv1.2",
+	}, got[0].OriginProtocol)
+}
+
+func (s *userOriginSuite) Test_cdnIngressesForUserOrigins_WithOriginPathIsValid() {
+	userOriginsYAML := `
+- host: foo.com
+  originPath: /assets
+  behaviors:
+  - path: /assets/*
+- host: foo.com
+  originPath: /docs
+  behaviors:
+  - path: /docs/*
+`
+	ing := &networkingv1.Ingress{}
+	ing.Annotations = map[string]string{
+		cfUserOriginsAnnotation: userOriginsYAML,
+		CDNGroupAnnotation:      "group",
+	}
+
+	got, err := cdnIngressesForUserOrigins(ing)
+	s.NoError(err)
+
+	s.Len(got, 2)
+	s.Equal("/assets", got[0].OriginPath)
+	s.Equal("/docs", got[1].OriginPath)
+}
+
+func (s *userOriginSuite) Test_cdnIngressesForUserOrigins_WithFailoverIsValid() {
+	userOriginsYAML := `
+- host: foo.com
+  behaviors:
+  - path: /*
+  failover:
+    host: bar.com
+`
+	ing := &networkingv1.Ingress{}
+	ing.Annotations = map[string]string{
+		cfUserOriginsAnnotation: userOriginsYAML,
+		CDNGroupAnnotation:      "group",
+	}
+
+	got, err := cdnIngressesForUserOrigins(ing)
+	s.NoError(err)
+
+	s.Len(got, 1)
+	s.Equal(&Failover{Host: "bar.com", OriginAccess: "Public", StatusCodes: []int64{500, 502, 503, 504}}, got[0].Failover)
+}
+
+func (s *userOriginSuite) Test_cdnIngressesForUserOrigins_WithDefaultBehaviorIsValid() {
+	userOriginsYAML := `
+- host: spa.s3.amazonaws.com
+  originAccess: Bucket
+  defaultBehavior: true
+  behaviors:
+  - path: /*
+- host: api.com
+  behaviors:
+  - path: /api/*
+`
+	ing := &networkingv1.Ingress{}
+	ing.Annotations = map[string]string{
+		cfUserOriginsAnnotation: userOriginsYAML,
+		CDNGroupAnnotation:      "group",
+	}
+
+	got, err := cdnIngressesForUserOrigins(ing)
+	s.NoError(err)
+
+	s.Len(got, 2)
+	s.True(got[0].DefaultBehavior)
+	s.False(got[1].DefaultBehavior)
+}
+
 func (s *userOriginSuite) Test_cdnIngressesForUserOrigins_InvalidAnnotationValue() {
 	testCases := []struct {
 		name            string
@@ -276,6 +422,48 @@ func (s *userOriginSuite) Test_cdnIngressesForUserOrigins_InvalidAnnotationValue
                                     - /foo/*
                                   originAccess: invalid`,
 		},
+		{
+			name: "Invalid behavior methods",
+			annotationValue: `
+                                - host: foo.com
+                                  behaviors:
+                                    - path: /foo
+                                      allowedMethods: [GET, POST]`,
+		},
+		{
+			name: "Invalid behavior viewer protocol policy",
+			annotationValue: `
+                                - host: foo.com
+                                  behaviors:
+                                    - path: /foo
+                                      viewerProtocolPolicy: http-only`,
+		},
+		{
+			name: "Invalid origin protocol",
+			annotationValue: `
+                                - host: foo.com
+                                  minSSLProtocol: TLSv1.3
+                                  behaviors:
+                                    - path: /foo`,
+		},
+		{
+			name: "Invalid origin path",
+			annotationValue: `
+                                - host: foo.com
+                                  originPath: assets/
+                                  behaviors:
+                                    - path: /foo`,
+		},
+		{
+			name: "Invalid failover",
+			annotationValue: `
+                                - host: foo.com
+                                  behaviors:
+                                    - path: /foo
+                                  failover:
+                                    host: bar.com
+                                    statusCodes: [501]`,
+		},
 	}
 
 	for _, tc := range testCases {
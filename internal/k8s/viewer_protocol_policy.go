@@ -0,0 +1,100 @@
+// Copyright (c) 2023 GPBR Participacoes LTDA.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package k8s
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+	networkingv1 "k8s.io/api/networking/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/Gympass/cdn-origin-controller/internal/strhelper"
+)
+
+const cfViewerProtocolPolicyAnnotation = "cdn-origin-controller.gympass.com/cf.viewer-protocol-policy"
+
+const (
+	ViewerProtocolPolicyAllowAll        = "allow-all"
+	ViewerProtocolPolicyHTTPSOnly       = "https-only"
+	ViewerProtocolPolicyRedirectToHTTPS = "redirect-to-https"
+)
+
+var validViewerProtocolPolicies = []string{
+	ViewerProtocolPolicyAllowAll,
+	ViewerProtocolPolicyHTTPSOnly,
+	ViewerProtocolPolicyRedirectToHTTPS,
+}
+
+// validateViewerProtocolPolicy returns an error if the policy is not empty nor one supported by CloudFront
+func validateViewerProtocolPolicy(policy string) error {
+	if len(policy) == 0 || strhelper.Contains(validViewerProtocolPolicies, policy) {
+		return nil
+	}
+	return fmt.Errorf("invalid viewer protocol policy %q. Valid values: %v", policy, validViewerProtocolPolicies)
+}
+
+// mergeViewerProtocolPolicies returns the policy configured by either p or other.
+// Returns an error if both configure different policies.
+func mergeViewerProtocolPolicies(p, other string) (string, error) {
+	if len(p) == 0 {
+		return other, nil
+	}
+	if len(other) > 0 && p != other {
+		return "", fmt.Errorf("viewer protocol policy informed twice with different values: %q and %q", p, other)
+	}
+	return p, nil
+}
+
+func viewerProtocolPoliciesByPath(obj client.Object) (map[string]string, error) {
+	policiesYAML, ok := obj.GetAnnotations()[cfViewerProtocolPolicyAnnotation]
+	if !ok {
+		return nil, nil
+	}
+
+	policies := make(map[string]string)
+	if err := yaml.Unmarshal([]byte(policiesYAML), &policies); err != nil {
+		return nil, fmt.Errorf("unmarshalling YAML: %v", err)
+	}
+	return policies, nil
+}
+
+// ValidateIngressViewerProtocolPolicies returns an error if the Ingress configures viewer protocol policies which are
+// invalid or for paths the Ingress doesn't have
+func ValidateIngressViewerProtocolPolicies(ing *networkingv1.Ingress) error {
+	policies, err := viewerProtocolPoliciesByPath(ing)
+	if err != nil {
+		return fmt.Errorf("parsing viewer protocol policies: %v", err)
+	}
+
+	ingPaths := ingressPaths(ing)
+	for path, policy := range policies {
+		if err := validateViewerProtocolPolicy(policy); err != nil {
+			return fmt.Errorf("invalid viewer protocol policy at path %q: %v", path, err)
+		}
+
+		if !strhelper.Contains(ingPaths, path) {
+			return fmt.Errorf("viewer protocol policy references a path %q that is not part of the Ingress' paths %v",
+				path, ingPaths)
+		}
+	}
+
+	return nil
+}
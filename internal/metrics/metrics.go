@@ -0,0 +1,170 @@
+// Copyright (c) 2023 GPBR Participacoes LTDA.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+// Package metrics holds the Prometheus metrics exposed by the controller, which are served
+// through the controller-runtime metrics endpoint
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const namespace = "cdn_origin_controller"
+
+const (
+	// UpdateApplied labels updates which were sent to AWS
+	UpdateApplied = "applied"
+	// UpdateSkipped labels updates which were not sent to AWS because the observed state already matched the desired one
+	UpdateSkipped = "skipped"
+)
+
+const (
+	// DriftReverted labels drifts which were reverted to the desired state
+	DriftReverted = "reverted"
+	// DriftReported labels drifts which were only reported
+	DriftReported = "reported"
+)
+
+const (
+	// OrphanedDistribution labels orphaned CloudFront distributions
+	OrphanedDistribution = "distribution"
+	// OrphanedOAC labels orphaned CloudFront origin access controls
+	OrphanedOAC = "oac"
+)
+
+const (
+	// ReconcileSucceeded labels reconciliations which finished without errors
+	ReconcileSucceeded = "success"
+	// ReconcileFailed labels reconciliations which finished with errors
+	ReconcileFailed = "failure"
+)
+
+// DistributionUpdates counts CloudFront distribution updates, by whether they were applied or skipped
+var DistributionUpdates = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "distribution_updates_total",
+		Help:      "Number of CloudFront distribution updates, by whether they were applied or skipped because nothing changed.",
+	},
+	[]string{"result"},
+)
+
+// Reconciliations counts reconciliations of CDN groups, by their outcome
+var Reconciliations = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "reconciliations_total",
+		Help:      "Number of reconciliations of CDN groups, by group and outcome.",
+	},
+	[]string{"group", "result"},
+)
+
+// AWSAPICallDuration observes the latency of AWS API calls, by service, operation and resulting error code
+var AWSAPICallDuration = prometheus.NewHistogramVec(
+	prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "aws_api_call_duration_seconds",
+		Help:      "Latency of AWS API calls, by service, operation and error code. Successful calls have an empty error code.",
+		Buckets:   prometheus.DefBuckets,
+	},
+	[]string{"service", "operation", "code"},
+)
+
+// DistributionDeploymentWaitDuration observes how long the controller waited for distributions to be deployed
+var DistributionDeploymentWaitDuration = prometheus.NewHistogram(
+	prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "distribution_deployment_wait_duration_seconds",
+		Help:      "Time spent waiting for CloudFront distributions to reach the Deployed status.",
+		Buckets:   prometheus.ExponentialBuckets(10, 2, 8), // from 10s to ~21min
+	},
+)
+
+// DistributionOrigins tracks the number of origins of each distribution, by group
+var DistributionOrigins = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "distribution_origins",
+		Help:      "Number of origins of the CloudFront distribution, by group.",
+	},
+	[]string{"group"},
+)
+
+// DistributionBehaviors tracks the number of cache behaviors of each distribution, by group
+var DistributionBehaviors = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "distribution_behaviors",
+		Help:      "Number of cache behaviors of the CloudFront distribution, by group.",
+	},
+	[]string{"group"},
+)
+
+// FailedIngresses tracks the number of Ingresses in Failed state, by CDNStatus
+var FailedIngresses = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "cdnstatus_failed_ingresses",
+		Help:      "Number of Ingresses in Failed state, by CDNStatus.",
+	},
+	[]string{"cdnstatus"},
+)
+
+// DistributionDrifts counts changes made to distributions outside of the controller, by group and whether they were reverted
+var DistributionDrifts = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "distribution_drifts_total",
+		Help:      "Number of times CloudFront distributions were found changed outside of the controller, by group and whether the changes were reverted or only reported.",
+	},
+	[]string{"group", "result"},
+)
+
+// OrphanedResources tracks the number of AWS resources owned by the controller whose group no longer exists, by type
+var OrphanedResources = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "orphaned_resources",
+		Help:      "Number of AWS resources owned by the controller whose group no longer exists in the cluster, by type. Only reported if garbage collection is enabled.",
+	},
+	[]string{"type"},
+)
+
+// DeleteGroup removes all metrics labeled with the given group, which should be called once a group no longer exists
+func DeleteGroup(group string) {
+	DistributionOrigins.DeleteLabelValues(group)
+	DistributionBehaviors.DeleteLabelValues(group)
+	FailedIngresses.DeleteLabelValues(group)
+	DistributionDrifts.DeletePartialMatch(prometheus.Labels{"group": group})
+}
+
+func init() {
+	ctrlmetrics.Registry.MustRegister(
+		DistributionUpdates,
+		Reconciliations,
+		AWSAPICallDuration,
+		DistributionDeploymentWaitDuration,
+		DistributionOrigins,
+		DistributionBehaviors,
+		FailedIngresses,
+		DistributionDrifts,
+		OrphanedResources,
+	)
+}
@@ -49,6 +49,11 @@ type AliasRepository interface {
 	Upsert(aliases Aliases) error
 	// Delete deletes Aliases on Route53
 	Delete(aliases Aliases) error
+	// DeleteOwned deletes the existing address records and ownership TXT values of Aliases on Route53, skipping
+	// entries not owned by the class. Unlike Delete, the target of the records doesn't need to be known.
+	DeleteOwned(aliases Aliases) error
+	// ReleaseOwnership removes the ownership TXT values of Aliases on Route53, keeping the address records
+	ReleaseOwnership(aliases Aliases) error
 }
 
 type repository struct {
@@ -72,6 +77,10 @@ func (r repository) Upsert(aliases Aliases) error {
 			return fmt.Errorf("fetching existing DNS records: %v", err)
 		}
 
+		if r.isUpToDate(aliases.Target, aliases.OwnershipTXTValue, e, existingRS) {
+			continue
+		}
+
 		var existingTXTRecords []*route53.ResourceRecord
 		if existingRS.txtRecord != nil {
 			existingTXTRecords = existingRS.txtRecord.ResourceRecords
@@ -81,6 +90,10 @@ func (r repository) Upsert(aliases Aliases) error {
 		changes = append(changes, r.newTXTChangeForUpsert(aliases.OwnershipTXTValue, e.Name, existingTXTRecords...))
 	}
 
+	if len(changes) == 0 {
+		return nil
+	}
+
 	return r.requestChanges(changes, aliases.HostedZoneID, "Upserting Alias for CloudFront distribution managed by cdn-origin-controller")
 }
 
@@ -115,6 +128,61 @@ func (r repository) Delete(aliases Aliases) error {
 	return r.requestChanges(changes, aliases.HostedZoneID, "Deleting Alias for CloudFront distribution managed by cdn-origin-controller")
 }
 
+func (r repository) DeleteOwned(aliases Aliases) error {
+	var changes []*route53.Change
+	for _, e := range aliases.Entries {
+		allRecordSets, err := r.resourceRecordSetsByEntry(aliases.HostedZoneID, e)
+		if err != nil {
+			return fmt.Errorf("fetching existing DNS records: %v", err)
+		}
+
+		recordSets := r.filterRecordSets(e, allRecordSets)
+		if recordSets.txtRecord == nil || !r.hasOwnershipValue(aliases.OwnershipTXTValue, recordSets.txtRecord.ResourceRecords) {
+			continue
+		}
+
+		for _, rs := range recordSets.addressRecords {
+			if rs.AliasTarget == nil {
+				continue
+			}
+			changes = append(changes, r.newAliasChange(aws.StringValue(rs.AliasTarget.DNSName), route53.ChangeActionDelete, e.Name, aws.StringValue(rs.Type)))
+		}
+		changes = append(changes, r.newTXTChangeForDelete(aliases.OwnershipTXTValue, e.Name, recordSets.txtRecord.ResourceRecords...))
+	}
+
+	if len(changes) == 0 {
+		return nil
+	}
+
+	return r.requestChanges(changes, aliases.HostedZoneID, "Deleting Alias for orphaned CloudFront distribution managed by cdn-origin-controller")
+}
+
+func (r repository) ReleaseOwnership(aliases Aliases) error {
+	var changes []*route53.Change
+	for _, e := range aliases.Entries {
+		txtRS, err := r.txtResourceRecordSetByEntry(aliases.HostedZoneID, e)
+		if err != nil {
+			return fmt.Errorf("fetching TXT record (%s): %v", e.Name, err)
+		}
+
+		if txtRS == nil || aws.StringValue(txtRS.Name) != e.Name || aws.StringValue(txtRS.Type) != route53.RRTypeTxt {
+			continue
+		}
+
+		if !r.hasOwnershipValue(aliases.OwnershipTXTValue, txtRS.ResourceRecords) {
+			continue
+		}
+
+		changes = append(changes, r.newTXTChangeForDelete(aliases.OwnershipTXTValue, e.Name, txtRS.ResourceRecords...))
+	}
+
+	if len(changes) == 0 {
+		return nil
+	}
+
+	return r.requestChanges(changes, aliases.HostedZoneID, "Releasing ownership of records of CloudFront distribution no longer managed by cdn-origin-controller")
+}
+
 func (r repository) requestChanges(changes []*route53.Change, hostedZoneID, comment string) error {
 	input := &route53.ChangeResourceRecordSetsInput{
 		ChangeBatch: &route53.ChangeBatch{
@@ -283,6 +351,16 @@ func (r repository) isOwnedByThisClass(ownershipTXTValue string, rec *route53.Re
 	return *rec.Value == ownershipTXTValue
 }
 
+// hasOwnershipValue returns whether any of the records is the ownership value of this class
+func (r repository) hasOwnershipValue(ownershipTXTValue string, records []*route53.ResourceRecord) bool {
+	for _, rec := range records {
+		if r.isOwnedByThisClass(ownershipTXTValue, rec) {
+			return true
+		}
+	}
+	return false
+}
+
 func (r repository) containsOwnershipRecord(records []*route53.ResourceRecord) bool {
 	for _, rec := range records {
 		if r.isOwnershipRecord(rec) {
@@ -296,6 +374,37 @@ func (r repository) isOwnershipRecord(record *route53.ResourceRecord) bool {
 	return strings.Contains(*record.Value, txtOwnerKey)
 }
 
+// isUpToDate returns whether all records of an entry already exist, pointing to the target and owned by this class
+func (r repository) isUpToDate(target, ownershipTXTValue string, entry Entry, existingRS filteredRecordSets) bool {
+	if existingRS.txtRecord == nil {
+		return false
+	}
+
+	if !r.hasOwnershipValue(ownershipTXTValue, existingRS.txtRecord.ResourceRecords) {
+		return false
+	}
+
+	for _, rType := range entry.Types {
+		if !r.hasAliasRecord(target, rType, existingRS.addressRecords) {
+			return false
+		}
+	}
+	return true
+}
+
+func (r repository) hasAliasRecord(target, rType string, recordSets []*route53.ResourceRecordSet) bool {
+	for _, rs := range recordSets {
+		if aws.StringValue(rs.Type) != rType || rs.AliasTarget == nil {
+			continue
+		}
+		if normalizeDomain(strings.ToLower(aws.StringValue(rs.AliasTarget.DNSName))) == normalizeDomain(strings.ToLower(target)) &&
+			aws.StringValue(rs.AliasTarget.HostedZoneId) == cfHostedZoneID {
+			return true
+		}
+	}
+	return false
+}
+
 func (r repository) newAliasChanges(target, action string, entry Entry) []*route53.Change {
 	var changes []*route53.Change
 	for _, rType := range entry.Types {
@@ -388,7 +388,7 @@ func (s *AliasRepositoryTestSuite) TestUpsert_RecordsExist_AlreadyOwnedByThisCla
 				Type: aws.String(awsroute53.RRTypeA),
 				TTL:  aws.Int64(300),
 				AliasTarget: &awsroute53.AliasTarget{
-					DNSName:              aws.String("target.foo.bar."),
+					DNSName:              aws.String("old.target.foo.bar."),
 					EvaluateTargetHealth: aws.Bool(false),
 					HostedZoneId:         aws.String(cfHostedZoneID),
 				},
@@ -466,6 +466,65 @@ func (s *AliasRepositoryTestSuite) TestUpsert_RecordsExist_AlreadyOwnedByThisCla
 	repo := route53.NewAliasRepository(mockClient)
 	aliases := route53.NewAliases("target.foo.bar.", "zone id", "owner value", []string{"alias.foo.bar."}, false)
 	s.NoError(repo.Upsert(aliases))
+	mockClient.AssertExpectations(s.T())
+}
+
+func (s *AliasRepositoryTestSuite) TestUpsert_RecordsExist_AlreadyUpToDate() {
+	mockClient := &awsClientMock{}
+
+	expectedListRRSInputForAddresses := &awsroute53.ListResourceRecordSetsInput{
+		HostedZoneId:    aws.String("zone id"),
+		StartRecordName: aws.String("alias.foo.bar."),
+		MaxItems:        aws.String(numberOfSupportedRecordTypes),
+	}
+	expectedListRRSOutputForAddresses := &awsroute53.ListResourceRecordSetsOutput{
+		ResourceRecordSets: []*awsroute53.ResourceRecordSet{
+			{
+				Name: aws.String("alias.foo.bar."),
+				Type: aws.String(awsroute53.RRTypeA),
+				TTL:  aws.Int64(300),
+				AliasTarget: &awsroute53.AliasTarget{
+					DNSName:              aws.String("target.foo.bar."),
+					EvaluateTargetHealth: aws.Bool(false),
+					HostedZoneId:         aws.String(cfHostedZoneID),
+				},
+			},
+		},
+	}
+	var noError error
+	mockClient.On("ListResourceRecordSets", expectedListRRSInputForAddresses).Return(noError).Once()
+	mockClient.ExpectedListRRSOutForAddressRecords = expectedListRRSOutputForAddresses
+
+	expectedListRRSInputForTXT := &awsroute53.ListResourceRecordSetsInput{
+		HostedZoneId:    aws.String("zone id"),
+		StartRecordName: aws.String("alias.foo.bar."),
+		MaxItems:        aws.String("1"),
+		StartRecordType: aws.String(awsroute53.RRTypeTxt),
+	}
+	expectedListRRSOutputForTXT := &awsroute53.ListResourceRecordSetsOutput{
+		ResourceRecordSets: []*awsroute53.ResourceRecordSet{
+			{
+				Name: aws.String("alias.foo.bar."),
+				Type: aws.String(awsroute53.RRTypeTxt),
+				TTL:  aws.Int64(300),
+				ResourceRecords: []*awsroute53.ResourceRecord{
+					{
+						Value: aws.String("some other value"),
+					},
+					{
+						Value: aws.String(`"cdn-origin-controller/owner=owner value"`),
+					},
+				},
+			},
+		},
+	}
+	mockClient.On("ListResourceRecordSets", expectedListRRSInputForTXT).Return(noError).Once()
+	mockClient.ExpectedListRSSOutForTXTRecord = expectedListRRSOutputForTXT
+
+	repo := route53.NewAliasRepository(mockClient)
+	aliases := route53.NewAliases("target.foo.bar.", "zone id", "owner value", []string{"alias.foo.bar."}, false)
+	s.NoError(repo.Upsert(aliases))
+	mockClient.AssertNotCalled(s.T(), "ChangeResourceRecordSets", mock.Anything)
 }
 
 func (s *AliasRepositoryTestSuite) TestDelete_NoEntriesOnAliases() {
@@ -759,3 +818,171 @@ func (s *AliasRepositoryTestSuite) TestDelete_RecordsExist_TXTNotOwnedByThisClas
 	s.Error(err)
 	s.Contains(err.Error(), "is managed by another CDN class")
 }
+
+func (s *AliasRepositoryTestSuite) TestReleaseOwnership_RemovesOnlyOwnershipTXTValue() {
+	mockClient := &awsClientMock{}
+
+	expectedListRRSInputForTXT := &awsroute53.ListResourceRecordSetsInput{
+		HostedZoneId:    aws.String("zone id"),
+		StartRecordName: aws.String("alias.foo.bar."),
+		MaxItems:        aws.String("1"),
+		StartRecordType: aws.String(awsroute53.RRTypeTxt),
+	}
+	mockClient.ExpectedListRSSOutForTXTRecord = &awsroute53.ListResourceRecordSetsOutput{
+		ResourceRecordSets: []*awsroute53.ResourceRecordSet{
+			{
+				Name: aws.String("alias.foo.bar."),
+				Type: aws.String(awsroute53.RRTypeTxt),
+				TTL:  aws.Int64(300),
+				ResourceRecords: []*awsroute53.ResourceRecord{
+					{Value: aws.String("some other value")},
+					{Value: aws.String(`"cdn-origin-controller/owner=owner value"`)},
+				},
+			},
+		},
+	}
+	var noError error
+	mockClient.On("ListResourceRecordSets", expectedListRRSInputForTXT).Return(noError).Once()
+
+	expectedChangeRRSInput := &awsroute53.ChangeResourceRecordSetsInput{
+		HostedZoneId: aws.String("zone id"),
+		ChangeBatch: &awsroute53.ChangeBatch{
+			Changes: []*awsroute53.Change{
+				{
+					Action: aws.String(awsroute53.ChangeActionUpsert),
+					ResourceRecordSet: &awsroute53.ResourceRecordSet{
+						Name:            aws.String("alias.foo.bar."),
+						Type:            aws.String(awsroute53.RRTypeTxt),
+						TTL:             aws.Int64(300),
+						ResourceRecords: []*awsroute53.ResourceRecord{{Value: aws.String("some other value")}},
+					},
+				},
+			},
+			Comment: aws.String("Releasing ownership of records of CloudFront distribution no longer managed by cdn-origin-controller"),
+		},
+	}
+	mockClient.On("ChangeResourceRecordSets", expectedChangeRRSInput).Return(noError).Once()
+
+	repo := route53.NewAliasRepository(mockClient)
+	aliases := route53.NewAliases("", "zone id", "owner value", []string{"alias.foo.bar."}, false)
+	s.NoError(repo.ReleaseOwnership(aliases))
+	mockClient.AssertExpectations(s.T())
+}
+
+func (s *AliasRepositoryTestSuite) TestReleaseOwnership_NotOwnedByThisClass() {
+	mockClient := &awsClientMock{}
+
+	mockClient.ExpectedListRSSOutForTXTRecord = &awsroute53.ListResourceRecordSetsOutput{
+		ResourceRecordSets: []*awsroute53.ResourceRecordSet{
+			{
+				Name: aws.String("alias.foo.bar."),
+				Type: aws.String(awsroute53.RRTypeTxt),
+				ResourceRecords: []*awsroute53.ResourceRecord{
+					{Value: aws.String(`"cdn-origin-controller/owner=another value"`)},
+				},
+			},
+		},
+	}
+	var noError error
+	mockClient.On("ListResourceRecordSets", mock.Anything).Return(noError).Once()
+
+	repo := route53.NewAliasRepository(mockClient)
+	aliases := route53.NewAliases("", "zone id", "owner value", []string{"alias.foo.bar."}, false)
+	s.NoError(repo.ReleaseOwnership(aliases))
+	mockClient.AssertNotCalled(s.T(), "ChangeResourceRecordSets", mock.Anything)
+}
+
+func (s *AliasRepositoryTestSuite) TestReleaseOwnership_TXTRecordDoesNotExist() {
+	mockClient := &awsClientMock{}
+
+	mockClient.ExpectedListRSSOutForTXTRecord = &awsroute53.ListResourceRecordSetsOutput{
+		ResourceRecordSets: []*awsroute53.ResourceRecordSet{
+			{
+				Name: aws.String("other.foo.bar."),
+				Type: aws.String(awsroute53.RRTypeTxt),
+				ResourceRecords: []*awsroute53.ResourceRecord{
+					{Value: aws.String(`"cdn-origin-controller/owner=owner value"`)},
+				},
+			},
+		},
+	}
+	var noError error
+	mockClient.On("ListResourceRecordSets", mock.Anything).Return(noError).Once()
+
+	repo := route53.NewAliasRepository(mockClient)
+	aliases := route53.NewAliases("", "zone id", "owner value", []string{"alias.foo.bar."}, false)
+	s.NoError(repo.ReleaseOwnership(aliases))
+	mockClient.AssertNotCalled(s.T(), "ChangeResourceRecordSets", mock.Anything)
+}
+
+func (s *AliasRepositoryTestSuite) TestDeleteOwned_DeletesRecordsOwnedByThisClass() {
+	mockClient := &awsClientMock{}
+
+	mockClient.ExpectedListRRSOutForAddressRecords = &awsroute53.ListResourceRecordSetsOutput{
+		ResourceRecordSets: []*awsroute53.ResourceRecordSet{
+			{
+				Name: aws.String("alias.foo.bar."),
+				Type: aws.String(awsroute53.RRTypeA),
+				AliasTarget: &awsroute53.AliasTarget{
+					DNSName:              aws.String("orphaned.cloudfront.net."),
+					HostedZoneId:         aws.String(cfHostedZoneID),
+					EvaluateTargetHealth: aws.Bool(false),
+				},
+			},
+		},
+	}
+	mockClient.ExpectedListRSSOutForTXTRecord = &awsroute53.ListResourceRecordSetsOutput{
+		ResourceRecordSets: []*awsroute53.ResourceRecordSet{
+			{
+				Name: aws.String("alias.foo.bar."),
+				Type: aws.String(awsroute53.RRTypeTxt),
+				TTL:  aws.Int64(300),
+				ResourceRecords: []*awsroute53.ResourceRecord{
+					{Value: aws.String(`"cdn-origin-controller/owner=owner value"`)},
+				},
+			},
+		},
+	}
+	var noError error
+	mockClient.On("ListResourceRecordSets", mock.Anything).Return(noError).Twice()
+
+	isExpectedDeletion := func(in *awsroute53.ChangeResourceRecordSetsInput) bool {
+		changes := in.ChangeBatch.Changes
+		return len(changes) == 2 &&
+			*changes[0].Action == awsroute53.ChangeActionDelete &&
+			*changes[0].ResourceRecordSet.Type == awsroute53.RRTypeA &&
+			*changes[0].ResourceRecordSet.AliasTarget.DNSName == "orphaned.cloudfront.net." &&
+			*changes[1].Action == awsroute53.ChangeActionDelete &&
+			*changes[1].ResourceRecordSet.Type == awsroute53.RRTypeTxt
+	}
+	mockClient.On("ChangeResourceRecordSets", mock.MatchedBy(isExpectedDeletion)).Return(noError).Once()
+
+	repo := route53.NewAliasRepository(mockClient)
+	aliases := route53.NewAliases("", "zone id", "owner value", []string{"alias.foo.bar."}, true)
+	s.NoError(repo.DeleteOwned(aliases))
+	mockClient.AssertExpectations(s.T())
+}
+
+func (s *AliasRepositoryTestSuite) TestDeleteOwned_NotOwnedByThisClass() {
+	mockClient := &awsClientMock{}
+
+	mockClient.ExpectedListRRSOutForAddressRecords = &awsroute53.ListResourceRecordSetsOutput{}
+	mockClient.ExpectedListRSSOutForTXTRecord = &awsroute53.ListResourceRecordSetsOutput{
+		ResourceRecordSets: []*awsroute53.ResourceRecordSet{
+			{
+				Name: aws.String("alias.foo.bar."),
+				Type: aws.String(awsroute53.RRTypeTxt),
+				ResourceRecords: []*awsroute53.ResourceRecord{
+					{Value: aws.String(`"cdn-origin-controller/owner=another value"`)},
+				},
+			},
+		},
+	}
+	var noError error
+	mockClient.On("ListResourceRecordSets", mock.Anything).Return(noError).Twice()
+
+	repo := route53.NewAliasRepository(mockClient)
+	aliases := route53.NewAliases("", "zone id", "owner value", []string{"alias.foo.bar."}, true)
+	s.NoError(repo.DeleteOwned(aliases))
+	mockClient.AssertNotCalled(s.T(), "ChangeResourceRecordSets", mock.Anything)
+}
@@ -38,6 +38,10 @@ type MockCloudFrontAPI struct {
 	ExpectedUpdateOriginAccessControlOutput  *cloudfront.UpdateOriginAccessControlOutput
 	ExpectedDeleteOriginAccessControlOutput  *cloudfront.DeleteOriginAccessControlOutput
 	ExpectedGetOriginAccessControlOutput     *cloudfront.GetOriginAccessControlOutput
+	ExpectedListTagsForResourceOutput        *cloudfront.ListTagsForResourceOutput
+	ExpectedCreateInvalidationOutput         *cloudfront.CreateInvalidationOutput
+	ExpectedGetInvalidationOutput            *cloudfront.GetInvalidationOutput
+	ExpectedListDistributionsPages           []*cloudfront.ListDistributionsOutput
 }
 
 func (c *MockCloudFrontAPI) GetDistributionConfig(in *cloudfront.GetDistributionConfigInput) (*cloudfront.GetDistributionConfigOutput, error) {
@@ -64,6 +68,21 @@ func (c *MockCloudFrontAPI) TagResource(in *cloudfront.TagResourceInput) (*cloud
 	return c.ExpectedTagResourceOutput, args.Error(0)
 }
 
+func (c *MockCloudFrontAPI) UntagResource(in *cloudfront.UntagResourceInput) (*cloudfront.UntagResourceOutput, error) {
+	args := c.Called(in)
+	return &cloudfront.UntagResourceOutput{}, args.Error(0)
+}
+
+func (c *MockCloudFrontAPI) ListDistributionsPages(in *cloudfront.ListDistributionsInput, fn func(*cloudfront.ListDistributionsOutput, bool) bool) error {
+	args := c.Called(in)
+	for i, page := range c.ExpectedListDistributionsPages {
+		if !fn(page, i == len(c.ExpectedListDistributionsPages)-1) {
+			break
+		}
+	}
+	return args.Error(0)
+}
+
 func (c *MockCloudFrontAPI) DeleteDistribution(in *cloudfront.DeleteDistributionInput) (*cloudfront.DeleteDistributionOutput, error) {
 	args := c.Called(in)
 	return nil, args.Error(0)
@@ -88,3 +107,21 @@ func (c *MockCloudFrontAPI) GetOriginAccessControl(in *cloudfront.GetOriginAcces
 	args := c.Called(in)
 	return c.ExpectedGetOriginAccessControlOutput, args.Error(0)
 }
+
+func (c *MockCloudFrontAPI) ListTagsForResource(in *cloudfront.ListTagsForResourceInput) (*cloudfront.ListTagsForResourceOutput, error) {
+	args := c.Called(in)
+	if c.ExpectedListTagsForResourceOutput == nil {
+		return &cloudfront.ListTagsForResourceOutput{Tags: &cloudfront.Tags{}}, args.Error(0)
+	}
+	return c.ExpectedListTagsForResourceOutput, args.Error(0)
+}
+
+func (c *MockCloudFrontAPI) CreateInvalidation(in *cloudfront.CreateInvalidationInput) (*cloudfront.CreateInvalidationOutput, error) {
+	args := c.Called(in)
+	return c.ExpectedCreateInvalidationOutput, args.Error(0)
+}
+
+func (c *MockCloudFrontAPI) GetInvalidation(in *cloudfront.GetInvalidationInput) (*cloudfront.GetInvalidationOutput, error) {
+	args := c.Called(in)
+	return c.ExpectedGetInvalidationOutput, args.Error(0)
+}
@@ -28,6 +28,7 @@ import (
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/acm"
 	awscloudfront "github.com/aws/aws-sdk-go/service/cloudfront"
+	"github.com/aws/aws-sdk-go/service/cloudfront/cloudfrontiface"
 	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi"
 	awsroute53 "github.com/aws/aws-sdk-go/service/route53"
 	"github.com/joho/godotenv"
@@ -47,6 +48,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 
 	cdnv1alpha1 "github.com/Gympass/cdn-origin-controller/api/v1alpha1"
+	cdnaws "github.com/Gympass/cdn-origin-controller/internal/aws"
 	"github.com/Gympass/cdn-origin-controller/internal/certificate"
 	"github.com/Gympass/cdn-origin-controller/internal/k8s"
 
@@ -135,6 +137,7 @@ func main() {
 
 func mustSetupControllers(mgr manager.Manager, cfg config.Config) {
 	s := session.Must(session.NewSession())
+	cdnaws.InstrumentAPICalls(&s.Handlers)
 
 	cfClient := awscloudfront.New(s)
 
@@ -143,7 +146,6 @@ func mustSetupControllers(mgr manager.Manager, cfg config.Config) {
 		OACRepo:          cloudfront.NewOACRepository(cfClient, cloudfront.NewOACLister(cfClient), cfg),
 		TaggingClient:    resourcegroupstaggingapi.New(s),
 		CallerRef:        func() string { return time.Now().String() },
-		WaitTimeout:      time.Minute * 10,
 		Cfg:              cfg,
 	}
 	distRepo.RunPostCreationOperations = distRepo.Sync
@@ -162,8 +164,27 @@ func mustSetupControllers(mgr manager.Manager, cfg config.Config) {
 	const ingressVersionAvailableMsg = " Ingress available, setting up its controller. Other versions will not be tried."
 
 	setupLog.V(1).Info(networkingv1.SchemeGroupVersion.String() + ingressVersionAvailableMsg)
-	cfService.Fetcher = k8s.NewIngressFetcherV1(mgr.GetClient())
+	cfService.Fetcher = k8s.NewAggregateFetcher(
+		k8s.NewIngressFetcherV1(mgr.GetClient()),
+		k8s.NewDistributionFetcher(mgr.GetClient()),
+	)
 	mustSetupV1Controller(mgr, cfService)
+	mustSetupDistributionController(mgr, cfService)
+	mustSetupInvalidationController(mgr, cfClient)
+	mustSetupRolloutController(mgr)
+	mustSetupCDNClassController(mgr)
+
+	if cfg.DriftDetectionInterval > 0 {
+		mustSetupDriftDetector(mgr, cfService, cfg)
+	}
+
+	if cfg.GarbageCollectionInterval > 0 {
+		mustSetupGarbageCollector(mgr, cfService, distRepo.OACRepo, cfg)
+	}
+
+	if cfg.WebhookEnabled {
+		mustSetupIngressWebhook(mgr, cfService.Fetcher)
+	}
 }
 
 func mustSetupV1Controller(mgr manager.Manager, ir *cloudfront.Service) {
@@ -179,6 +200,100 @@ func mustSetupV1Controller(mgr manager.Manager, ir *cloudfront.Service) {
 	}
 }
 
+func mustSetupDistributionController(mgr manager.Manager, svc *cloudfront.Service) {
+	distReconciler := controllers.DistributionReconciler{
+		Client:            mgr.GetClient(),
+		CloudFrontService: svc,
+		CDNClassFetcher:   k8s.NewCDNClassFetcher(mgr.GetClient()),
+	}
+
+	if err := distReconciler.SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to set up distribution controller")
+		os.Exit(1)
+	}
+}
+
+func mustSetupInvalidationController(mgr manager.Manager, cfClient cloudfrontiface.CloudFrontAPI) {
+	invReconciler := controllers.InvalidationReconciler{
+		Client: mgr.GetClient(),
+		InvalidationService: &cloudfront.InvalidationService{
+			Client:   mgr.GetClient(),
+			Recorder: mgr.GetEventRecorderFor("cdn-origin-controller"),
+			Repo:     cloudfront.NewInvalidationRepository(cfClient),
+		},
+	}
+
+	if err := invReconciler.SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to set up invalidation controller")
+		os.Exit(1)
+	}
+}
+
+func mustSetupRolloutController(mgr manager.Manager) {
+	rolloutReconciler := controllers.RolloutReconciler{
+		Client: mgr.GetClient(),
+		RolloutService: &cloudfront.RolloutService{
+			Client:   mgr.GetClient(),
+			Recorder: mgr.GetEventRecorderFor("cdn-origin-controller"),
+		},
+	}
+
+	if err := rolloutReconciler.SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to set up rollout controller")
+		os.Exit(1)
+	}
+}
+
+func mustSetupCDNClassController(mgr manager.Manager) {
+	classReconciler := controllers.CDNClassReconciler{
+		Client:         mgr.GetClient(),
+		StatusReporter: k8s.NewCDNClassStatusReporter(mgr.GetClient()),
+	}
+
+	if err := classReconciler.SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to set up cdn class controller")
+		os.Exit(1)
+	}
+}
+
+func mustSetupDriftDetector(mgr manager.Manager, svc *cloudfront.Service, cfg config.Config) {
+	detector := &cloudfront.DriftDetector{
+		Service:         svc,
+		CDNClassFetcher: k8s.NewCDNClassFetcher(mgr.GetClient()),
+		Interval:        cfg.DriftDetectionInterval,
+		ReportOnly:      cfg.DriftDetectionReportOnly,
+	}
+
+	if err := mgr.Add(detector); err != nil {
+		setupLog.Error(err, "unable to set up drift detector")
+		os.Exit(1)
+	}
+}
+
+func mustSetupGarbageCollector(mgr manager.Manager, svc *cloudfront.Service, oacRepo cloudfront.OACRepository, cfg config.Config) {
+	gc := &cloudfront.GarbageCollector{
+		Service:  svc,
+		OACRepo:  oacRepo,
+		Interval: cfg.GarbageCollectionInterval,
+	}
+
+	if err := mgr.Add(gc); err != nil {
+		setupLog.Error(err, "unable to set up garbage collector")
+		os.Exit(1)
+	}
+}
+
+func mustSetupIngressWebhook(mgr manager.Manager, fetcher k8s.IngressFetcher) {
+	err := ctrl.NewWebhookManagedBy(mgr).
+		For(&networkingv1.Ingress{}).
+		WithValidator(&k8s.IngressValidator{Fetcher: fetcher}).
+		Complete()
+	if err != nil {
+		setupLog.Error(err, "unable to set up ingress validating webhook")
+		os.Exit(1)
+	}
+}
+
 func mustGetLogLevel(logLvl string) zapcore.Level {
 	var l zapcore.Level
 	if err := l.Set(logLvl); err != nil {